@@ -20,12 +20,24 @@ package catalog
 import (
 	"context"
 	"crypto/tls"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/table"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/uuid"
 )
 
 type CatalogType string
@@ -43,8 +55,56 @@ var (
 	ErrNoSuchTable            = errors.New("table does not exist")
 	ErrNoSuchNamespace        = errors.New("namespace does not exist")
 	ErrNamespaceAlreadyExists = errors.New("namespace already exists")
+	// ErrNamespaceNotEmpty is returned when dropping a namespace which
+	// still contains tables or child namespaces.
+	ErrNamespaceNotEmpty = errors.New("namespace is not empty")
+	// ErrTableAlreadyExists is returned when creating a table which
+	// already exists in the catalog.
+	ErrTableAlreadyExists = errors.New("table already exists")
+	// ErrNoSuchView is returned when a view does not exist in the catalog.
+	ErrNoSuchView = errors.New("view does not exist")
 )
 
+// NotFoundError is returned when a table or namespace doesn't exist. It
+// wraps ErrNoSuchTable or ErrNoSuchNamespace along with the underlying
+// error from the catalog service.
+type NotFoundError struct {
+	Err error
+}
+
+func (e *NotFoundError) Error() string { return e.Err.Error() }
+func (e *NotFoundError) Unwrap() error { return e.Err }
+
+// ValidationError is returned when the catalog rejects a request as
+// invalid. Sending the same request again will fail in the same way.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string { return "validation failed: " + e.Err.Error() }
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// CommitFailedError is returned when a commit was rejected because the
+// table changed concurrently and its requirements no longer hold. Nothing
+// was committed, so the commit can be retried against the refreshed table.
+type CommitFailedError struct {
+	Err error
+}
+
+func (e *CommitFailedError) Error() string { return "commit failed: " + e.Err.Error() }
+func (e *CommitFailedError) Unwrap() error { return e.Err }
+
+// CommitStateUnknownError is returned when it isn't known whether a
+// commit was applied, such as when the connection failed after the request
+// was sent. The commit must not be retried blindly, the table should be
+// reloaded to check whether the changes were applied.
+type CommitStateUnknownError struct {
+	Err error
+}
+
+func (e *CommitStateUnknownError) Error() string { return "commit state unknown: " + e.Err.Error() }
+func (e *CommitStateUnknownError) Unwrap() error { return e.Err }
+
 // WithAwsConfig sets the AWS configuration for the catalog.
 func WithAwsConfig(cfg aws.Config) Option[GlueCatalog] {
 	return func(o *options) {
@@ -129,13 +189,20 @@ type options struct {
 	sigv4Service      string
 	prefix            string
 	authUri           *url.URL
+	metricsReporting  bool
+	clock             table.Clock
+	endpoints         []RestEndpoint
+	endpointRetries   int
+	tableUUIDs        func(table.Identifier) uuid.UUID
+	locationTemplate  func(namespace table.Identifier, name string) string
+	inheritedProps    []string
+	logger            *slog.Logger
+	requestTimeout    time.Duration
+	operationTimeout  time.Duration
+	extraHeaders      http.Header
 }
 
-type PropertiesUpdateSummary struct {
-	Removed []string `json:"removed"`
-	Updated []string `json:"updated"`
-	Missing []string `json:"missing"`
-}
+type PropertiesUpdateSummary = table.PropertiesUpdateSummary
 
 // Catalog for iceberg table operations like create, drop, load, list and others.
 type Catalog interface {
@@ -145,8 +212,19 @@ type Catalog interface {
 	// ListTables returns a list of table identifiers in the catalog, with the returned
 	// identifiers containing the information required to load the table via that catalog.
 	ListTables(ctx context.Context, namespace table.Identifier) ([]table.Identifier, error)
+	// CreateTable creates a new table in the catalog with the given schema,
+	// configured by the options, and returns the created table.
+	CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) (*table.Table, error)
 	// LoadTable loads a table from the catalog and returns a Table with the metadata.
 	LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error)
+	// CommitTable applies the updates to the table if the requirements hold
+	// for its current metadata, and returns the new metadata along with its
+	// location. If a requirement doesn't hold, a CommitFailedError is
+	// returned.
+	CommitTable(ctx context.Context, identifier table.Identifier, requirements []table.Requirement, updates []table.Update) (table.Metadata, string, error)
+	// TableExists returns whether the table exists in the catalog without
+	// loading its metadata. An error is only returned if the check failed.
+	TableExists(ctx context.Context, identifier table.Identifier) (bool, error)
 	// DropTable tells the catalog to drop the table entirely
 	DropTable(ctx context.Context, identifier table.Identifier) error
 	// RenameTable tells the catalog to rename a given table by the identifiers
@@ -155,9 +233,14 @@ type Catalog interface {
 	// ListNamespaces returns the list of available namespaces, optionally filtering by a
 	// parent namespace
 	ListNamespaces(ctx context.Context, parent table.Identifier) ([]table.Identifier, error)
+	// NamespaceExists returns whether the namespace exists in the catalog.
+	// An error is only returned if the check failed.
+	NamespaceExists(ctx context.Context, namespace table.Identifier) (bool, error)
 	// CreateNamespace tells the catalog to create a new namespace with the given properties
 	CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error
-	// DropNamespace tells the catalog to drop the namespace and all tables in that namespace
+	// DropNamespace tells the catalog to drop an empty namespace. If the namespace
+	// still contains tables or child namespaces, an error wrapping ErrNamespaceNotEmpty
+	// is returned, use DropNamespaceCascade to drop a namespace and its contents.
 	DropNamespace(ctx context.Context, namespace table.Identifier) error
 	// LoadNamespaceProperties returns the current properties in the catalog for
 	// a given namespace
@@ -185,3 +268,726 @@ func TableNameFromIdent(ident table.Identifier) string {
 func NamespaceFromIdent(ident table.Identifier) table.Identifier {
 	return ident[:len(ident)-1]
 }
+
+// WithLogger sets the logger for the catalog's requests, retries and
+// commits, which are logged at debug level, and for failures which are
+// logged as warnings. Tokens and credentials are never logged. Nothing is
+// logged by default.
+func WithLogger[T GlueCatalog | RestCatalog](logger *slog.Logger) Option[T] {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRequestTimeout limits how long a single request to the catalog may
+// take, so that a server which stalls can't block the caller forever. It's
+// applied through the request's context, so a shorter deadline set by the
+// caller still takes precedence.
+//
+// For the REST catalog the timeout applies to each attempt, and idempotent
+// requests which time out are retried like other transient failures, see
+// WithOperationTimeout to limit the total time across retries. The Glue
+// catalog leaves retries to the AWS SDK, so the timeout applies to each
+// catalog call unless an operation timeout is set. No timeout is applied
+// by default.
+func WithRequestTimeout[T GlueCatalog | RestCatalog](d time.Duration) Option[T] {
+	return func(o *options) {
+		o.requestTimeout = d
+	}
+}
+
+// WithOperationTimeout limits the total time a catalog call may take,
+// including any retries and failovers to other endpoints. Like
+// WithRequestTimeout, a shorter deadline set by the caller takes
+// precedence. No timeout is applied by default.
+func WithOperationTimeout[T GlueCatalog | RestCatalog](d time.Duration) Option[T] {
+	return func(o *options) {
+		o.operationTimeout = d
+	}
+}
+
+// withTimeout returns a context which expires after d, or ctx itself if d
+// isn't positive. The parent's deadline is kept if it's earlier.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// discardHandler is a slog.Handler which drops all records, used when no
+// logger is configured.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// loggerOrDiscard returns the logger, or one which discards everything if
+// it's nil.
+func loggerOrDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(discardHandler{})
+	}
+	return logger
+}
+
+// WithClock sets the clock used for the timestamps of metadata changes to
+// tables loaded from the catalog, which defaults to the wall clock.
+func WithClock[T GlueCatalog | RestCatalog](c table.Clock) Option[T] {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithTableUUIDGenerator sets a function which returns the UUID for each
+// table created with the catalog, such as a name-based UUID derived from
+// the identifier. WithTableUUID takes precedence for a single table. By
+// default the catalog assigns a random UUID.
+func WithTableUUIDGenerator(fn func(table.Identifier) uuid.UUID) Option[RestCatalog] {
+	return func(o *options) {
+		o.tableUUIDs = fn
+	}
+}
+
+// WithLocationTemplate sets a function which returns the location of each
+// table created with the catalog without an explicit location, such as
+// one built with LocationTemplate. By default the catalog chooses the
+// location of new tables.
+func WithLocationTemplate(fn func(namespace table.Identifier, name string) string) Option[RestCatalog] {
+	return func(o *options) {
+		o.locationTemplate = fn
+	}
+}
+
+// LocationTemplate returns a function for WithLocationTemplate which
+// expands the {namespace} and {table} placeholders in tmpl with the
+// namespace and name of the table. The levels of a multi-level namespace
+// are joined with "/", for example "s3://bucket/{namespace}/{table}"
+// places the table a.b.t at "s3://bucket/a/b/t".
+func LocationTemplate(tmpl string) func(namespace table.Identifier, name string) string {
+	return func(namespace table.Identifier, name string) string {
+		return strings.NewReplacer(
+			"{namespace}", strings.Join(namespace, "/"),
+			"{table}", name,
+		).Replace(tmpl)
+	}
+}
+
+// InheritableNamespaceProperties are the namespace properties which new
+// tables inherit by default with WithNamespacePropertyInheritance. They're
+// table defaults, unlike other namespace properties such as the location
+// or owner of the namespace.
+var InheritableNamespaceProperties = []string{
+	"write.format.default",
+	"write.target-file-size-bytes",
+	"write.parquet.compression-codec",
+	"write.parquet.compression-level",
+	"write.parquet.row-group-size-bytes",
+	"write.parquet.page-size-bytes",
+	"write.avro.compression-codec",
+	"write.orc.compression-codec",
+	"write.metadata.compression-codec",
+	"write.distribution-mode",
+	"commit.retry.num-retries",
+}
+
+// WithNamespacePropertyInheritance makes tables created with the catalog
+// inherit the given properties of their namespace, or those listed in
+// InheritableNamespaceProperties if none are given. Properties set with
+// WithProperties take precedence over the inherited ones.
+func WithNamespacePropertyInheritance(keys ...string) Option[RestCatalog] {
+	if len(keys) == 0 {
+		keys = InheritableNamespaceProperties
+	}
+	return func(o *options) {
+		o.inheritedProps = slices.Clone(keys)
+	}
+}
+
+// inheritProperties returns the properties of a new table, with the
+// inheritable namespace properties under the table's own properties.
+func inheritProperties(keys []string, nsProps, tblProps iceberg.Properties) iceberg.Properties {
+	props := iceberg.Properties{}
+	for _, k := range keys {
+		if v, ok := nsProps[k]; ok {
+			props[k] = v
+		}
+	}
+	maps.Copy(props, tblProps)
+	return props
+}
+
+// WithMetricsReporting enables sending scan reports to the REST catalog's
+// metrics endpoint, if the server advertises support for it.
+func WithMetricsReporting(enabled bool) Option[RestCatalog] {
+	return func(o *options) {
+		o.metricsReporting = enabled
+	}
+}
+
+// RestEndpoint is an additional base URI for a REST catalog which is
+// served from multiple regions.
+type RestEndpoint struct {
+	URI string
+	// SigV4Region overrides the signing region used for requests to this
+	// endpoint when SigV4 signing is enabled.
+	SigV4Region string
+}
+
+// WithEndpoints adds endpoints for the REST catalog to fail over to when
+// the catalog's URI can't be reached or responds with a server error.
+// Endpoints are tried in the order given, except that an endpoint which
+// failed recently is tried after the others.
+func WithEndpoints(endpoints ...RestEndpoint) Option[RestCatalog] {
+	return func(o *options) {
+		o.endpoints = append(o.endpoints, endpoints...)
+	}
+}
+
+// WithEndpointRetries sets the number of attempts made to send a request
+// to an endpoint before failing over to the next one. Defaults to 1.
+// Attempts are spaced out by an exponential backoff with jitter, or by
+// the delay a 503 response asks for with its Retry-After header.
+func WithEndpointRetries(n int) Option[RestCatalog] {
+	return func(o *options) {
+		o.endpointRetries = n
+	}
+}
+
+// WithExtraHeaders adds headers to every request the REST catalog sends,
+// including the OAuth token exchange and the config request, for example
+// a tenant header required by an API gateway. The Authorization header
+// and the X-Amz- headers used for SigV4 signing are reserved, and are
+// never set from hdrs. Use ContextWithHeaders for headers which are
+// specific to a call.
+func WithExtraHeaders(hdrs http.Header) Option[RestCatalog] {
+	return func(o *options) {
+		if o.extraHeaders == nil {
+			o.extraHeaders = http.Header{}
+		}
+		for k, v := range hdrs {
+			o.extraHeaders[http.CanonicalHeaderKey(k)] = slices.Clone(v)
+		}
+	}
+}
+
+// CreateTableCfg is the configuration of a table created with CreateTable.
+type CreateTableCfg struct {
+	Location      string
+	PartitionSpec *iceberg.PartitionSpec
+	SortOrder     table.SortOrder
+	Properties    iceberg.Properties
+	// FormatVersion is the format version of the table, zero means
+	// table.DefaultFormatVersion.
+	FormatVersion int
+	// CloneFrom is the table to shallow clone, if any.
+	CloneFrom *table.Table
+	// TableUUID is the UUID of the table, uuid.Nil means the catalog
+	// assigns one.
+	TableUUID uuid.UUID
+}
+
+// CreateTableOpt is an option for CreateTable.
+type CreateTableOpt func(*CreateTableCfg)
+
+// WithLocation sets the base location of the new table, otherwise the
+// catalog chooses a location.
+func WithLocation(loc string) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		cfg.Location = loc
+	}
+}
+
+// WithPartitionSpec sets the partition spec of the new table, which is
+// unpartitioned by default.
+func WithPartitionSpec(spec *iceberg.PartitionSpec) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		cfg.PartitionSpec = spec
+	}
+}
+
+// WithSortOrder sets the sort order of the new table, which is unsorted
+// by default. An order without fields is the unsorted order, and is
+// created with the unsorted order ID whatever its ID.
+func WithSortOrder(order table.SortOrder) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		cfg.SortOrder = order
+	}
+}
+
+// WithProperties sets properties of the new table.
+func WithProperties(props iceberg.Properties) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		if cfg.Properties == nil {
+			cfg.Properties = iceberg.Properties{}
+		}
+		maps.Copy(cfg.Properties, props)
+	}
+}
+
+// WithFormatVersion sets the format version of the new table, which must
+// be between 1 and 3. Tables are created with table.DefaultFormatVersion
+// if this isn't set.
+func WithFormatVersion(v int) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		cfg.FormatVersion = v
+	}
+}
+
+// WithTableUUID sets the UUID of the new table rather than letting the
+// catalog assign a random one, for example to use a UUID derived from the
+// table's name. Table creation fails if the table already exists.
+func WithTableUUID(id uuid.UUID) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		cfg.TableUUID = id
+	}
+}
+
+// WithCloneFrom creates the new table as a shallow clone of src. The new
+// table has the schema, partition spec and sort order of src, unless later
+// options change them, and a current snapshot which references the data
+// files of the current snapshot of src without copying them. The schema
+// passed to CreateTable may be nil, in which case the schema of src is
+// used.
+//
+// As the data files are shared rather than copied, the clone breaks if
+// they are deleted from src, such as by expiring snapshots or purging the
+// table. Copying the data files for a deep clone is not supported, and
+// cloning a table whose partition spec has evolved is not supported.
+func WithCloneFrom(src *table.Table) CreateTableOpt {
+	return func(cfg *CreateTableCfg) {
+		spec := src.Spec()
+		cfg.CloneFrom = src
+		cfg.PartitionSpec = &spec
+		cfg.SortOrder = src.SortOrder()
+		cfg.FormatVersion = src.Metadata().Version()
+	}
+}
+
+// newCreateTableCfg applies the options and validates the resulting
+// configuration against the schema, by building the metadata the table
+// would be created with. The format version is returned as part of the
+// properties, which is how it's requested from a catalog.
+func newCreateTableCfg(schema *iceberg.Schema, opts []CreateTableOpt) (CreateTableCfg, *iceberg.Schema, error) {
+	cfg := CreateTableCfg{
+		PartitionSpec: iceberg.UnpartitionedSpec,
+		SortOrder:     table.UnsortedSortOrder,
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	if cfg.SortOrder.IsUnsorted() {
+		cfg.SortOrder = table.UnsortedSortOrder
+	}
+
+	if src := cfg.CloneFrom; src != nil {
+		if schema == nil {
+			schema = src.Schema()
+		}
+		if err := checkCloneCompatible(src, schema, cfg.PartitionSpec, cfg.FormatVersion); err != nil {
+			return cfg, nil, err
+		}
+	}
+
+	props := maps.Clone(cfg.Properties)
+	if props == nil {
+		props = iceberg.Properties{}
+	}
+	if cfg.FormatVersion != 0 {
+		props[table.PropertyFormatVersion] = strconv.Itoa(cfg.FormatVersion)
+	}
+	cfg.Properties = props
+
+	if _, err := table.NewMetadata(schema, cfg.PartitionSpec, cfg.SortOrder,
+		cfg.Location, cfg.Properties); err != nil {
+		return cfg, nil, err
+	}
+	return cfg, schema, nil
+}
+
+// checkCloneCompatible returns an error if a shallow clone of src with the
+// given schema and spec couldn't read the data files of src.
+func checkCloneCompatible(src *table.Table, schema *iceberg.Schema, spec *iceberg.PartitionSpec, formatVersion int) error {
+	if formatVersion == 0 {
+		formatVersion = table.DefaultFormatVersion
+	}
+	if formatVersion < src.Metadata().Version() {
+		return fmt.Errorf("%w: cannot clone a format version %d table as format version %d",
+			table.ErrInvalidMetadataFormatVersion, src.Metadata().Version(), formatVersion)
+	}
+
+	if len(src.Metadata().PartitionSpecs()) > 1 {
+		return fmt.Errorf("%w: cloning a table with more than one partition spec",
+			iceberg.ErrNotImplemented)
+	}
+
+	if !schema.Equals(src.Schema()) {
+		return fmt.Errorf("%w: schema of a clone must match the schema of the source table",
+			iceberg.ErrInvalidArgument)
+	}
+
+	srcSpec := src.Spec()
+	if !srcSpec.CompatibleWith(spec) {
+		return fmt.Errorf("%w: partition spec of a clone must match the spec of the source table",
+			iceberg.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// stagedCreateUpdates returns the updates which commit the staged
+// metadata of a new table, assigning the table UUID from cfg if one is
+// set and adding a snapshot for a shallow clone.
+func stagedCreateUpdates(staged table.Metadata, cfg CreateTableCfg, clock table.Clock) ([]table.Update, error) {
+	id := staged.TableUUID()
+	if cfg.TableUUID != uuid.Nil {
+		id = cfg.TableUUID
+	}
+
+	spec, order := staged.PartitionSpec(), staged.SortOrder()
+	updates := []table.Update{
+		table.NewAssignUUIDUpdate(id),
+		table.NewUpgradeFormatVersionUpdate(staged.Version()),
+		table.NewAddSchemaUpdate(staged.CurrentSchema(), staged.LastColumnID()),
+		table.NewSetCurrentSchemaUpdate(-1),
+		table.NewAddPartitionSpecUpdate(&spec),
+		table.NewSetDefaultSpecUpdate(-1),
+		table.NewAddSortOrderUpdate(&order),
+		table.NewSetDefaultSortOrderUpdate(-1),
+		table.NewSetLocationUpdate(staged.Location()),
+		table.NewSetPropertiesUpdate(staged.Properties()),
+	}
+
+	if cfg.CloneFrom != nil {
+		if current := cfg.CloneFrom.CurrentSnapshot(); current != nil {
+			schemaID := staged.CurrentSchema().ID
+			snapshot := table.Snapshot{
+				SnapshotID: newSnapshotID(),
+				// manifests keep the sequence numbers they were written
+				// with, so the clone continues from the source's
+				SequenceNumber: current.SequenceNumber,
+				TimestampMs:    clock.Now().UnixMilli(),
+				ManifestList:   current.ManifestList,
+				Summary:        &table.Summary{Operation: table.OpAppend, Properties: map[string]string{}},
+				SchemaID:       &schemaID,
+			}
+			if current.Summary != nil {
+				maps.Copy(snapshot.Summary.Properties, current.Summary.Properties)
+			}
+
+			updates = append(updates, table.NewAddSnapshotUpdate(&snapshot),
+				table.NewSetSnapshotRefUpdate(table.MainBranch,
+					table.SnapshotRef{SnapshotID: snapshot.SnapshotID, SnapshotRefType: table.BranchRef}))
+		}
+	}
+
+	// check the updates produce valid metadata before sending them
+	b, err := table.NewMetadataBuilder(staged.Version())
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range updates {
+		if err := u.Apply(b); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := b.Build(); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// newSnapshotID returns a random, positive snapshot ID.
+func newSnapshotID() int64 {
+	id := uuid.New()
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	return int64((hi ^ lo) & math.MaxInt64)
+}
+
+// TablePurger is implemented by catalogs which can drop a table and also
+// delete its data and metadata files.
+type TablePurger interface {
+	PurgeTable(ctx context.Context, identifier table.Identifier) error
+}
+
+// DropNamespaceCascade drops the namespace after dropping every table in it
+// and, recursively, every child namespace and its tables. If purge is true,
+// tables are dropped with PurgeTable which requires the catalog to implement
+// TablePurger. The identifiers of the dropped tables are returned, including
+// when an error stops the drop part way through.
+func DropNamespaceCascade(ctx context.Context, cat Catalog, namespace table.Identifier, purge bool) ([]table.Identifier, error) {
+	var purger TablePurger
+	if purge {
+		var ok bool
+		if purger, ok = cat.(TablePurger); !ok {
+			return nil, fmt.Errorf("%w: %s catalog does not support purging tables",
+				iceberg.ErrNotImplemented, cat.CatalogType())
+		}
+	}
+
+	var dropped []table.Identifier
+	var drop func(ns table.Identifier) error
+	drop = func(ns table.Identifier) error {
+		children, err := cat.ListNamespaces(ctx, ns)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			// guard against catalogs which list the parent as its own child
+			if slices.Equal(child, ns) {
+				continue
+			}
+			if err := drop(child); err != nil {
+				return err
+			}
+		}
+
+		tables, err := cat.ListTables(ctx, ns)
+		if err != nil {
+			return err
+		}
+
+		for _, tbl := range tables {
+			if purger != nil {
+				err = purger.PurgeTable(ctx, tbl)
+			} else {
+				err = cat.DropTable(ctx, tbl)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to drop table %s: %w", strings.Join(tbl, "."), err)
+			}
+			dropped = append(dropped, tbl)
+		}
+
+		return cat.DropNamespace(ctx, ns)
+	}
+
+	err := drop(namespace)
+	return dropped, err
+}
+
+// listAllTablesConcurrency bounds the number of namespaces which
+// ListAllTables lists at the same time.
+const listAllTablesConcurrency = 8
+
+// ListAllTables walks root and, recursively, every child namespace and
+// streams the identifier of each table found. An empty root walks every
+// namespace in the catalog. Namespaces are listed concurrently and each
+// namespace and table is reported only once, in no particular order.
+//
+// The returned function has the signature of an iter.Seq2 so it can be
+// ranged over directly with Go 1.23 or newer. An error listing a
+// namespace is yielded with a nil identifier and the walk continues with
+// the remaining namespaces. Returning false from yield or cancelling ctx
+// stops the walk.
+func ListAllTables(ctx context.Context, cat Catalog, root table.Identifier) func(yield func(table.Identifier, error) bool) {
+	return func(yield func(table.Identifier, error) bool) {
+		walkCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			ident table.Identifier
+			err   error
+		}
+
+		var (
+			results = make(chan result)
+			sem     = make(chan struct{}, listAllTablesConcurrency)
+			wg      sync.WaitGroup
+
+			mx         sync.Mutex
+			namespaces = map[string]struct{}{strings.Join(root, "\x1f"): {}}
+		)
+
+		send := func(r result) bool {
+			select {
+			case results <- r:
+				return true
+			case <-walkCtx.Done():
+				return false
+			}
+		}
+
+		var walk func(ns table.Identifier)
+		walk = func(ns table.Identifier) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-walkCtx.Done():
+				return
+			}
+
+			children, err := cat.ListNamespaces(walkCtx, ns)
+			var tables []table.Identifier
+			// the root of the catalog has no tables of its own
+			if err == nil && len(ns) > 0 {
+				tables, err = cat.ListTables(walkCtx, ns)
+			}
+			<-sem
+
+			if err != nil {
+				send(result{err: fmt.Errorf("failed to list namespace %s: %w",
+					strings.Join(ns, "."), err)})
+				return
+			}
+
+			for _, child := range children {
+				key := strings.Join(child, "\x1f")
+				mx.Lock()
+				_, seen := namespaces[key]
+				namespaces[key] = struct{}{}
+				mx.Unlock()
+				if seen {
+					continue
+				}
+
+				wg.Add(1)
+				go walk(child)
+			}
+
+			for _, tbl := range tables {
+				if !send(result{ident: tbl}) {
+					return
+				}
+			}
+		}
+
+		wg.Add(1)
+		go walk(root)
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		seen, stopped := make(map[string]struct{}), false
+		for r := range results {
+			if r.err == nil {
+				key := strings.Join(r.ident, "\x1f")
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+			}
+
+			if !yield(r.ident, r.err) {
+				stopped = true
+				cancel()
+				break
+			}
+		}
+
+		// unblock and wait for any walkers still running
+		for range results {
+		}
+
+		if err := ctx.Err(); err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+// filterTablesConcurrency bounds the number of tables which FilterTables
+// loads at the same time.
+const filterTablesConcurrency = 8
+
+// FilterTables lists the tables in the namespace and streams the
+// identifiers of those whose properties match the predicate, in no
+// particular order. Tables are loaded concurrently to read their
+// properties, and tables which are dropped before they can be loaded are
+// skipped.
+//
+// The returned function has the signature of an iter.Seq2 so it can be
+// ranged over directly with Go 1.23 or newer. An error loading a table is
+// yielded with a nil identifier and filtering continues with the
+// remaining tables, while an error listing the namespace ends it.
+// Returning false from yield or cancelling ctx stops the filtering.
+func FilterTables(ctx context.Context, cat Catalog, namespace table.Identifier, predicate func(iceberg.Properties) bool) func(yield func(table.Identifier, error) bool) {
+	return func(yield func(table.Identifier, error) bool) {
+		tables, err := cat.ListTables(ctx, namespace)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to list namespace %s: %w",
+				strings.Join(namespace, "."), err))
+			return
+		}
+
+		filterCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			ident table.Identifier
+			err   error
+		}
+
+		var (
+			idents  = make(chan table.Identifier)
+			results = make(chan result)
+			wg      sync.WaitGroup
+		)
+
+		go func() {
+			defer close(idents)
+			for _, ident := range tables {
+				select {
+				case idents <- ident:
+				case <-filterCtx.Done():
+					return
+				}
+			}
+		}()
+
+		for i := 0; i < min(filterTablesConcurrency, len(tables)); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for ident := range idents {
+					var r result
+					tbl, err := cat.LoadTable(filterCtx, ident, nil)
+					switch {
+					case errors.Is(err, ErrNoSuchTable):
+						continue
+					case err != nil:
+						r.err = fmt.Errorf("failed to load table %s: %w",
+							strings.Join(ident, "."), err)
+					case predicate(tbl.Properties()):
+						r.ident = ident
+					default:
+						continue
+					}
+
+					select {
+					case results <- r:
+					case <-filterCtx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		stopped := false
+		for r := range results {
+			if !yield(r.ident, r.err) {
+				stopped = true
+				cancel()
+				break
+			}
+		}
+
+		// unblock and wait for any loaders still running
+		for range results {
+		}
+
+		if err := ctx.Err(); err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
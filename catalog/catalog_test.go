@@ -0,0 +1,165 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// propsCatalog lists tables and loads them with new metadata using the
+// properties stored for their names. Its other methods are not
+// implemented, and panic if called.
+type propsCatalog struct {
+	catalog.Catalog
+	tables []table.Identifier
+	props  map[string]iceberg.Properties
+}
+
+func (c *propsCatalog) ListTables(context.Context, table.Identifier) ([]table.Identifier, error) {
+	return c.tables, nil
+}
+
+func (c *propsCatalog) LoadTable(_ context.Context, ident table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	tblProps, ok := c.props[catalog.TableNameFromIdent(ident)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrNoSuchTable, strings.Join(ident, "."))
+	}
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec,
+		table.UnsortedSortOrder, "s3://bucket/"+strings.Join(ident, "/"), tblProps)
+	if err != nil {
+		return nil, err
+	}
+	return table.New(ident, meta, "s3://bucket/metadata.json", nil).WithCatalog(c, ident, props), nil
+}
+
+func TestFilterTables(t *testing.T) {
+	cat := &propsCatalog{
+		tables: []table.Identifier{
+			{"ns", "orders"}, {"ns", "customers"}, {"ns", "sales"}, {"ns", "dropped"},
+		},
+		props: map[string]iceberg.Properties{
+			"orders":    {"table-type": "fact"},
+			"customers": {"table-type": "dimension"},
+			"sales":     {"table-type": "fact"},
+		},
+	}
+	isFact := func(props iceberg.Properties) bool { return props["table-type"] == "fact" }
+
+	var found []string
+	catalog.FilterTables(context.Background(), cat, table.Identifier{"ns"}, isFact)(
+		func(ident table.Identifier, err error) bool {
+			assert.NoError(t, err)
+			found = append(found, strings.Join(ident, "."))
+			return true
+		})
+	assert.ElementsMatch(t, []string{"ns.orders", "ns.sales"}, found)
+
+	found = found[:0]
+	catalog.FilterTables(context.Background(), cat, table.Identifier{"ns"}, isFact)(
+		func(ident table.Identifier, err error) bool {
+			assert.NoError(t, err)
+			found = append(found, strings.Join(ident, "."))
+			return false
+		})
+	assert.Len(t, found, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var errs []error
+	catalog.FilterTables(ctx, cat, table.Identifier{"ns"}, isFact)(
+		func(ident table.Identifier, err error) bool {
+			errs = append(errs, err)
+			return true
+		})
+	if assert.NotEmpty(t, errs) {
+		assert.ErrorIs(t, errs[len(errs)-1], context.Canceled)
+	}
+
+	listErr := errors.New("list failed")
+	errs = errs[:0]
+	catalog.FilterTables(context.Background(), &failingListCatalog{propsCatalog: cat, err: listErr},
+		table.Identifier{"ns"}, isFact)(func(ident table.Identifier, err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], listErr)
+	}
+}
+
+type failingListCatalog struct {
+	*propsCatalog
+	err error
+}
+
+func (c *failingListCatalog) ListTables(context.Context, table.Identifier) ([]table.Identifier, error) {
+	return nil, c.err
+}
+
+func TestRegisterAndLoad(t *testing.T) {
+	const fakeType catalog.CatalogType = "fake"
+
+	var gotName string
+	var gotProps iceberg.Properties
+	fake := &recordingCatalog{}
+	catalog.Register(fakeType, func(_ context.Context, name string, props iceberg.Properties) (catalog.Catalog, error) {
+		gotName, gotProps = name, props
+		return fake, nil
+	})
+
+	assert.Contains(t, catalog.RegisteredTypes(), fakeType)
+	assert.Contains(t, catalog.RegisteredTypes(), catalog.REST)
+	assert.Contains(t, catalog.RegisteredTypes(), catalog.Glue)
+
+	props := iceberg.Properties{"type": "fake", "warehouse": "s3://bucket"}
+	cat, err := catalog.Load(context.Background(), "mine", props)
+	require.NoError(t, err)
+	assert.Same(t, fake, cat)
+	assert.Equal(t, "mine", gotName)
+	assert.Equal(t, props, gotProps)
+
+	assert.PanicsWithValue(t, "catalog: Register called twice for type fake", func() {
+		catalog.Register(fakeType, func(context.Context, string, iceberg.Properties) (catalog.Catalog, error) {
+			return nil, nil
+		})
+	})
+	assert.Panics(t, func() { catalog.Register("other", nil) })
+
+	_, err = catalog.Load(context.Background(), "mine", iceberg.Properties{"type": "unknown"})
+	assert.ErrorIs(t, err, catalog.ErrUnknownCatalogType)
+	assert.ErrorContains(t, err, "unknown")
+
+	_, err = catalog.Load(context.Background(), "mine", iceberg.Properties{})
+	assert.ErrorIs(t, err, catalog.ErrUnknownCatalogType)
+
+	_, err = catalog.Load(context.Background(), "mine", iceberg.Properties{"type": "rest"})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -21,11 +21,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"time"
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 	"github.com/aws/aws-sdk-go-v2/service/glue/types"
 )
@@ -36,13 +41,40 @@ var (
 	_ Catalog = (*GlueCatalog)(nil)
 )
 
+func init() {
+	Register(Glue, func(ctx context.Context, _ string, props iceberg.Properties) (Catalog, error) {
+		var opts []func(*config.LoadOptions) error
+		if region, ok := props["glue.region"]; ok {
+			opts = append(opts, config.WithRegion(region))
+		} else if region, ok := props["client.region"]; ok {
+			opts = append(opts, config.WithRegion(region))
+		}
+
+		awscfg, err := config.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGlueCatalog(WithAwsConfig(awscfg)), nil
+	})
+}
+
 type glueAPI interface {
 	GetTable(ctx context.Context, params *glue.GetTableInput, optFns ...func(*glue.Options)) (*glue.GetTableOutput, error)
 	GetTables(ctx context.Context, params *glue.GetTablesInput, optFns ...func(*glue.Options)) (*glue.GetTablesOutput, error)
+	GetDatabase(ctx context.Context, params *glue.GetDatabaseInput, optFns ...func(*glue.Options)) (*glue.GetDatabaseOutput, error)
+	CreateTable(ctx context.Context, params *glue.CreateTableInput, optFns ...func(*glue.Options)) (*glue.CreateTableOutput, error)
+	DeleteTable(ctx context.Context, params *glue.DeleteTableInput, optFns ...func(*glue.Options)) (*glue.DeleteTableOutput, error)
 }
 
 type GlueCatalog struct {
 	glueSvc glueAPI
+	// awsConfig also configures the S3 file IO of the catalog's tables
+	awsConfig aws.Config
+	clock     table.Clock
+	logger    *slog.Logger
+	// timeout bounds each catalog call, the AWS SDK handles retries
+	timeout time.Duration
 }
 
 func NewGlueCatalog(opts ...Option[GlueCatalog]) *GlueCatalog {
@@ -52,8 +84,17 @@ func NewGlueCatalog(opts ...Option[GlueCatalog]) *GlueCatalog {
 		o(glueOps)
 	}
 
+	timeout := glueOps.operationTimeout
+	if timeout <= 0 {
+		timeout = glueOps.requestTimeout
+	}
+
 	return &GlueCatalog{
-		glueSvc: glue.NewFromConfig(glueOps.awsConfig),
+		glueSvc:   glue.NewFromConfig(glueOps.awsConfig),
+		awsConfig: glueOps.awsConfig,
+		clock:     glueOps.clock,
+		logger:    glueOps.logger,
+		timeout:   timeout,
 	}
 }
 
@@ -61,6 +102,9 @@ func NewGlueCatalog(opts ...Option[GlueCatalog]) *GlueCatalog {
 //
 // The namespace should just contain the Glue database name.
 func (c *GlueCatalog) ListTables(ctx context.Context, namespace table.Identifier) ([]table.Identifier, error) {
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+
 	database, err := identifierToGlueDatabase(namespace)
 	if err != nil {
 		return nil, err
@@ -75,6 +119,8 @@ func (c *GlueCatalog) ListTables(ctx context.Context, namespace table.Identifier
 		if err != nil {
 			return nil, fmt.Errorf("failed to list tables in namespace %s: %w", database, err)
 		}
+		c.log().DebugContext(ctx, "listed glue tables", "database", database,
+			"tables", len(tblsRes.TableList))
 
 		icebergTables = append(icebergTables,
 			filterTableListByType(database, tblsRes.TableList, glueTableTypeIceberg)...)
@@ -93,6 +139,9 @@ func (c *GlueCatalog) ListTables(ctx context.Context, namespace table.Identifier
 //
 // The identifier should contain the Glue database name, then glue table name.
 func (c *GlueCatalog) LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+
 	database, tableName, err := identifierToGlueTable(identifier)
 	if err != nil {
 		return nil, err
@@ -107,8 +156,9 @@ func (c *GlueCatalog) LoadTable(ctx context.Context, identifier table.Identifier
 		return nil, err
 	}
 
-	// TODO: consider providing a way to directly access the S3 iofs to enable testing of the catalog.
-	iofs, err := io.LoadFS(props, location)
+	// the table's files are read with the catalog's AWS config, so the
+	// credentials don't need to be passed again in props
+	iofs, err := io.LoadFSForLocationsWithAWSConfig(&c.awsConfig, props, location)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load table %s.%s: %w", database, tableName, err)
 	}
@@ -118,19 +168,183 @@ func (c *GlueCatalog) LoadTable(ctx context.Context, identifier table.Identifier
 		return nil, fmt.Errorf("failed to create table from location %s.%s: %w", database, tableName, err)
 	}
 
-	return icebergTable, nil
+	// s3 properties of the table, such as its endpoint, configure the IO
+	// for its data files unless they're overridden by props
+	if ioProps := s3Properties(icebergTable.Properties()); len(ioProps) > 0 {
+		maps.Copy(ioProps, props)
+		if iofs, err = io.LoadFSForLocationsWithAWSConfig(&c.awsConfig, ioProps, location); err != nil {
+			return nil, fmt.Errorf("failed to load table %s.%s: %w", database, tableName, err)
+		}
+		icebergTable = table.New(icebergTable.Identifier(), icebergTable.Metadata(), location, iofs)
+	}
+	icebergTable = icebergTable.WithCatalog(c, identifier, props)
+
+	if c.clock != nil {
+		icebergTable = icebergTable.WithClock(c.clock)
+	}
+	return icebergTable.PinFromProperties(props)
+}
+
+// s3Properties returns the properties which configure S3 file IO.
+func s3Properties(props iceberg.Properties) iceberg.Properties {
+	out := iceberg.Properties{}
+	for k, v := range props {
+		if strings.HasPrefix(k, "s3.") {
+			out[k] = v
+		}
+	}
+	return out
 }
 
 func (c *GlueCatalog) CatalogType() CatalogType {
 	return Glue
 }
 
+func (c *GlueCatalog) CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) (*table.Table, error) {
+	return nil, fmt.Errorf("%w: [Glue Catalog] create table", iceberg.ErrNotImplemented)
+}
+
+func (c *GlueCatalog) CommitTable(ctx context.Context, identifier table.Identifier, requirements []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	return nil, "", fmt.Errorf("%w: [Glue Catalog] commit table", iceberg.ErrNotImplemented)
+}
+
+// TableExists returns whether an iceberg table exists in the Glue database.
+//
+// The identifier should contain the Glue database name, then the table name.
+func (c *GlueCatalog) TableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+
+	database, tableName, err := identifierToGlueTable(identifier)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = c.getTable(ctx, database, tableName)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrNoSuchTable):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// NamespaceExists returns whether the Glue database exists.
+//
+// The namespace should just contain the Glue database name.
+func (c *GlueCatalog) NamespaceExists(ctx context.Context, namespace table.Identifier) (bool, error) {
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+
+	database, err := identifierToGlueDatabase(namespace)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = c.glueSvc.GetDatabase(ctx, &glue.GetDatabaseInput{Name: aws.String(database)})
+	if err != nil {
+		var notFound *types.EntityNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get database %s: %w", database, err)
+	}
+
+	return true, nil
+}
+
 func (c *GlueCatalog) DropTable(ctx context.Context, identifier table.Identifier) error {
 	return fmt.Errorf("%w: [Glue Catalog] drop table", iceberg.ErrNotImplemented)
 }
 
+// RenameTable renames the table by creating a Glue table with the new name
+// pointing at the same metadata, then deleting the old one. Glue has no
+// atomic rename, so if the old table can't be deleted the new table is
+// deleted again, so that the table isn't left with two names. The data and
+// metadata files aren't moved.
+//
+// The identifiers should contain the Glue database name, then the table
+// name, and may be in different databases.
 func (c *GlueCatalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
-	return nil, fmt.Errorf("%w: [Glue Catalog] rename table", iceberg.ErrNotImplemented)
+	ctx, cancel := withTimeout(ctx, c.timeout)
+	defer cancel()
+
+	fromDB, fromTable, err := identifierToGlueTable(from)
+	if err != nil {
+		return nil, err
+	}
+	toDB, toTable, err := identifierToGlueTable(to)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := c.glueSvc.GetTable(ctx, &glue.GetTableInput{
+		DatabaseName: aws.String(fromDB), Name: aws.String(fromTable)})
+	if err != nil {
+		var notFound *types.EntityNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to rename table %s.%s: %w", fromDB, fromTable,
+				&NotFoundError{Err: fmt.Errorf("%w: %w", ErrNoSuchTable, err)})
+		}
+		return nil, fmt.Errorf("failed to rename table %s.%s: %w", fromDB, fromTable, err)
+	}
+	if src.Table.Parameters["table_type"] != glueTableTypeIceberg {
+		return nil, errors.New("table is not an iceberg table")
+	}
+
+	exists, err := c.NamespaceExists(ctx, GlueDatabaseIdentifier(toDB))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &NotFoundError{Err: fmt.Errorf("%w: %s", ErrNoSuchNamespace, toDB)}
+	}
+
+	input := glueTableInput(src.Table)
+	input.Name = aws.String(toTable)
+	_, err = c.glueSvc.CreateTable(ctx, &glue.CreateTableInput{
+		DatabaseName: aws.String(toDB), TableInput: input})
+	if err != nil {
+		var alreadyExists *types.AlreadyExistsException
+		if errors.As(err, &alreadyExists) {
+			return nil, fmt.Errorf("failed to rename table to %s.%s: %w: %w", toDB, toTable,
+				ErrTableAlreadyExists, err)
+		}
+		return nil, fmt.Errorf("failed to rename table to %s.%s: %w", toDB, toTable, err)
+	}
+
+	_, err = c.glueSvc.DeleteTable(ctx, &glue.DeleteTableInput{
+		DatabaseName: aws.String(fromDB), Name: aws.String(fromTable)})
+	if err != nil {
+		_, rollbackErr := c.glueSvc.DeleteTable(ctx, &glue.DeleteTableInput{
+			DatabaseName: aws.String(toDB), Name: aws.String(toTable)})
+		if rollbackErr != nil {
+			c.log().WarnContext(ctx, "failed to delete the renamed glue table after a failed rename",
+				"database", toDB, "table", toTable, "error", rollbackErr)
+		}
+		return nil, fmt.Errorf("failed to rename table %s.%s: %w", fromDB, fromTable, err)
+	}
+
+	return c.LoadTable(ctx, to, nil)
+}
+
+// glueTableInput returns the input to create a copy of the Glue table.
+func glueTableInput(tbl *types.Table) *types.TableInput {
+	return &types.TableInput{
+		Name:              tbl.Name,
+		Description:       tbl.Description,
+		Owner:             tbl.Owner,
+		Parameters:        tbl.Parameters,
+		PartitionKeys:     tbl.PartitionKeys,
+		Retention:         tbl.Retention,
+		StorageDescriptor: tbl.StorageDescriptor,
+		TableType:         tbl.TableType,
+		TargetTable:       tbl.TargetTable,
+		ViewExpandedText:  tbl.ViewExpandedText,
+		ViewOriginalText:  tbl.ViewOriginalText,
+	}
 }
 
 func (c *GlueCatalog) CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error {
@@ -154,8 +368,14 @@ func (c *GlueCatalog) ListNamespaces(ctx context.Context, parent table.Identifie
 	return nil, fmt.Errorf("%w: [Glue Catalog] list namespaces", iceberg.ErrNotImplemented)
 }
 
+// log returns the catalog's logger, which discards everything if none
+// was configured.
+func (c *GlueCatalog) log() *slog.Logger { return loggerOrDiscard(c.logger) }
+
 // GetTable loads a table from the Glue Catalog using the given database and table name.
 func (c *GlueCatalog) getTable(ctx context.Context, database, tableName string) (string, error) {
+	c.log().DebugContext(ctx, "getting glue table", "database", database, "table", tableName)
+
 	tblRes, err := c.glueSvc.GetTable(ctx,
 		&glue.GetTableInput{
 			DatabaseName: aws.String(database),
@@ -163,8 +383,10 @@ func (c *GlueCatalog) getTable(ctx context.Context, database, tableName string)
 		},
 	)
 	if err != nil {
-		if errors.Is(err, &types.EntityNotFoundException{}) {
-			return "", fmt.Errorf("failed to get table %s.%s: %w", database, tableName, ErrNoSuchTable)
+		var notFound *types.EntityNotFoundException
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("failed to get table %s.%s: %w", database, tableName,
+				&NotFoundError{Err: fmt.Errorf("%w: %w", ErrNoSuchTable, err)})
 		}
 		return "", fmt.Errorf("failed to get table %s.%s: %w", database, tableName, err)
 	}
@@ -19,11 +19,20 @@ package catalog
 
 import (
 	"context"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/glue"
 	"github.com/aws/aws-sdk-go-v2/service/glue/types"
 	"github.com/stretchr/testify/mock"
@@ -44,6 +53,21 @@ func (m *mockGlueClient) GetTables(ctx context.Context, params *glue.GetTablesIn
 	return args.Get(0).(*glue.GetTablesOutput), args.Error(1)
 }
 
+func (m *mockGlueClient) GetDatabase(ctx context.Context, params *glue.GetDatabaseInput, optFns ...func(*glue.Options)) (*glue.GetDatabaseOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.Get(0).(*glue.GetDatabaseOutput), args.Error(1)
+}
+
+func (m *mockGlueClient) CreateTable(ctx context.Context, params *glue.CreateTableInput, optFns ...func(*glue.Options)) (*glue.CreateTableOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.Get(0).(*glue.CreateTableOutput), args.Error(1)
+}
+
+func (m *mockGlueClient) DeleteTable(ctx context.Context, params *glue.DeleteTableInput, optFns ...func(*glue.Options)) (*glue.DeleteTableOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.Get(0).(*glue.DeleteTableOutput), args.Error(1)
+}
+
 func TestGlueGetTable(t *testing.T) {
 	assert := require.New(t)
 
@@ -70,6 +94,200 @@ func TestGlueGetTable(t *testing.T) {
 	assert.Equal("s3://test-bucket/test_table/metadata/abc123-123.metadata.json", location)
 }
 
+func TestGlueTableExists(t *testing.T) {
+	assert := require.New(t)
+
+	mockGlueSvc := &mockGlueClient{}
+
+	mockGlueSvc.On("GetTable", mock.Anything, &glue.GetTableInput{
+		DatabaseName: aws.String("test_database"),
+		Name:         aws.String("test_table"),
+	}, mock.Anything).Return(&glue.GetTableOutput{
+		Table: &types.Table{
+			Parameters: map[string]string{"table_type": "ICEBERG"},
+		},
+	}, nil)
+	mockGlueSvc.On("GetTable", mock.Anything, &glue.GetTableInput{
+		DatabaseName: aws.String("test_database"),
+		Name:         aws.String("missing_table"),
+	}, mock.Anything).Return((*glue.GetTableOutput)(nil), &types.EntityNotFoundException{})
+
+	glueCatalog := &GlueCatalog{
+		glueSvc: mockGlueSvc,
+	}
+
+	exists, err := glueCatalog.TableExists(context.TODO(), GlueTableIdentifier("test_database", "test_table"))
+	assert.NoError(err)
+	assert.True(exists)
+
+	exists, err = glueCatalog.TableExists(context.TODO(), GlueTableIdentifier("test_database", "missing_table"))
+	assert.NoError(err)
+	assert.False(exists)
+
+	_, err = glueCatalog.getTable(context.TODO(), "test_database", "missing_table")
+	var notFound *NotFoundError
+	assert.ErrorAs(err, &notFound)
+	var awsNotFound *types.EntityNotFoundException
+	assert.ErrorAs(err, &awsNotFound)
+	assert.ErrorIs(err, ErrNoSuchTable)
+}
+
+func TestGlueNamespaceExists(t *testing.T) {
+	assert := require.New(t)
+
+	mockGlueSvc := &mockGlueClient{}
+
+	mockGlueSvc.On("GetDatabase", mock.Anything, &glue.GetDatabaseInput{
+		Name: aws.String("test_database"),
+	}, mock.Anything).Return(&glue.GetDatabaseOutput{}, nil)
+	mockGlueSvc.On("GetDatabase", mock.Anything, &glue.GetDatabaseInput{
+		Name: aws.String("missing_database"),
+	}, mock.Anything).Return((*glue.GetDatabaseOutput)(nil), &types.EntityNotFoundException{})
+
+	glueCatalog := &GlueCatalog{
+		glueSvc: mockGlueSvc,
+	}
+
+	exists, err := glueCatalog.NamespaceExists(context.TODO(), GlueDatabaseIdentifier("test_database"))
+	assert.NoError(err)
+	assert.True(exists)
+
+	exists, err = glueCatalog.NamespaceExists(context.TODO(), GlueDatabaseIdentifier("missing_database"))
+	assert.NoError(err)
+	assert.False(exists)
+}
+
+func TestGlueTimeout(t *testing.T) {
+	assert := require.New(t)
+
+	mockGlueSvc := &mockGlueClient{}
+
+	var deadline time.Time
+	mockGlueSvc.On("GetDatabase", mock.Anything, &glue.GetDatabaseInput{
+		Name: aws.String("test_database"),
+	}, mock.Anything).Run(func(args mock.Arguments) {
+		deadline, _ = args.Get(0).(context.Context).Deadline()
+	}).Return(&glue.GetDatabaseOutput{}, nil)
+
+	glueCatalog := &GlueCatalog{
+		glueSvc: mockGlueSvc,
+		timeout: time.Minute,
+	}
+
+	start := time.Now()
+	_, err := glueCatalog.NamespaceExists(context.TODO(), GlueDatabaseIdentifier("test_database"))
+	assert.NoError(err)
+	assert.WithinDuration(start.Add(time.Minute), deadline, 5*time.Second)
+
+	// a shorter deadline from the caller takes precedence
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	callerDeadline, _ := ctx.Deadline()
+
+	_, err = glueCatalog.NamespaceExists(ctx, GlueDatabaseIdentifier("test_database"))
+	assert.NoError(err)
+	assert.Equal(callerDeadline, deadline)
+}
+
+func TestGlueRenameTable(t *testing.T) {
+	metadataLoc := filepath.Join(t.TempDir(), "00000.metadata.json")
+	require.NoError(t, os.WriteFile(metadataLoc, []byte(`{
+		"format-version": 2,
+		"table-uuid": "b55d9dda-6561-423a-8bfc-787980ce421f",
+		"location": "s3://test-bucket/test_table",
+		"last-sequence-number": 0,
+		"last-updated-ms": 1646787054459,
+		"last-column-id": 1,
+		"current-schema-id": 0,
+		"schemas": [{"type": "struct", "schema-id": 0, "fields": [
+			{"id": 1, "name": "id", "required": false, "type": "int"}]}],
+		"default-spec-id": 0,
+		"partition-specs": [{"spec-id": 0, "fields": []}],
+		"last-partition-id": 999,
+		"default-sort-order-id": 0,
+		"sort-orders": [{"order-id": 0, "fields": []}]
+	}`), 0o644))
+
+	params := map[string]string{"table_type": "ICEBERG", "metadata_location": metadataLoc}
+	getTable := func(m *mockGlueClient, database, name string) *mock.Call {
+		return m.On("GetTable", mock.Anything, &glue.GetTableInput{
+			DatabaseName: aws.String(database), Name: aws.String(name),
+		}, mock.Anything)
+	}
+	createTable := func(m *mockGlueClient, database, name string) *mock.Call {
+		return m.On("CreateTable", mock.Anything, &glue.CreateTableInput{
+			DatabaseName: aws.String(database),
+			TableInput:   &types.TableInput{Name: aws.String(name), Parameters: params},
+		}, mock.Anything)
+	}
+	deleteTable := func(m *mockGlueClient, database, name string) *mock.Call {
+		return m.On("DeleteTable", mock.Anything, &glue.DeleteTableInput{
+			DatabaseName: aws.String(database), Name: aws.String(name),
+		}, mock.Anything)
+	}
+	getDatabase := func(m *mockGlueClient, database string) *mock.Call {
+		return m.On("GetDatabase", mock.Anything, &glue.GetDatabaseInput{
+			Name: aws.String(database),
+		}, mock.Anything)
+	}
+	srcTable := &glue.GetTableOutput{Table: &types.Table{Name: aws.String("src"), Parameters: params}}
+
+	t.Run("across databases", func(t *testing.T) {
+		m := &mockGlueClient{}
+		getTable(m, "db1", "src").Return(srcTable, nil).Once()
+		getDatabase(m, "db2").Return(&glue.GetDatabaseOutput{}, nil)
+		createTable(m, "db2", "dst").Return(&glue.CreateTableOutput{}, nil).Once()
+		deleteTable(m, "db1", "src").Return(&glue.DeleteTableOutput{}, nil).Once()
+		getTable(m, "db2", "dst").Return(&glue.GetTableOutput{Table: &types.Table{
+			Name: aws.String("dst"), Parameters: params}}, nil).Once()
+
+		tbl, err := (&GlueCatalog{glueSvc: m}).RenameTable(context.TODO(),
+			GlueTableIdentifier("db1", "src"), GlueTableIdentifier("db2", "dst"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"dst"}, tbl.Identifier())
+		require.Equal(t, metadataLoc, tbl.MetadataLocation())
+		m.AssertExpectations(t)
+	})
+
+	t.Run("missing destination database", func(t *testing.T) {
+		m := &mockGlueClient{}
+		getTable(m, "db1", "src").Return(srcTable, nil).Once()
+		getDatabase(m, "missing").Return((*glue.GetDatabaseOutput)(nil), &types.EntityNotFoundException{})
+
+		_, err := (&GlueCatalog{glueSvc: m}).RenameTable(context.TODO(),
+			GlueTableIdentifier("db1", "src"), GlueTableIdentifier("missing", "dst"))
+		require.ErrorIs(t, err, ErrNoSuchNamespace)
+		m.AssertNotCalled(t, "CreateTable", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("destination exists", func(t *testing.T) {
+		m := &mockGlueClient{}
+		getTable(m, "db1", "src").Return(srcTable, nil).Once()
+		getDatabase(m, "db1").Return(&glue.GetDatabaseOutput{}, nil)
+		createTable(m, "db1", "dst").Return((*glue.CreateTableOutput)(nil), &types.AlreadyExistsException{}).Once()
+
+		_, err := (&GlueCatalog{glueSvc: m}).RenameTable(context.TODO(),
+			GlueTableIdentifier("db1", "src"), GlueTableIdentifier("db1", "dst"))
+		require.ErrorIs(t, err, ErrTableAlreadyExists)
+		m.AssertNotCalled(t, "DeleteTable", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed delete is rolled back", func(t *testing.T) {
+		m := &mockGlueClient{}
+		getTable(m, "db1", "src").Return(srcTable, nil).Once()
+		getDatabase(m, "db1").Return(&glue.GetDatabaseOutput{}, nil)
+		createTable(m, "db1", "dst").Return(&glue.CreateTableOutput{}, nil).Once()
+		deleteTable(m, "db1", "src").Return((*glue.DeleteTableOutput)(nil), &types.AccessDeniedException{}).Once()
+		deleteTable(m, "db1", "dst").Return(&glue.DeleteTableOutput{}, nil).Once()
+
+		_, err := (&GlueCatalog{glueSvc: m}).RenameTable(context.TODO(),
+			GlueTableIdentifier("db1", "src"), GlueTableIdentifier("db1", "dst"))
+		var denied *types.AccessDeniedException
+		require.ErrorAs(t, err, &denied)
+		m.AssertExpectations(t)
+	})
+}
+
 func TestGlueListTables(t *testing.T) {
 	assert := require.New(t)
 
@@ -146,3 +364,115 @@ func TestGlueLoadTableIntegration(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal([]string{os.Getenv("TEST_TABLE_NAME")}, table.Identifier())
 }
+
+// fakeS3 serves the objects with path style S3 requests, recording the
+// access key of each request's signature.
+func fakeS3(t *testing.T, objects map[string]string) (*httptest.Server, *[]string) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, cred, _ := strings.Cut(req.Header.Get("Authorization"), "Credential=")
+		keyID, _, _ := strings.Cut(cred, "/")
+		keys = append(keys, keyID)
+
+		body, ok := objects[strings.TrimPrefix(req.URL.Path, "/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &keys
+}
+
+func TestGlueLoadTableS3FileIO(t *testing.T) {
+	t.Setenv("AWS_S3_ENDPOINT", "")
+
+	metadata := func(props string) string {
+		return `{
+			"format-version": 2,
+			"table-uuid": "b55d9dda-6561-423a-8bfc-787980ce421f",
+			"location": "s3://warehouse/db/tbl",
+			"last-sequence-number": 0,
+			"last-updated-ms": 1646787054459,
+			"last-column-id": 1,
+			"current-schema-id": 0,
+			"schemas": [{"type": "struct", "schema-id": 0, "fields": [
+				{"id": 1, "name": "id", "required": false, "type": "int"}]}],
+			"default-spec-id": 0,
+			"partition-specs": [{"spec-id": 0, "fields": []}],
+			"last-partition-id": 999,
+			"default-sort-order-id": 0,
+			"sort-orders": [{"order-id": 0, "fields": []}],
+			"properties": ` + props + `
+		}`
+	}
+
+	catalogObjects := map[string]string{
+		"warehouse/db/tbl/metadata/v1.metadata.json": metadata(`{}`),
+		"warehouse/db/tbl/data/00000.parquet":        "catalog data",
+	}
+	catalogS3, catalogKeys := fakeS3(t, catalogObjects)
+
+	awsCfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("catalog-key", "secret", ""),
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{URL: catalogS3.URL, HostnameImmutable: true}, nil
+			}),
+	}
+
+	readData := func(t *testing.T, location string) string {
+		m := &mockGlueClient{}
+		m.On("GetTable", mock.Anything, &glue.GetTableInput{
+			DatabaseName: aws.String("db"), Name: aws.String("tbl"),
+		}, mock.Anything).Return(&glue.GetTableOutput{Table: &types.Table{
+			Name: aws.String("tbl"),
+			Parameters: map[string]string{
+				"table_type": "ICEBERG", "metadata_location": location,
+			},
+		}}, nil)
+
+		cat := &GlueCatalog{glueSvc: m, awsConfig: awsCfg}
+		tbl, err := cat.LoadTable(context.Background(), GlueTableIdentifier("db", "tbl"), nil)
+		require.NoError(t, err)
+
+		f, err := tbl.FS().Open(tbl.ResolveLocation("data/00000.parquet"))
+		require.NoError(t, err)
+		defer f.Close()
+		data, err := stdio.ReadAll(f)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("catalog aws config", func(t *testing.T) {
+		data := readData(t, "s3://warehouse/db/tbl/metadata/v1.metadata.json")
+		require.Equal(t, "catalog data", data)
+		require.NotEmpty(t, *catalogKeys)
+		for _, k := range *catalogKeys {
+			require.Equal(t, "catalog-key", k)
+		}
+	})
+
+	t.Run("table s3 properties", func(t *testing.T) {
+		tableS3, tableKeys := fakeS3(t, map[string]string{
+			"warehouse/db/tbl/data/00000.parquet": "table data",
+		})
+		catalogObjects["warehouse/db/tbl/metadata/v2.metadata.json"] = metadata(`{
+			"s3.endpoint": "` + tableS3.URL + `",
+			"s3.access-key-id": "table-key",
+			"s3.secret-access-key": "secret"
+		}`)
+		*catalogKeys = nil
+
+		// the table's metadata is read with the catalog's config, and its
+		// data files with the table's own endpoint and credentials
+		data := readData(t, "s3://warehouse/db/tbl/metadata/v2.metadata.json")
+		require.Equal(t, "table data", data)
+		require.Equal(t, []string{"catalog-key"}, *catalogKeys)
+		require.Equal(t, []string{"table-key"}, *tableKeys)
+	})
+}
@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+)
+
+// ErrReadOnly is returned when attempting to change a catalog which only
+// allows reads.
+var ErrReadOnly = errors.New("catalog is read-only")
+
+// ReadOnly wraps the catalog so that its methods which change tables or
+// namespaces return an error wrapping ErrReadOnly without calling inner,
+// while all of the methods which only read are passed through. Tables
+// loaded through the returned catalog commit through it as well, so they
+// can't be changed either.
+func ReadOnly(inner Catalog) Catalog {
+	return &readOnlyCatalog{Catalog: inner}
+}
+
+type readOnlyCatalog struct {
+	Catalog
+}
+
+func readOnlyErr(op string) error {
+	return fmt.Errorf("%w: %s is not allowed", ErrReadOnly, op)
+}
+
+func (c *readOnlyCatalog) CreateTable(context.Context, table.Identifier, *iceberg.Schema, ...CreateTableOpt) (*table.Table, error) {
+	return nil, readOnlyErr("create table")
+}
+
+func (c *readOnlyCatalog) LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	tbl, err := c.Catalog.LoadTable(ctx, identifier, props)
+	if err != nil {
+		return nil, err
+	}
+
+	return tbl.WithCatalog(c, identifier, props), nil
+}
+
+func (c *readOnlyCatalog) CommitTable(context.Context, table.Identifier, []table.Requirement, []table.Update) (table.Metadata, string, error) {
+	return nil, "", readOnlyErr("commit table")
+}
+
+func (c *readOnlyCatalog) DropTable(context.Context, table.Identifier) error {
+	return readOnlyErr("drop table")
+}
+
+func (c *readOnlyCatalog) RenameTable(context.Context, table.Identifier, table.Identifier) (*table.Table, error) {
+	return nil, readOnlyErr("rename table")
+}
+
+func (c *readOnlyCatalog) CreateNamespace(context.Context, table.Identifier, iceberg.Properties) error {
+	return readOnlyErr("create namespace")
+}
+
+func (c *readOnlyCatalog) DropNamespace(context.Context, table.Identifier) error {
+	return readOnlyErr("drop namespace")
+}
+
+func (c *readOnlyCatalog) UpdateNamespaceProperties(context.Context, table.Identifier, []string, iceberg.Properties) (PropertiesUpdateSummary, error) {
+	return PropertiesUpdateSummary{}, readOnlyErr("update namespace properties")
+}
@@ -0,0 +1,177 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCatalog records the names of the methods called on it.
+type recordingCatalog struct {
+	calls []string
+	meta  table.Metadata
+}
+
+func (c *recordingCatalog) CatalogType() catalog.CatalogType {
+	c.calls = append(c.calls, "CatalogType")
+	return catalog.REST
+}
+
+func (c *recordingCatalog) ListTables(context.Context, table.Identifier) ([]table.Identifier, error) {
+	c.calls = append(c.calls, "ListTables")
+	return []table.Identifier{{"ns", "tbl"}}, nil
+}
+
+func (c *recordingCatalog) CreateTable(context.Context, table.Identifier, *iceberg.Schema, ...catalog.CreateTableOpt) (*table.Table, error) {
+	c.calls = append(c.calls, "CreateTable")
+	return nil, nil
+}
+
+func (c *recordingCatalog) LoadTable(_ context.Context, ident table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	c.calls = append(c.calls, "LoadTable")
+	return table.New(ident, c.meta, "s3://bucket/metadata.json", nil).WithCatalog(c, ident, props), nil
+}
+
+func (c *recordingCatalog) CommitTable(context.Context, table.Identifier, []table.Requirement, []table.Update) (table.Metadata, string, error) {
+	c.calls = append(c.calls, "CommitTable")
+	return nil, "", nil
+}
+
+func (c *recordingCatalog) TableExists(context.Context, table.Identifier) (bool, error) {
+	c.calls = append(c.calls, "TableExists")
+	return true, nil
+}
+
+func (c *recordingCatalog) DropTable(context.Context, table.Identifier) error {
+	c.calls = append(c.calls, "DropTable")
+	return nil
+}
+
+func (c *recordingCatalog) RenameTable(context.Context, table.Identifier, table.Identifier) (*table.Table, error) {
+	c.calls = append(c.calls, "RenameTable")
+	return nil, nil
+}
+
+func (c *recordingCatalog) ListNamespaces(context.Context, table.Identifier) ([]table.Identifier, error) {
+	c.calls = append(c.calls, "ListNamespaces")
+	return []table.Identifier{{"ns"}}, nil
+}
+
+func (c *recordingCatalog) NamespaceExists(context.Context, table.Identifier) (bool, error) {
+	c.calls = append(c.calls, "NamespaceExists")
+	return true, nil
+}
+
+func (c *recordingCatalog) CreateNamespace(context.Context, table.Identifier, iceberg.Properties) error {
+	c.calls = append(c.calls, "CreateNamespace")
+	return nil
+}
+
+func (c *recordingCatalog) DropNamespace(context.Context, table.Identifier) error {
+	c.calls = append(c.calls, "DropNamespace")
+	return nil
+}
+
+func (c *recordingCatalog) LoadNamespaceProperties(context.Context, table.Identifier) (iceberg.Properties, error) {
+	c.calls = append(c.calls, "LoadNamespaceProperties")
+	return iceberg.Properties{"owner": "me"}, nil
+}
+
+func (c *recordingCatalog) UpdateNamespaceProperties(context.Context, table.Identifier, []string, iceberg.Properties) (catalog.PropertiesUpdateSummary, error) {
+	c.calls = append(c.calls, "UpdateNamespaceProperties")
+	return catalog.PropertiesUpdateSummary{}, nil
+}
+
+var readOnlySchema = iceberg.NewSchema(0,
+	iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+
+func TestReadOnlyCatalogBlocksChanges(t *testing.T) {
+	inner := &recordingCatalog{}
+	cat := catalog.ReadOnly(inner)
+	ctx, ident, ns := context.Background(), table.Identifier{"ns", "tbl"}, table.Identifier{"ns"}
+
+	_, err := cat.CreateTable(ctx, ident, readOnlySchema)
+	assert.ErrorIs(t, err, catalog.ErrReadOnly)
+	_, _, err = cat.CommitTable(ctx, ident, nil, nil)
+	assert.ErrorIs(t, err, catalog.ErrReadOnly)
+	assert.ErrorIs(t, cat.DropTable(ctx, ident), catalog.ErrReadOnly)
+	_, err = cat.RenameTable(ctx, ident, table.Identifier{"ns", "other"})
+	assert.ErrorIs(t, err, catalog.ErrReadOnly)
+	assert.ErrorIs(t, cat.CreateNamespace(ctx, ns, nil), catalog.ErrReadOnly)
+	assert.ErrorIs(t, cat.DropNamespace(ctx, ns), catalog.ErrReadOnly)
+	_, err = cat.UpdateNamespaceProperties(ctx, ns, []string{"owner"}, nil)
+	assert.ErrorIs(t, err, catalog.ErrReadOnly)
+
+	assert.Empty(t, inner.calls)
+}
+
+func TestReadOnlyCatalogPassesThroughReads(t *testing.T) {
+	meta, err := table.NewMetadata(readOnlySchema, iceberg.UnpartitionedSpec,
+		table.UnsortedSortOrder, "s3://bucket/ns/tbl", nil)
+	require.NoError(t, err)
+
+	inner := &recordingCatalog{meta: meta}
+	cat := catalog.ReadOnly(inner)
+	ctx, ident, ns := context.Background(), table.Identifier{"ns", "tbl"}, table.Identifier{"ns"}
+
+	assert.Equal(t, catalog.REST, cat.CatalogType())
+
+	tables, err := cat.ListTables(ctx, ns)
+	require.NoError(t, err)
+	assert.Equal(t, []table.Identifier{ident}, tables)
+
+	tbl, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+	assert.Equal(t, meta, tbl.Metadata())
+
+	exists, err := cat.TableExists(ctx, ident)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	namespaces, err := cat.ListNamespaces(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []table.Identifier{ns}, namespaces)
+
+	exists, err = cat.NamespaceExists(ctx, ns)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	props, err := cat.LoadNamespaceProperties(ctx, ns)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.Properties{"owner": "me"}, props)
+
+	assert.Equal(t, []string{"CatalogType", "ListTables", "LoadTable", "TableExists",
+		"ListNamespaces", "NamespaceExists", "LoadNamespaceProperties"}, inner.calls)
+
+	// tables loaded through the catalog can't be committed to either
+	_, _, err = tbl.UpdateProperties().Set("owner", "me").Commit(ctx)
+	assert.ErrorIs(t, err, catalog.ErrReadOnly)
+
+	refreshed, err := tbl.Refresh(ctx)
+	require.NoError(t, err)
+	_, _, err = refreshed.UpdateProperties().Set("owner", "me").Commit(ctx)
+	assert.ErrorIs(t, err, catalog.ErrReadOnly)
+	assert.NotContains(t, inner.calls, "CommitTable")
+}
@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+)
+
+// ErrUnknownCatalogType is returned by Load when no catalog is
+// registered for the requested type.
+var ErrUnknownCatalogType = errors.New("unknown catalog type")
+
+const (
+	keyCatalogType = "type"
+	keyCatalogURI  = "uri"
+)
+
+// Factory constructs a catalog with the given name from its properties.
+type Factory func(ctx context.Context, name string, props iceberg.Properties) (Catalog, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[CatalogType]Factory{}
+)
+
+// Register makes a catalog type available to Load. Like the drivers of
+// database/sql it's intended to be called from the init function of the
+// package implementing the catalog. Register panics if the factory is nil
+// or a factory was already registered for the type.
+func Register(typ CatalogType, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("catalog: Register factory is nil for type " + string(typ))
+	}
+	if _, dup := registry[typ]; dup {
+		panic("catalog: Register called twice for type " + string(typ))
+	}
+	registry[typ] = factory
+}
+
+// RegisteredTypes returns the sorted catalog types which can be loaded.
+func RegisteredTypes() []CatalogType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]CatalogType, 0, len(registry))
+	for typ := range registry {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Load constructs the catalog named name, using the factory registered
+// for the type in the "type" property. If the type isn't set, a catalog
+// with a "uri" property is assumed to be a REST catalog.
+func Load(ctx context.Context, name string, props iceberg.Properties) (Catalog, error) {
+	typ := CatalogType(props[keyCatalogType])
+	if typ == "" {
+		if _, ok := props[keyCatalogURI]; !ok {
+			return nil, fmt.Errorf("%w: catalog %s has no type", ErrUnknownCatalogType, name)
+		}
+		typ = REST
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[typ]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCatalogType, typ)
+	}
+
+	return factory(ctx, name, props)
+}
@@ -26,10 +26,17 @@ import (
 	"fmt"
 	"hash"
 	"io"
+	"log/slog"
 	"maps"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/iceberg-go"
@@ -38,12 +45,24 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/google/uuid"
 )
 
 var (
 	_ Catalog = (*RestCatalog)(nil)
 )
 
+func init() {
+	Register(REST, func(_ context.Context, name string, props iceberg.Properties) (Catalog, error) {
+		uri, ok := props[keyCatalogURI]
+		if !ok {
+			return nil, fmt.Errorf("%w: rest catalog %s requires a uri", iceberg.ErrInvalidArgument, name)
+		}
+
+		return NewRestCatalog(name, uri, func(o *options) { *o = *fromProps(props) })
+	})
+}
+
 const (
 	authorizationHeader = "Authorization"
 	bearerPrefix        = "Bearer"
@@ -77,6 +96,9 @@ type errorResponse struct {
 	Code    int    `json:"code"`
 
 	wrapping error
+	// retryAfter is the delay asked for by the Retry-After header of a
+	// 503 response, if any.
+	retryAfter time.Duration
 }
 
 func (e errorResponse) Unwrap() error { return e.wrapping }
@@ -114,16 +136,75 @@ func (o oauthErrorResponse) Error() string {
 type configResponse struct {
 	Defaults  iceberg.Properties `json:"defaults"`
 	Overrides iceberg.Properties `json:"overrides"`
+	Endpoints []string           `json:"endpoints,omitempty"`
+}
+
+const endpointReportMetrics = "POST /v1/{prefix}/namespaces/{namespace}/tables/{table}/metrics"
+
+// supportsEndpoint returns whether the server supports the endpoint. Servers
+// which don't advertise their endpoints are assumed to support the default
+// set of endpoints, which includes reporting metrics.
+func (c configResponse) supportsEndpoint(endpoint string) bool {
+	if c.Endpoints == nil {
+		return true
+	}
+
+	for _, e := range c.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+type headersCtxKey struct{}
+
+// ContextWithHeaders returns a copy of ctx carrying headers which are
+// added to the REST catalog requests made with it, such as a trace id.
+// They replace any headers of the same name set with WithExtraHeaders,
+// except for the reserved Authorization and SigV4 headers which are never
+// overwritten. Headers added to a context which already carries headers
+// are merged with them.
+func ContextWithHeaders(ctx context.Context, hdrs http.Header) context.Context {
+	merged := http.Header{}
+	if existing, ok := ctx.Value(headersCtxKey{}).(http.Header); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range hdrs {
+		merged[http.CanonicalHeaderKey(k)] = slices.Clone(v)
+	}
+	return context.WithValue(ctx, headersCtxKey{}, merged)
+}
+
+// isReservedHeader returns true for headers which carry the catalog's
+// credentials, which extra headers must not replace.
+func isReservedHeader(key string) bool {
+	key = http.CanonicalHeaderKey(key)
+	return key == authorizationHeader || strings.HasPrefix(key, "X-Amz-")
+}
+
+// setExtraHeaders sets the headers on the request, skipping the reserved
+// ones.
+func setExtraHeaders(dst, hdrs http.Header) {
+	for k, v := range hdrs {
+		if !isReservedHeader(k) {
+			dst[http.CanonicalHeaderKey(k)] = slices.Clone(v)
+		}
+	}
 }
 
 type sessionTransport struct {
 	http.Transport
 
 	defaultHeaders http.Header
+	extraHeaders   http.Header
 	signer         v4.HTTPSigner
 	cfg            aws.Config
 	service        string
 	h              hash.Hash
+	logger         *slog.Logger
 }
 
 // from https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/aws/signer/v4#Signer.SignHTTP
@@ -135,6 +216,10 @@ func (s *sessionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 			r.Header.Add(k, hdr)
 		}
 	}
+	setExtraHeaders(r.Header, s.extraHeaders)
+	if hdrs, ok := r.Context().Value(headersCtxKey{}).(http.Header); ok {
+		setExtraHeaders(r.Header, hdrs)
+	}
 
 	if s.signer != nil {
 		var payloadHash string
@@ -167,7 +252,17 @@ func (s *sessionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 		}
 	}
 
-	return s.Transport.RoundTrip(r)
+	// only the method and URL are logged, the headers carry credentials
+	start := time.Now()
+	rsp, err := s.Transport.RoundTrip(r)
+	if err != nil {
+		s.logger.DebugContext(r.Context(), "rest request failed", "method", r.Method,
+			"url", r.URL.Redacted(), "duration", time.Since(start), "error", err)
+		return nil, err
+	}
+	s.logger.DebugContext(r.Context(), "rest request", "method", r.Method,
+		"url", r.URL.Redacted(), "status", rsp.StatusCode, "duration", time.Since(start))
+	return rsp, nil
 }
 
 func do[T any](ctx context.Context, method string, baseURI *url.URL, path []string, cl *http.Client, override map[int]error, allowNoContent bool) (ret T, err error) {
@@ -176,7 +271,7 @@ func do[T any](ctx context.Context, method string, baseURI *url.URL, path []stri
 		rsp *http.Response
 	)
 
-	uri := baseURI.JoinPath(path...).String()
+	uri := baseURI.JoinPath(escapePathSegments(path)...).String()
 	if req, err = http.NewRequestWithContext(ctx, method, uri, nil); err != nil {
 		return
 	}
@@ -184,16 +279,21 @@ func do[T any](ctx context.Context, method string, baseURI *url.URL, path []stri
 	if rsp, err = cl.Do(req); err != nil {
 		return
 	}
+	defer closeBody(rsp)
 
 	if allowNoContent && rsp.StatusCode == http.StatusNoContent {
 		return
 	}
 
+	// responses to HEAD requests never have a body to decode
+	if method == http.MethodHead && rsp.StatusCode == http.StatusOK {
+		return
+	}
+
 	if rsp.StatusCode != http.StatusOK {
 		return ret, handleNon200(rsp, override)
 	}
 
-	defer rsp.Body.Close()
 	if err = json.NewDecoder(rsp.Body).Decode(&ret); err != nil {
 		return ret, fmt.Errorf("%w: error decoding json payload: `%s`", ErrRESTError, err.Error())
 	}
@@ -201,10 +301,38 @@ func do[T any](ctx context.Context, method string, baseURI *url.URL, path []stri
 	return
 }
 
+// closeBody drains and closes the body of rsp, so that its connection
+// can be reused even if the body wasn't read.
+func closeBody(rsp *http.Response) {
+	_, _ = io.Copy(io.Discard, rsp.Body)
+	rsp.Body.Close()
+}
+
+// escapePathSegments percent-encodes each of the path segments so that
+// namespace and table names containing reserved characters such as '/'
+// or '%' are sent as a single segment. Namespace levels are joined by the
+// 0x1F unit separator before escaping, so they're encoded as %1F.
+func escapePathSegments(path []string) []string {
+	out := make([]string, len(path))
+	for i, p := range path {
+		out[i] = url.PathEscape(p)
+		// dot segments would otherwise be removed when joining the path
+		if strings.Trim(p, ".") == "" {
+			out[i] = strings.ReplaceAll(out[i], ".", "%2E")
+		}
+	}
+	return out
+}
+
 func doGet[T any](ctx context.Context, baseURI *url.URL, path []string, cl *http.Client, override map[int]error) (ret T, err error) {
 	return do[T](ctx, http.MethodGet, baseURI, path, cl, override, false)
 }
 
+func doHead(ctx context.Context, baseURI *url.URL, path []string, cl *http.Client, override map[int]error) error {
+	_, err := do[struct{}](ctx, http.MethodHead, baseURI, path, cl, override, true)
+	return err
+}
+
 func doDelete[T any](ctx context.Context, baseURI *url.URL, path []string, cl *http.Client, override map[int]error) (ret T, err error) {
 	return do[T](ctx, http.MethodDelete, baseURI, path, cl, override, true)
 }
@@ -216,7 +344,7 @@ func doPost[Payload, Result any](ctx context.Context, baseURI *url.URL, path []s
 		data []byte
 	)
 
-	uri := baseURI.JoinPath(path...).String()
+	uri := baseURI.JoinPath(escapePathSegments(path)...).String()
 	data, err = json.Marshal(payload)
 	if err != nil {
 		return
@@ -233,6 +361,11 @@ func doPost[Payload, Result any](ctx context.Context, baseURI *url.URL, path []s
 	if err != nil {
 		return
 	}
+	defer closeBody(rsp)
+
+	if rsp.StatusCode == http.StatusNoContent {
+		return
+	}
 
 	if rsp.StatusCode != http.StatusOK {
 		return ret, handleNon200(rsp, override)
@@ -242,7 +375,6 @@ func doPost[Payload, Result any](ctx context.Context, baseURI *url.URL, path []s
 		return
 	}
 
-	defer rsp.Body.Close()
 	if err = json.NewDecoder(rsp.Body).Decode(&ret); err != nil {
 		return ret, fmt.Errorf("%w: error decoding json payload: `%s`", ErrRESTError, err.Error())
 	}
@@ -250,6 +382,28 @@ func doPost[Payload, Result any](ctx context.Context, baseURI *url.URL, path []s
 	return
 }
 
+// errorTypes maps the type of a REST error response, which is the name of
+// the exception raised by the server, to the corresponding error. These
+// are more specific than the status code, such as when loading a table
+// in a namespace which doesn't exist. AlreadyExistsException isn't mapped
+// as only the endpoint knows whether it refers to a table or a namespace.
+var errorTypes = map[string]error{
+	"NoSuchTableException":          ErrNoSuchTable,
+	"NoSuchNamespaceException":      ErrNoSuchNamespace,
+	"NoSuchViewException":           ErrNoSuchView,
+	"NamespaceNotEmptyException":    ErrNamespaceNotEmpty,
+	"CommitFailedException":         ErrCommitFailed,
+	"CommitStateUnknownException":   ErrCommitStateUnknown,
+	"ForbiddenException":            ErrForbidden,
+	"NotAuthorizedException":        ErrUnauthorized,
+	"BadRequestException":           ErrBadRequest,
+	"IllegalArgumentException":      ErrBadRequest,
+	"ValidationException":           ErrBadRequest,
+	"UnsupportedOperationException": iceberg.ErrNotImplemented,
+	"ServiceUnavailableException":   ErrServiceUnavailable,
+	"ServiceFailureException":       ErrServerError,
+}
+
 func handleNon200(rsp *http.Response, override map[int]error) error {
 	var e errorResponse
 
@@ -258,11 +412,26 @@ func handleNon200(rsp *http.Response, override map[int]error) error {
 		Error *errorResponse `json:"error"`
 	}{Error: &e})
 
-	if override != nil {
-		if err, ok := override[rsp.StatusCode]; ok {
-			e.wrapping = err
-			return e
-		}
+	if rsp.StatusCode == http.StatusServiceUnavailable {
+		e.retryAfter = parseRetryAfter(rsp.Header.Get("Retry-After"), time.Now())
+	}
+
+	// overrides of server errors take precedence over the error type, so
+	// that a commit failing with any server error is reported as having
+	// an unknown state rather than as a failure which can be retried.
+	if err, ok := override[rsp.StatusCode]; ok && rsp.StatusCode >= 500 {
+		e.wrapping = err
+		return typedError(e)
+	}
+
+	if err, ok := errorTypes[e.Type]; ok {
+		e.wrapping = err
+		return typedError(e)
+	}
+
+	if err, ok := override[rsp.StatusCode]; ok {
+		e.wrapping = err
+		return typedError(e)
 	}
 
 	switch rsp.StatusCode {
@@ -288,6 +457,34 @@ func handleNon200(rsp *http.Response, override map[int]error) error {
 		}
 	}
 
+	return typedError(e)
+}
+
+// parseRetryAfter returns the delay given by a Retry-After header, which
+// is either a number of seconds or an HTTP date, or zero if there is none.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// typedError wraps an error response in the error type for its category,
+// so that callers can use errors.As to decide how to handle it.
+func typedError(e errorResponse) error {
+	switch {
+	case errors.Is(e, ErrNoSuchTable), errors.Is(e, ErrNoSuchNamespace):
+		return &NotFoundError{Err: e}
+	case errors.Is(e, ErrCommitFailed):
+		return &CommitFailedError{Err: e}
+	case errors.Is(e, ErrCommitStateUnknown):
+		return &CommitStateUnknownError{Err: e}
+	case errors.Is(e, ErrBadRequest), e.Code == http.StatusUnprocessableEntity:
+		return &ValidationError{Err: e}
+	}
 	return e
 }
 
@@ -296,6 +493,9 @@ func ToRestIdentifier(ident ...string) table.Identifier {
 		if ident[0] == "" {
 			return nil
 		}
+		if parsed, err := table.ParseIdentifier(ident[0]); err == nil {
+			return parsed
+		}
 		return table.Identifier(strings.Split(ident[0], "."))
 	}
 
@@ -359,12 +559,49 @@ func toProps(o *options) iceberg.Properties {
 	return props
 }
 
+// endpointFailureCooldown is how long an endpoint which failed is tried
+// after the catalog's other endpoints.
+const endpointFailureCooldown = time.Minute
+
+const (
+	// retryBaseDelay and retryMaxDelay bound the exponential backoff
+	// between attempts against the same endpoint.
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+	// retryAfterMaxDelay caps the delay a server can ask for with a
+	// Retry-After header.
+	retryAfterMaxDelay = time.Minute
+)
+
+// restEndpoint is one of the base URIs a RestCatalog sends requests to,
+// along with the session used for it. Sessions are created lazily, since
+// the OAuth token and SigV4 region are specific to each endpoint.
+type restEndpoint struct {
+	root        *url.URL
+	baseURI     *url.URL
+	sigv4Region string
+
+	cl          *http.Client
+	lastFailure time.Time
+}
+
 type RestCatalog struct {
-	baseURI *url.URL
-	cl      *http.Client
+	mx        sync.Mutex
+	endpoints []*restEndpoint
+	opts      *options
+	retries   int
 
 	name  string
 	props iceberg.Properties
+
+	metricsReporting bool
+	clock            table.Clock
+	tableUUIDs       func(table.Identifier) uuid.UUID
+	locationTemplate func(namespace table.Identifier, name string) string
+	inheritedProps   []string
+	logger           *slog.Logger
+	requestTimeout   time.Duration
+	operationTimeout time.Duration
 }
 
 func NewRestCatalog(name, uri string, opts ...Option[RestCatalog]) (*RestCatalog, error) {
@@ -373,34 +610,230 @@ func NewRestCatalog(name, uri string, opts ...Option[RestCatalog]) (*RestCatalog
 		o(ops)
 	}
 
-	baseuri, err := url.Parse(uri)
-	if err != nil {
-		return nil, err
-	}
-
 	r := &RestCatalog{
 		name:    name,
-		baseURI: baseuri.JoinPath("v1"),
+		opts:    ops,
+		retries: max(ops.endpointRetries, 1),
+		logger:  loggerOrDiscard(ops.logger),
+
+		requestTimeout:   ops.requestTimeout,
+		operationTimeout: ops.operationTimeout,
 	}
 
-	if ops, err = r.fetchConfig(ops); err != nil {
-		return nil, err
+	endpoints := append([]RestEndpoint{{URI: uri}}, ops.endpoints...)
+	for _, e := range endpoints {
+		baseuri, err := url.Parse(e.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		baseuri = baseuri.JoinPath("v1")
+		r.endpoints = append(r.endpoints, &restEndpoint{
+			root: baseuri, baseURI: baseuri, sigv4Region: e.SigV4Region})
 	}
 
-	cl, err := r.createSession(ops)
+	ops, err := r.fetchConfig(ops)
 	if err != nil {
 		return nil, err
 	}
 
-	r.cl = cl
-	if ops.prefix != "" {
-		r.baseURI = r.baseURI.JoinPath(ops.prefix)
+	// sessions are recreated with the configuration from the server
+	r.opts = ops
+	for _, ep := range r.endpoints {
+		ep.cl = nil
+		if ops.prefix != "" {
+			ep.baseURI = ep.root.JoinPath(ops.prefix)
+		}
+	}
+
+	// authenticate eagerly so that credential errors are reported here
+	// rather than on the first request
+	if err = r.call(context.Background(), true, func(context.Context, *url.URL, *http.Client) error { return nil }); err != nil {
+		return nil, err
 	}
+
 	r.props = toProps(ops)
+	r.metricsReporting = ops.metricsReporting
+	r.clock = ops.clock
+	r.tableUUIDs = ops.tableUUIDs
+	r.locationTemplate = ops.locationTemplate
+	r.inheritedProps = ops.inheritedProps
 	return r, nil
 }
 
-func (r *RestCatalog) fetchAccessToken(cl *http.Client, creds string, opts *options) (string, error) {
+// orderedEndpoints returns the endpoints in the order they should be
+// tried: those which failed within the last endpointFailureCooldown are
+// moved to the end, least recently failed first.
+func (r *RestCatalog) orderedEndpoints() []*restEndpoint {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	now := time.Now()
+	failedRecently := func(ep *restEndpoint) bool {
+		return !ep.lastFailure.IsZero() && now.Sub(ep.lastFailure) < endpointFailureCooldown
+	}
+
+	eps := slices.Clone(r.endpoints)
+	sort.SliceStable(eps, func(i, j int) bool {
+		a, b := failedRecently(eps[i]), failedRecently(eps[j])
+		if a != b {
+			return b
+		}
+		return a && eps[i].lastFailure.Before(eps[j].lastFailure)
+	})
+	return eps
+}
+
+func (r *RestCatalog) session(ep *restEndpoint) (*http.Client, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if ep.cl == nil {
+		cl, err := r.createSession(ep, r.opts)
+		if err != nil {
+			return nil, err
+		}
+		ep.cl = cl
+	}
+	return ep.cl, nil
+}
+
+func (r *RestCatalog) setFailed(ep *restEndpoint, failed bool) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if failed {
+		ep.lastFailure = time.Now()
+	} else {
+		ep.lastFailure = time.Time{}
+	}
+}
+
+// canFailover reports whether a request which failed with err may be
+// sent again, to the same or another endpoint. Requests which aren't
+// idempotent, such as commits, are only resent if the error shows they
+// never reached the server, so that they can't be applied twice.
+func canFailover(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	if !idempotent {
+		return false
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) ||
+		errors.Is(err, ErrServerError) || errors.Is(err, ErrServiceUnavailable)
+}
+
+// call invokes fn with the base URI and client of each of the catalog's
+// endpoints in turn until it succeeds, making up to the configured number
+// of attempts against each endpoint before failing over to the next. The
+// error from the last attempt is returned if all of the endpoints fail.
+//
+// The context passed to fn is limited by the request timeout for each
+// attempt, and ctx by the operation timeout for the call as a whole. An
+// idempotent request whose attempt timed out is retried, as long as ctx
+// itself hasn't expired.
+//
+// Attempts against the same endpoint are spaced out by retryDelay. If ctx
+// would expire before the next attempt could start, the call fails over
+// to the next endpoint straight away.
+func (r *RestCatalog) call(ctx context.Context, idempotent bool, fn func(ctx context.Context, baseURI *url.URL, cl *http.Client) error) error {
+	ctx, cancel := withTimeout(ctx, r.operationTimeout)
+	defer cancel()
+
+	var err error
+	for _, ep := range r.orderedEndpoints() {
+		var cl *http.Client
+		if cl, err = r.session(ep); err != nil {
+			if !canFailover(err, true) {
+				return err
+			}
+			r.logger.Warn("failed to create rest catalog session",
+				"endpoint", ep.root.Redacted(), "error", err)
+			r.setFailed(ep, true)
+			continue
+		}
+
+		for attempt := 0; attempt < r.retries; attempt++ {
+			attemptCtx, cancelAttempt := withTimeout(ctx, r.requestTimeout)
+			err = fn(attemptCtx, ep.baseURI, cl)
+			cancelAttempt()
+			if err == nil {
+				r.setFailed(ep, false)
+				return nil
+			}
+
+			if ctx.Err() != nil {
+				return err
+			}
+
+			attemptTimedOut := errors.Is(err, context.DeadlineExceeded)
+			if !(attemptTimedOut && idempotent) && !canFailover(err, idempotent) {
+				return err
+			}
+			if attempt+1 == r.retries {
+				break
+			}
+
+			delay := retryDelay(attempt, err)
+			r.logger.Debug("rest catalog request failed, retrying",
+				"endpoint", ep.root.Redacted(), "attempt", attempt+1, "delay", delay, "error", err)
+			if !sleepCtx(ctx, delay) {
+				if ctx.Err() != nil {
+					return err
+				}
+				break
+			}
+		}
+		r.logger.Warn("rest catalog endpoint failed",
+			"endpoint", ep.root.Redacted(), "error", err)
+		r.setFailed(ep, true)
+	}
+
+	return err
+}
+
+// retryDelay returns how long to wait before retrying a request which
+// failed with err on the given attempt, counting from zero. This is the
+// delay asked for by a 503 response's Retry-After header if there is one,
+// otherwise an exponential backoff with jitter so that clients failing at
+// the same time don't retry in lockstep.
+func retryDelay(attempt int, err error) time.Duration {
+	var e errorResponse
+	if errors.As(err, &e) && e.retryAfter > 0 {
+		return min(e.retryAfter, retryAfterMaxDelay)
+	}
+
+	d := min(retryBaseDelay<<min(attempt, 8), retryMaxDelay)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// sleepCtx waits for d, returning false without waiting if ctx would
+// expire first, or as soon as ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func (r *RestCatalog) fetchAccessToken(cl *http.Client, baseURI *url.URL, creds string, opts *options) (string, error) {
 	clientID, clientSecret, hasID := strings.Cut(creds, ":")
 	if !hasID {
 		clientID, clientSecret = "", clientID
@@ -415,7 +848,7 @@ func (r *RestCatalog) fetchAccessToken(cl *http.Client, creds string, opts *opti
 
 	uri := opts.authUri
 	if uri == nil {
-		uri = r.baseURI.JoinPath("oauth/tokens")
+		uri = baseURI.JoinPath("oauth/tokens")
 	}
 
 	rsp, err := cl.PostForm(uri.String(), data)
@@ -449,19 +882,22 @@ func (r *RestCatalog) fetchAccessToken(cl *http.Client, creds string, opts *opti
 	}
 }
 
-func (r *RestCatalog) createSession(opts *options) (*http.Client, error) {
+func (r *RestCatalog) createSession(ep *restEndpoint, opts *options) (*http.Client, error) {
 	session := &sessionTransport{
 		Transport:      http.Transport{TLSClientConfig: opts.tlsConfig},
 		defaultHeaders: http.Header{},
+		extraHeaders:   opts.extraHeaders,
+		logger:         r.logger,
 	}
 	cl := &http.Client{Transport: session}
 
 	token := opts.oauthToken
 	if token == "" && opts.credential != "" {
 		var err error
-		if token, err = r.fetchAccessToken(cl, opts.credential, opts); err != nil {
+		if token, err = r.fetchAccessToken(cl, ep.root, opts.credential, opts); err != nil {
 			return nil, fmt.Errorf("auth error: %w", err)
 		}
+		r.logger.Debug("fetched rest catalog access token", "endpoint", ep.root.Redacted())
 	}
 
 	if token != "" {
@@ -479,7 +915,9 @@ func (r *RestCatalog) createSession(opts *options) (*http.Client, error) {
 			return nil, err
 		}
 
-		if opts.sigv4Region != "" {
+		if ep.sigv4Region != "" {
+			cfg.Region = ep.sigv4Region
+		} else if opts.sigv4Region != "" {
 			cfg.Region = opts.sigv4Region
 		}
 
@@ -496,15 +934,14 @@ func (r *RestCatalog) fetchConfig(opts *options) (*options, error) {
 		params.Set(keyWarehouseLocation, opts.warehouseLocation)
 	}
 
-	route := r.baseURI.JoinPath("config")
-	route.RawQuery = params.Encode()
-
-	sess, err := r.createSession(opts)
-	if err != nil {
-		return nil, err
-	}
+	var rsp configResponse
+	err := r.call(context.Background(), true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		route := baseURI.JoinPath("config")
+		route.RawQuery = params.Encode()
 
-	rsp, err := doGet[configResponse](context.Background(), route, []string{}, sess, nil)
+		rsp, err = doGet[configResponse](ctx, route, []string{}, cl, nil)
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -516,13 +953,27 @@ func (r *RestCatalog) fetchConfig(opts *options) (*options, error) {
 	o := fromProps(cfg)
 	o.awsConfig = opts.awsConfig
 	o.tlsConfig = opts.tlsConfig
-
-	if uri, ok := cfg["uri"]; ok {
-		r.baseURI, err = url.Parse(uri)
+	o.metricsReporting = opts.metricsReporting && rsp.supportsEndpoint(endpointReportMetrics)
+	o.clock = opts.clock
+	o.endpoints = opts.endpoints
+	o.endpointRetries = opts.endpointRetries
+	o.tableUUIDs = opts.tableUUIDs
+	o.locationTemplate = opts.locationTemplate
+	o.inheritedProps = opts.inheritedProps
+	o.logger = opts.logger
+	o.requestTimeout = opts.requestTimeout
+	o.operationTimeout = opts.operationTimeout
+	o.extraHeaders = opts.extraHeaders
+
+	// the server can only redirect the catalog to another URI if it
+	// wasn't configured with several endpoints
+	if uri, ok := cfg["uri"]; ok && len(r.endpoints) == 1 {
+		baseURI, err := url.Parse(uri)
 		if err != nil {
 			return nil, err
 		}
-		r.baseURI = r.baseURI.JoinPath("v1")
+		r.endpoints[0].root = baseURI.JoinPath("v1")
+		r.endpoints[0].baseURI = r.endpoints[0].root
 	}
 
 	return o, nil
@@ -552,7 +1003,11 @@ func (r *RestCatalog) ListTables(ctx context.Context, namespace table.Identifier
 		} `json:"identifiers"`
 	}
 
-	rsp, err := doGet[resp](ctx, r.baseURI, path, r.cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+	var rsp resp
+	err := r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		rsp, err = doGet[resp](ctx, baseURI, path, cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -600,12 +1055,63 @@ func (r *RestCatalog) LoadTable(ctx context.Context, identifier table.Identifier
 		props = iceberg.Properties{}
 	}
 
-	ret, err := doGet[tblResponse](ctx, r.baseURI, []string{"namespaces", ns, "tables", tbl},
-		r.cl, map[int]error{http.StatusNotFound: ErrNoSuchTable})
+	var ret tblResponse
+	err = r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		ret, err = doGet[tblResponse](ctx, baseURI, []string{"namespaces", ns, "tables", tbl},
+			cl, map[int]error{http.StatusNotFound: ErrNoSuchTable})
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return r.tableFromResponse(identifier, props, ret)
+}
+
+// TableExists checks for the table with a HEAD request, which unlike
+// LoadTable doesn't fetch the table's metadata.
+func (r *RestCatalog) TableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) error {
+		return doHead(ctx, baseURI, []string{"namespaces", ns, "tables", tbl},
+			cl, map[int]error{http.StatusNotFound: ErrNoSuchTable})
+	})
+	return checkExists(err, ErrNoSuchTable)
+}
+
+// ViewExists checks for the view with a HEAD request, which unlike
+// loading the view doesn't fetch its metadata.
+func (r *RestCatalog) ViewExists(ctx context.Context, identifier table.Identifier) (bool, error) {
+	ns, view, err := splitIdentForPath(identifier)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) error {
+		return doHead(ctx, baseURI, []string{"namespaces", ns, "views", view},
+			cl, map[int]error{http.StatusNotFound: ErrNoSuchView})
+	})
+	return checkExists(err, ErrNoSuchView)
+}
+
+// checkExists converts the result of a HEAD request into whether the
+// resource exists, treating notFound as absence rather than an error.
+func checkExists(err, notFound error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, notFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (r *RestCatalog) tableFromResponse(identifier table.Identifier, props iceberg.Properties, ret tblResponse) (*table.Table, error) {
 	id := identifier
 	if r.name != "" {
 		id = append([]string{r.name}, identifier...)
@@ -618,19 +1124,300 @@ func (r *RestCatalog) LoadTable(ctx context.Context, identifier table.Identifier
 		tblProps[k] = v
 	}
 
-	iofs, err := iceio.LoadFS(tblProps, ret.MetadataLoc)
+	iofs, err := iceio.LoadFSForLocations(tblProps, ret.MetadataLoc)
+	if err != nil {
+		return nil, err
+	}
+	result := table.New(id, ret.Metadata, ret.MetadataLoc, iofs).
+		WithCatalog(r, identifier, props)
+	if r.clock != nil {
+		result = result.WithClock(r.clock)
+	}
+	return result.PinFromProperties(props)
+}
+
+// CreateTable creates the table in the catalog, which creates the initial
+// metadata for it. The options are validated against the schema before
+// sending the request, so that, for example, types which aren't supported
+// by the requested format version are rejected.
+func (r *RestCatalog) CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) (*table.Table, error) {
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, schema, err := newCreateTableCfg(schema, opts)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TableUUID == uuid.Nil && r.tableUUIDs != nil {
+		cfg.TableUUID = r.tableUUIDs(identifier)
+	}
+	if cfg.Location == "" && r.locationTemplate != nil {
+		cfg.Location = r.locationTemplate(NamespaceFromIdent(identifier), TableNameFromIdent(identifier))
+	}
+	if len(r.inheritedProps) > 0 {
+		nsProps, err := r.LoadNamespaceProperties(ctx, NamespaceFromIdent(identifier))
+		if err != nil {
+			return nil, err
+		}
+		cfg.Properties = inheritProperties(r.inheritedProps, nsProps, cfg.Properties)
+	}
+	// the create request can't carry a snapshot or UUID, so the table
+	// is staged and those are added when committing it
+	stageCreate := cfg.CloneFrom != nil || cfg.TableUUID != uuid.Nil
+
+	type payload struct {
+		Name          string                 `json:"name"`
+		Location      string                 `json:"location,omitempty"`
+		Schema        *iceberg.Schema        `json:"schema"`
+		PartitionSpec *iceberg.PartitionSpec `json:"partition-spec,omitempty"`
+		WriteOrder    *table.SortOrder       `json:"write-order,omitempty"`
+		StageCreate   bool                   `json:"stage-create"`
+		Props         iceberg.Properties     `json:"properties,omitempty"`
+	}
+
+	var ret tblResponse
+	err = r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		ret, err = doPost[payload, tblResponse](ctx, baseURI, []string{"namespaces", ns, "tables"},
+			payload{
+				Name:          tbl,
+				Location:      cfg.Location,
+				Schema:        schema,
+				PartitionSpec: cfg.PartitionSpec,
+				WriteOrder:    &cfg.SortOrder,
+				StageCreate:   stageCreate,
+				Props:         cfg.Properties,
+			}, cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace, http.StatusConflict: ErrTableAlreadyExists})
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
-	return table.New(id, ret.Metadata, ret.MetadataLoc, iofs), nil
+
+	if stageCreate {
+		if ret, err = r.commitStaged(ctx, identifier, ret, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.tableFromResponse(identifier, nil, ret)
+}
+
+// commitStaged commits a staged table with the UUID and clone snapshot
+// from cfg. For a clone, it first checks that the new table will be able
+// to read the data files of the source table.
+func (r *RestCatalog) commitStaged(ctx context.Context, identifier table.Identifier, staged tblResponse, cfg CreateTableCfg) (tblResponse, error) {
+	if src := cfg.CloneFrom; src != nil {
+		props := maps.Clone(r.props)
+		maps.Copy(props, staged.Metadata.Properties())
+		maps.Copy(props, staged.Config)
+		if _, err := iceio.LoadFS(props, staged.Metadata.Location()); err != nil {
+			return tblResponse{}, err
+		}
+		// files are opened with the IO for their own location, so the
+		// clone only needs to support the source table's storage
+		if _, err := iceio.LoadFS(props, src.Location()); err != nil {
+			return tblResponse{}, fmt.Errorf("%w: clone at %s can't read the files of source table at %s: %s",
+				iceberg.ErrInvalidArgument, staged.Metadata.Location(), src.Location(), err)
+		}
+	}
+
+	clock := r.clock
+	if clock == nil {
+		clock = table.SystemClock
+	}
+	updates, err := stagedCreateUpdates(staged.Metadata, cfg, clock)
+	if err != nil {
+		return tblResponse{}, err
+	}
+
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err != nil {
+		return tblResponse{}, err
+	}
+
+	r.logger.DebugContext(ctx, "committing staged table", "table", strings.Join(identifier, "."),
+		"updates", len(updates))
+
+	var ret tblResponse
+	err = r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		ret, err = doPost[commitTablePayload, tblResponse](ctx, baseURI, []string{"namespaces", ns, "tables", tbl},
+			newCommitTablePayload(identifier, []table.Requirement{table.AssertCreate()}, updates),
+			cl, map[int]error{
+				http.StatusNotFound:            ErrNoSuchNamespace,
+				http.StatusConflict:            ErrTableAlreadyExists,
+				http.StatusInternalServerError: ErrCommitStateUnknown,
+				http.StatusBadGateway:          ErrCommitStateUnknown,
+				http.StatusServiceUnavailable:  ErrCommitStateUnknown,
+				http.StatusGatewayTimeout:      ErrCommitStateUnknown,
+			})
+		return
+	})
+	return ret, commitError(err)
+}
+
+type commitTablePayload struct {
+	Identifier struct {
+		Namespace table.Identifier `json:"namespace"`
+		Name      string           `json:"name"`
+	} `json:"identifier"`
+	Requirements []table.Requirement `json:"requirements"`
+	Updates      []table.Update      `json:"updates"`
+}
+
+func newCommitTablePayload(identifier table.Identifier, reqs []table.Requirement, updates []table.Update) commitTablePayload {
+	p := commitTablePayload{Requirements: reqs, Updates: updates}
+	p.Identifier.Namespace = NamespaceFromIdent(identifier)
+	p.Identifier.Name = TableNameFromIdent(identifier)
+	return p
+}
+
+// CommitTable commits the updates to the table, provided the requirements
+// hold for its current metadata on the server. A requirement which doesn't
+// hold results in a CommitFailedError, after which the table should be
+// refreshed and the changes retried.
+func (r *RestCatalog) CommitTable(ctx context.Context, identifier table.Identifier, requirements []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if requirements == nil {
+		requirements = []table.Requirement{}
+	}
+
+	r.logger.DebugContext(ctx, "committing table", "table", strings.Join(identifier, "."),
+		"updates", len(updates))
+
+	var ret tblResponse
+	err = r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		ret, err = doPost[commitTablePayload, tblResponse](ctx, baseURI, []string{"namespaces", ns, "tables", tbl},
+			newCommitTablePayload(identifier, requirements, updates),
+			cl, map[int]error{
+				http.StatusNotFound:            ErrNoSuchTable,
+				http.StatusConflict:            ErrCommitFailed,
+				http.StatusInternalServerError: ErrCommitStateUnknown,
+				http.StatusBadGateway:          ErrCommitStateUnknown,
+				http.StatusServiceUnavailable:  ErrCommitStateUnknown,
+				http.StatusGatewayTimeout:      ErrCommitStateUnknown,
+			})
+		return
+	})
+	if err != nil {
+		return nil, "", commitError(err)
+	}
+	return ret.Metadata, ret.MetadataLoc, nil
+}
+
+// commitError converts a transport error from a commit, which may have
+// been applied by the server before the connection failed, into a
+// CommitStateUnknownError. Other errors are returned unchanged.
+func commitError(err error) error {
+	var (
+		urlErr *url.Error
+		opErr  *net.OpError
+	)
+	if !errors.As(err, &urlErr) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	// the request was never sent if the connection couldn't be made
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return err
+	}
+	return &CommitStateUnknownError{Err: err}
+}
+
+// ReportMetrics sends the scan report to the catalog's metrics endpoint if
+// metrics reporting was enabled with WithMetricsReporting. Reporting metrics
+// is best effort, so failures are logged rather than returned.
+func (r *RestCatalog) ReportMetrics(ctx context.Context, identifier table.Identifier, report table.ScanReport) {
+	if !r.metricsReporting {
+		return
+	}
+
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err == nil {
+		err = r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+			_, err = doPost[table.ScanReport, struct{}](ctx, baseURI,
+				[]string{"namespaces", ns, "tables", tbl, "metrics"}, report, cl,
+				map[int]error{http.StatusNotFound: ErrNoSuchTable})
+			return
+		})
+	}
+
+	if err != nil {
+		r.logger.WarnContext(ctx, "failed to report scan metrics",
+			"table", strings.Join(identifier, "."), "error", err)
+	}
 }
 
 func (r *RestCatalog) DropTable(ctx context.Context, identifier table.Identifier) error {
-	return fmt.Errorf("%w: [Rest Catalog] drop table", iceberg.ErrNotImplemented)
+	return r.dropTable(ctx, identifier, false)
 }
 
+// PurgeTable drops the table and requests that the catalog also delete the
+// table's data and metadata files.
+func (r *RestCatalog) PurgeTable(ctx context.Context, identifier table.Identifier) error {
+	return r.dropTable(ctx, identifier, true)
+}
+
+func (r *RestCatalog) dropTable(ctx context.Context, identifier table.Identifier, purge bool) error {
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err != nil {
+		return err
+	}
+
+	return r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		uri := *baseURI
+		if purge {
+			v := url.Values{}
+			v.Set("purgeRequested", "true")
+			uri.RawQuery = v.Encode()
+		}
+
+		_, err = doDelete[struct{}](ctx, &uri, []string{"namespaces", ns, "tables", tbl}, cl,
+			map[int]error{http.StatusNotFound: ErrNoSuchTable})
+		return
+	})
+}
+
+// RenameTable renames the table with the tables/rename endpoint, which
+// only changes the identifier the catalog stores for the table, and then
+// loads the table from its new identifier. Tables can be moved between
+// namespaces, if the destination namespace doesn't exist an error wrapping
+// ErrNoSuchNamespace is returned, and if the destination table already
+// exists one wrapping ErrTableAlreadyExists.
 func (r *RestCatalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
-	return nil, fmt.Errorf("%w: [Rest Catalog] rename table", iceberg.ErrNotImplemented)
+	if _, _, err := splitIdentForPath(from); err != nil {
+		return nil, err
+	}
+	if _, _, err := splitIdentForPath(to); err != nil {
+		return nil, err
+	}
+
+	type tableIdent struct {
+		Namespace table.Identifier `json:"namespace"`
+		Name      string           `json:"name"`
+	}
+	type payload struct {
+		Source      tableIdent `json:"source"`
+		Destination tableIdent `json:"destination"`
+	}
+
+	err := r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		_, err = doPost[payload, struct{}](ctx, baseURI, []string{"tables", "rename"},
+			payload{
+				Source:      tableIdent{Namespace: NamespaceFromIdent(from), Name: TableNameFromIdent(from)},
+				Destination: tableIdent{Namespace: NamespaceFromIdent(to), Name: TableNameFromIdent(to)},
+			}, cl, map[int]error{http.StatusNotFound: ErrNoSuchTable, http.StatusConflict: ErrTableAlreadyExists})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LoadTable(ctx, to, nil)
 }
 
 func (r *RestCatalog) CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error {
@@ -638,10 +1425,12 @@ func (r *RestCatalog) CreateNamespace(ctx context.Context, namespace table.Ident
 		return err
 	}
 
-	_, err := doPost[map[string]any, struct{}](ctx, r.baseURI, []string{"namespaces"},
-		map[string]any{"namespace": namespace, "properties": props}, r.cl, map[int]error{
-			http.StatusNotFound: ErrNoSuchNamespace, http.StatusConflict: ErrNamespaceAlreadyExists})
-	return err
+	return r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		_, err = doPost[map[string]any, struct{}](ctx, baseURI, []string{"namespaces"},
+			map[string]any{"namespace": namespace, "properties": props}, cl, map[int]error{
+				http.StatusNotFound: ErrNoSuchNamespace, http.StatusConflict: ErrNamespaceAlreadyExists})
+		return
+	})
 }
 
 func (r *RestCatalog) DropNamespace(ctx context.Context, namespace table.Identifier) error {
@@ -649,25 +1438,30 @@ func (r *RestCatalog) DropNamespace(ctx context.Context, namespace table.Identif
 		return err
 	}
 
-	_, err := doDelete[struct{}](ctx, r.baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
-		r.cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
-
-	return err
+	return r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		_, err = doDelete[struct{}](ctx, baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
+			cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace, http.StatusConflict: ErrNamespaceNotEmpty})
+		return
+	})
 }
 
 func (r *RestCatalog) ListNamespaces(ctx context.Context, parent table.Identifier) ([]table.Identifier, error) {
-	uri := r.baseURI.JoinPath("namespaces")
-	if len(parent) != 0 {
-		v := url.Values{}
-		v.Set("parent", strings.Join(parent, namespaceSeparator))
-		uri.RawQuery = v.Encode()
-	}
-
 	type rsptype struct {
 		Namespaces []table.Identifier `json:"namespaces"`
 	}
 
-	rsp, err := doGet[rsptype](ctx, uri, []string{}, r.cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+	var rsp rsptype
+	err := r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		uri := baseURI.JoinPath("namespaces")
+		if len(parent) != 0 {
+			v := url.Values{}
+			v.Set("parent", strings.Join(parent, namespaceSeparator))
+			uri.RawQuery = v.Encode()
+		}
+
+		rsp, err = doGet[rsptype](ctx, uri, []string{}, cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -675,6 +1469,19 @@ func (r *RestCatalog) ListNamespaces(ctx context.Context, parent table.Identifie
 	return rsp.Namespaces, nil
 }
 
+// NamespaceExists checks for the namespace with a HEAD request.
+func (r *RestCatalog) NamespaceExists(ctx context.Context, namespace table.Identifier) (bool, error) {
+	if err := checkValidNamespace(namespace); err != nil {
+		return false, err
+	}
+
+	err := r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) error {
+		return doHead(ctx, baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
+			cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+	})
+	return checkExists(err, ErrNoSuchNamespace)
+}
+
 func (r *RestCatalog) LoadNamespaceProperties(ctx context.Context, namespace table.Identifier) (iceberg.Properties, error) {
 	if err := checkValidNamespace(namespace); err != nil {
 		return nil, err
@@ -685,8 +1492,12 @@ func (r *RestCatalog) LoadNamespaceProperties(ctx context.Context, namespace tab
 		Props     iceberg.Properties `json:"properties"`
 	}
 
-	rsp, err := doGet[nsresponse](ctx, r.baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
-		r.cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+	var rsp nsresponse
+	err := r.call(ctx, true, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		rsp, err = doGet[nsresponse](ctx, baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
+			cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -707,6 +1518,11 @@ func (r *RestCatalog) UpdateNamespaceProperties(ctx context.Context, namespace t
 	}
 
 	ns := strings.Join(namespace, namespaceSeparator)
-	return doPost[payload, PropertiesUpdateSummary](ctx, r.baseURI, []string{"namespaces", ns, "properties"},
-		payload{Remove: removals, Updates: updates}, r.cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+	var summary PropertiesUpdateSummary
+	err := r.call(ctx, false, func(ctx context.Context, baseURI *url.URL, cl *http.Client) (err error) {
+		summary, err = doPost[payload, PropertiesUpdateSummary](ctx, baseURI, []string{"namespaces", ns, "properties"},
+			payload{Remove: removals, Updates: updates}, cl, map[int]error{http.StatusNotFound: ErrNoSuchNamespace})
+		return
+	})
+	return summary, err
 }
@@ -23,6 +23,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,14 +65,14 @@ func TestAuthHeader(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, cat)
 
-	require.IsType(t, (*sessionTransport)(nil), cat.cl.Transport)
+	require.IsType(t, (*sessionTransport)(nil), cat.endpoints[0].cl.Transport)
 	assert.Equal(t, http.Header{
 		"Authorization":               {"Bearer some_jwt_token"},
 		"Content-Type":                {"application/json"},
 		"User-Agent":                  {"GoIceberg/(unknown version)"},
 		"X-Client-Version":            {icebergRestSpecVersion},
 		"X-Iceberg-Access-Delegation": {"vended-credentials"},
-	}, cat.cl.Transport.(*sessionTransport).defaultHeaders)
+	}, cat.endpoints[0].cl.Transport.(*sessionTransport).defaultHeaders)
 }
 
 func TestAuthUriHeader(t *testing.T) {
@@ -112,12 +113,33 @@ func TestAuthUriHeader(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, cat)
 
-	require.IsType(t, (*sessionTransport)(nil), cat.cl.Transport)
+	require.IsType(t, (*sessionTransport)(nil), cat.endpoints[0].cl.Transport)
 	assert.Equal(t, http.Header{
 		"Authorization":               {"Bearer some_jwt_token"},
 		"Content-Type":                {"application/json"},
 		"User-Agent":                  {"GoIceberg/(unknown version)"},
 		"X-Client-Version":            {icebergRestSpecVersion},
 		"X-Iceberg-Access-Delegation": {"vended-credentials"},
-	}, cat.cl.Transport.(*sessionTransport).defaultHeaders)
+	}, cat.endpoints[0].cl.Transport.(*sessionTransport).defaultHeaders)
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempt := 0; attempt < 20; attempt++ {
+		d := retryDelay(attempt, ErrServerError)
+		expected := min(retryBaseDelay<<min(attempt, 8), retryMaxDelay)
+		assert.GreaterOrEqual(t, d, expected/2)
+		assert.LessOrEqual(t, d, expected)
+	}
+
+	unavailable := errorResponse{wrapping: ErrServiceUnavailable, retryAfter: 3 * time.Second}
+	assert.Equal(t, 3*time.Second, retryDelay(0, unavailable))
+	unavailable.retryAfter = time.Hour
+	assert.Equal(t, retryAfterMaxDelay, retryDelay(0, unavailable))
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, 7*time.Second, parseRetryAfter("7", now))
+	assert.Equal(t, 10*time.Second, parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
 }
@@ -18,18 +18,26 @@
 package catalog_test
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/catalog"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -135,6 +143,26 @@ func (r *RestCatalogSuite) TestToken400() {
 	r.ErrorContains(err, "invalid_client: credentials for key invalid_key do not match")
 }
 
+func (r *RestCatalogSuite) TestLoadFromProps() {
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		r.Equal("Bearer "+TestToken, req.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": []table.Identifier{{"accounting"}}})
+	})
+
+	cat, err := catalog.Load(context.Background(), "rest", iceberg.Properties{
+		"uri":       r.srv.URL,
+		"token":     TestToken,
+		"warehouse": "s3://some-bucket",
+	})
+	r.Require().NoError(err)
+	r.Equal(catalog.REST, cat.CatalogType())
+	r.Equal("s3://some-bucket", r.configVals.Get("warehouse"))
+
+	namespaces, err := cat.ListNamespaces(context.Background(), nil)
+	r.Require().NoError(err)
+	r.Equal([]table.Identifier{{"accounting"}}, namespaces)
+}
+
 func (r *RestCatalogSuite) TestToken200AuthUrl() {
 	r.mux.HandleFunc("/auth-token-url", func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(http.MethodPost, req.Method)
@@ -519,6 +547,359 @@ func (r *RestCatalogSuite) TestDropNamespace404() {
 	r.ErrorContains(err, "examples in warehouse")
 }
 
+func (r *RestCatalogSuite) TestDropNamespace409() {
+	r.mux.HandleFunc("/v1/namespaces/examples", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodDelete, req.Method)
+
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{
+				"message": "Namespace examples is not empty",
+				"type":    "NamespaceNotEmptyException",
+				"code":    409,
+			},
+		})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	err = cat.DropNamespace(context.Background(), catalog.ToRestIdentifier("examples"))
+	r.ErrorIs(err, catalog.ErrNamespaceNotEmpty)
+	r.ErrorContains(err, "is not empty")
+}
+
+func (r *RestCatalogSuite) TestDropNamespaceCascade() {
+	var deleted []string
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+
+		var children []table.Identifier
+		if req.URL.Query().Get("parent") == "accounting" {
+			children = []table.Identifier{{"accounting", "tax"}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": children})
+	})
+
+	tables := map[string][]map[string]any{
+		"accounting":        {{"namespace": []string{"accounting"}, "name": "ledger"}},
+		"accounting\x1Ftax": {{"namespace": []string{"accounting", "tax"}, "name": "returns"}},
+	}
+	r.mux.HandleFunc("/v1/namespaces/", func(w http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/v1/namespaces/")
+		ns, tbl, isTable := strings.Cut(path, "/tables")
+
+		switch {
+		case req.Method == http.MethodGet && isTable && tbl == "":
+			json.NewEncoder(w).Encode(map[string]any{"identifiers": tables[ns]})
+		case req.Method == http.MethodDelete:
+			if isTable {
+				r.Equal("true", req.URL.Query().Get("purgeRequested"))
+			}
+			deleted = append(deleted, path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			r.Failf("unexpected request", "%s %s", req.Method, req.URL)
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	dropped, err := catalog.DropNamespaceCascade(context.Background(), cat,
+		catalog.ToRestIdentifier("accounting"), true)
+	r.Require().NoError(err)
+	r.Equal([]table.Identifier{{"accounting", "tax", "returns"}, {"accounting", "ledger"}}, dropped)
+	r.Equal([]string{
+		"accounting\x1Ftax/tables/returns", "accounting\x1Ftax",
+		"accounting/tables/ledger", "accounting",
+	}, deleted)
+}
+
+func (r *RestCatalogSuite) TestListAllTables() {
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+
+		var children []table.Identifier
+		switch req.URL.Query().Get("parent") {
+		case "":
+			children = []table.Identifier{{"accounting"}, {"sales"}}
+		case "accounting":
+			// listed twice to check that namespaces are only walked once
+			children = []table.Identifier{{"accounting", "tax"}, {"accounting", "tax"}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": children})
+	})
+
+	tables := map[string][]map[string]any{
+		"accounting":        {{"namespace": []string{"accounting"}, "name": "ledger"}},
+		"accounting\x1Ftax": {{"namespace": []string{"accounting", "tax"}, "name": "returns"}},
+		"sales": {
+			{"namespace": []string{"sales"}, "name": "orders"},
+			{"namespace": []string{"sales"}, "name": "orders"},
+		},
+	}
+	r.mux.HandleFunc("/v1/namespaces/", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+		ns, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v1/namespaces/"), "/tables")
+		json.NewEncoder(w).Encode(map[string]any{"identifiers": tables[ns]})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	var found []string
+	catalog.ListAllTables(context.Background(), cat, nil)(func(ident table.Identifier, err error) bool {
+		r.Require().NoError(err)
+		found = append(found, strings.Join(ident, "."))
+		return true
+	})
+	r.ElementsMatch([]string{"accounting.ledger", "accounting.tax.returns", "sales.orders"}, found)
+
+	found = found[:0]
+	catalog.ListAllTables(context.Background(), cat, catalog.ToRestIdentifier("accounting"))(
+		func(ident table.Identifier, err error) bool {
+			r.Require().NoError(err)
+			found = append(found, strings.Join(ident, "."))
+			return false
+		})
+	r.Len(found, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var errs []error
+	catalog.ListAllTables(ctx, cat, nil)(func(ident table.Identifier, err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+	r.Require().NotEmpty(errs)
+	r.ErrorIs(errs[len(errs)-1], context.Canceled)
+}
+
+func (r *RestCatalogSuite) TestExtraHeaders() {
+	r.mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": TestToken, "token_type": "Bearer", "expires_in": 86400,
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+		})
+	})
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]any{"namespaces": []table.Identifier{{"ns"}}})
+		case http.MethodPost:
+			json.NewEncoder(w).Encode(map[string]any{"namespace": []string{"ns"}, "properties": map[string]any{}})
+		}
+	})
+	r.mux.HandleFunc("/v1/namespaces/ns", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	type request struct {
+		route   string
+		headers http.Header
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, request{req.Method + " " + req.URL.Path, req.Header.Clone()})
+		r.mux.ServeHTTP(w, req)
+	}))
+	defer srv.Close()
+
+	cat, err := catalog.NewRestCatalog("rest", srv.URL,
+		catalog.WithCredential(TestCreds),
+		catalog.WithExtraHeaders(http.Header{
+			"x-tenant":      {"acme"},
+			"X-Trace-Id":    {"default"},
+			"Authorization": {"Bearer stolen"},
+			"X-Amz-Date":    {"20240101T000000Z"},
+		}))
+	r.Require().NoError(err)
+
+	ctx := catalog.ContextWithHeaders(context.Background(), http.Header{"X-Trace-Id": {"abc"}})
+	ctx = catalog.ContextWithHeaders(ctx, http.Header{"x-request-id": {"1"}, "Authorization": {"Bearer nope"}})
+	_, err = cat.ListNamespaces(ctx, nil)
+	r.Require().NoError(err)
+	r.Require().NoError(cat.CreateNamespace(ctx, catalog.ToRestIdentifier("ns"), nil))
+	_, err = cat.NamespaceExists(ctx, catalog.ToRestIdentifier("ns"))
+	r.Require().NoError(err)
+	r.Require().NoError(cat.DropNamespace(ctx, catalog.ToRestIdentifier("ns")))
+
+	routes := make([]string, len(requests))
+	for i, req := range requests {
+		routes[i] = req.route
+		r.Equal("acme", req.headers.Get("X-Tenant"), req.route)
+		r.Empty(req.headers.Get("X-Amz-Date"), req.route)
+
+		// the token exchange and config requests aren't made with ctx
+		switch req.route {
+		case "POST /v1/oauth/tokens":
+			r.Empty(req.headers.Get("Authorization"))
+			r.Equal("default", req.headers.Get("X-Trace-Id"))
+		case "GET /v1/config":
+			r.Equal("Bearer "+TestToken, req.headers.Get("Authorization"))
+			r.Equal("default", req.headers.Get("X-Trace-Id"))
+		default:
+			r.Equal("Bearer "+TestToken, req.headers.Get("Authorization"), req.route)
+			r.Equal("abc", req.headers.Get("X-Trace-Id"), req.route)
+			r.Equal("1", req.headers.Get("X-Request-Id"), req.route)
+		}
+	}
+	r.Equal([]string{
+		"POST /v1/oauth/tokens", "GET /v1/config", "POST /v1/oauth/tokens",
+		"GET /v1/namespaces", "POST /v1/namespaces", "HEAD /v1/namespaces/ns", "DELETE /v1/namespaces/ns",
+	}, routes)
+}
+
+func (r *RestCatalogSuite) TestLogger() {
+	r.mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      TestToken,
+			"token_type":        "Bearer",
+			"expires_in":        86400,
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+		})
+	})
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": []table.Identifier{{"accounting"}}})
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL,
+		catalog.WithCredential(TestCreds), catalog.WithLogger[catalog.RestCatalog](logger))
+	r.Require().NoError(err)
+
+	_, err = cat.ListNamespaces(context.Background(), nil)
+	r.Require().NoError(err)
+
+	logs := buf.String()
+	r.Contains(logs, "fetched rest catalog access token")
+	r.Contains(logs, `msg="rest request" method=GET url=`+r.srv.URL+"/v1/namespaces status=200")
+	r.NotContains(logs, TestToken)
+	r.NotContains(logs, "secret")
+}
+
+func (r *RestCatalogSuite) TestTableExists() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodHead, req.Method)
+
+		switch strings.TrimPrefix(req.URL.Path, "/v1/namespaces/fokko/tables/") {
+		case "present":
+			w.WriteHeader(http.StatusNoContent)
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	exists, err := cat.TableExists(context.Background(), catalog.ToRestIdentifier("fokko", "present"))
+	r.NoError(err)
+	r.True(exists)
+
+	exists, err = cat.TableExists(context.Background(), catalog.ToRestIdentifier("fokko", "missing"))
+	r.NoError(err)
+	r.False(exists)
+
+	_, err = cat.TableExists(context.Background(), catalog.ToRestIdentifier("fokko", "secret"))
+	r.ErrorIs(err, catalog.ErrForbidden)
+}
+
+func (r *RestCatalogSuite) TestViewExists() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/views/", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodHead, req.Method)
+
+		switch strings.TrimPrefix(req.URL.Path, "/v1/namespaces/fokko/views/") {
+		case "present":
+			w.WriteHeader(http.StatusNoContent)
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	exists, err := cat.ViewExists(context.Background(), catalog.ToRestIdentifier("fokko", "present"))
+	r.NoError(err)
+	r.True(exists)
+
+	exists, err = cat.ViewExists(context.Background(), catalog.ToRestIdentifier("fokko", "missing"))
+	r.NoError(err)
+	r.False(exists)
+
+	_, err = cat.ViewExists(context.Background(), catalog.ToRestIdentifier("fokko", "secret"))
+	r.ErrorIs(err, catalog.ErrForbidden)
+}
+
+func (r *RestCatalogSuite) TestNamespaceExists() {
+	r.mux.HandleFunc("/v1/namespaces/", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodHead, req.Method)
+
+		switch req.URL.Path {
+		case "/v1/namespaces/accounting":
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/namespaces/secret":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	exists, err := cat.NamespaceExists(context.Background(), catalog.ToRestIdentifier("accounting"))
+	r.NoError(err)
+	r.True(exists)
+
+	exists, err = cat.NamespaceExists(context.Background(), catalog.ToRestIdentifier("sales"))
+	r.NoError(err)
+	r.False(exists)
+
+	_, err = cat.NamespaceExists(context.Background(), catalog.ToRestIdentifier("secret"))
+	r.ErrorIs(err, catalog.ErrForbidden)
+}
+
+func (r *RestCatalogSuite) TestEscapedIdentifiers() {
+	var paths []string
+	r.mux.HandleFunc("/v1/namespaces/", func(w http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.EscapedPath())
+
+		switch req.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]any{"identifiers": []map[string]any{
+				{"namespace": []string{"x", "y/z"}, "name": "tbl ü"},
+			}})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	tables, err := cat.ListTables(context.Background(), table.Identifier{"x", "y/z"})
+	r.Require().NoError(err)
+	r.Equal([]table.Identifier{{"x", "y/z", "tbl ü"}}, tables)
+
+	r.Require().NoError(cat.DropTable(context.Background(), table.Identifier{"a", "b.c", "50%/ü?"}))
+	r.Require().NoError(cat.DropNamespace(context.Background(), table.Identifier{"..", "d"}))
+
+	r.Equal([]string{
+		"/v1/namespaces/x%1Fy%2Fz/tables",
+		"/v1/namespaces/a%1Fb.c/tables/50%25%2F%C3%BC%3F",
+		"/v1/namespaces/..%1Fd",
+	}, paths)
+}
+
 func (r *RestCatalogSuite) TestLoadNamespaceProps200() {
 	r.mux.HandleFunc("/v1/namespaces/leden", func(w http.ResponseWriter, req *http.Request) {
 		r.Require().Equal(http.MethodGet, req.Method)
@@ -741,6 +1122,191 @@ func (r *RestCatalogSuite) TestLoadTable200() {
 			},
 		},
 	}))
+
+	pinned, err := cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"),
+		iceberg.Properties{table.LoadSnapshotIDProp: "3497810964824022504"})
+	r.Require().NoError(err)
+	r.EqualValues(3497810964824022504, pinned.Snapshot().SnapshotID)
+
+	_, err = cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"),
+		iceberg.Properties{table.LoadSnapshotIDProp: "1"})
+	r.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	_, err = cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"),
+		iceberg.Properties{table.LoadSnapshotIDProp: "3497810964824022504", table.LoadAsOfTimestampMsProp: "1646787054459"})
+	r.ErrorIs(err, iceberg.ErrInvalidArgument)
+	r.ErrorContains(err, "conflicting snapshot pins")
+}
+
+func (r *RestCatalogSuite) TestReportMetrics204() {
+	var reported map[string]any
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table/metrics", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		for k, v := range TestHeaders {
+			r.Equal(v, req.Header.Values(k))
+		}
+
+		defer req.Body.Close()
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&reported))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	report := table.ScanReport{
+		TableName:           "fokko.table",
+		SnapshotID:          1,
+		Filter:              iceberg.EqualTo(iceberg.Reference("x"), int64(5)),
+		ProjectedFieldIDs:   []int{1},
+		ProjectedFieldNames: []string{"x"},
+		Metrics:             table.ScanMetrics{ScannedDataManifests: 2, SkippedDataManifests: 3},
+	}
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	// disabled by default
+	cat.ReportMetrics(context.Background(), catalog.ToRestIdentifier("fokko", "table"), report)
+	r.Nil(reported)
+
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithMetricsReporting(true))
+	r.Require().NoError(err)
+
+	cat.ReportMetrics(context.Background(), catalog.ToRestIdentifier("fokko", "table"), report)
+	r.Require().NotNil(reported)
+	r.Equal("scan-report", reported["report-type"])
+	r.Equal("fokko.table", reported["table-name"])
+	r.Equal([]any{"x"}, reported["projected-field-names"])
+	r.Equal(map[string]any{"type": "eq", "term": "x", "value": float64(5)}, reported["filter"])
+	r.Equal(map[string]any{"unit": "count", "value": float64(3)},
+		reported["metrics"].(map[string]any)["skipped-data-manifests"])
+
+	// failures are not surfaced to the caller
+	cat.ReportMetrics(context.Background(), catalog.ToRestIdentifier("fokko", "missing"), report)
+}
+
+func (r *RestCatalogSuite) TestReportMetricsUnsupported() {
+	r.mux = http.NewServeMux()
+	r.mux.HandleFunc("/v1/config", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"defaults":  map[string]any{},
+			"overrides": map[string]any{},
+			"endpoints": []string{"GET /v1/{prefix}/namespaces"},
+		})
+	})
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table/metrics", func(w http.ResponseWriter, req *http.Request) {
+		r.Fail("metrics should not be reported")
+	})
+	r.srv.Config.Handler = r.mux
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithMetricsReporting(true))
+	r.Require().NoError(err)
+
+	cat.ReportMetrics(context.Background(), catalog.ToRestIdentifier("fokko", "table"), table.ScanReport{})
+}
+
+func newSecondaryCatalogServer(token string) (*httptest.Server, *http.ServeMux) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/config", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"defaults": map[string]any{}, "overrides": map[string]any{}})
+	})
+	mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": token, "token_type": "Bearer", "expires_in": 86400,
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+		})
+	})
+	return httptest.NewServer(mux), mux
+}
+
+func (r *RestCatalogSuite) TestFailoverOn503() {
+	r.mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": TestToken, "token_type": "Bearer", "expires_in": 86400,
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+		})
+	})
+
+	primaryCalls := 0
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		primaryCalls++
+		r.Equal("Bearer "+TestToken, req.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{
+				"message": "Service Unavailable", "type": "ServiceUnavailableException", "code": 503,
+			},
+		})
+	})
+
+	secondary, mux := newSecondaryCatalogServer("secondary_token")
+	defer secondary.Close()
+
+	secondaryCalls := 0
+	mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		secondaryCalls++
+		r.Equal("Bearer secondary_token", req.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"namespaces": []table.Identifier{{"default"}},
+		})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithCredential(TestCreds),
+		catalog.WithEndpoints(catalog.RestEndpoint{URI: secondary.URL}),
+		catalog.WithEndpointRetries(2))
+	r.Require().NoError(err)
+
+	results, err := cat.ListNamespaces(context.Background(), nil)
+	r.Require().NoError(err)
+	r.Equal([]table.Identifier{{"default"}}, results)
+	r.Equal(2, primaryCalls)
+	r.Equal(1, secondaryCalls)
+
+	// the primary failed recently, so it's now tried last
+	_, err = cat.ListNamespaces(context.Background(), nil)
+	r.Require().NoError(err)
+	r.Equal(2, primaryCalls)
+	r.Equal(2, secondaryCalls)
+}
+
+func (r *RestCatalogSuite) TestFailoverCommitOnlyBeforeSend() {
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	secondary, mux := newSecondaryCatalogServer(TestToken)
+	defer secondary.Close()
+
+	created := 0
+	mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		r.Equal(http.MethodPost, req.Method)
+		created++
+		json.NewEncoder(w).Encode(map[string]any{
+			"namespace": []string{"leden"}, "properties": map[string]any{},
+		})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpoints(catalog.RestEndpoint{URI: secondary.URL}))
+	r.Require().NoError(err)
+
+	// the primary may have applied the request, so it isn't resent
+	err = cat.CreateNamespace(context.Background(), catalog.ToRestIdentifier("leden"), nil)
+	r.ErrorIs(err, catalog.ErrServiceUnavailable)
+	r.Zero(created)
+
+	// once the primary can't be reached at all, the request fails over
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	cat, err = catalog.NewRestCatalog("rest", down.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpoints(catalog.RestEndpoint{URI: secondary.URL}))
+	r.Require().NoError(err)
+
+	r.Require().NoError(cat.CreateNamespace(context.Background(), catalog.ToRestIdentifier("leden"), nil))
+	r.Equal(1, created)
 }
 
 type RestTLSCatalogSuite struct {
@@ -811,6 +1377,848 @@ func (r *RestTLSCatalogSuite) TestSSLCerts() {
 	r.Equal(r.configVals.Get("warehouse"), "s3://some-bucket")
 }
 
+func (r *RestCatalogSuite) TestCreateTable200() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		for k, v := range TestHeaders {
+			r.Equal(v, req.Header.Values(k))
+		}
+
+		var payload struct {
+			Name       string             `json:"name"`
+			Location   string             `json:"location"`
+			Schema     *iceberg.Schema    `json:"schema"`
+			WriteOrder json.RawMessage    `json:"write-order"`
+			Props      iceberg.Properties `json:"properties"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Equal("table", payload.Name)
+		r.Equal("s3://warehouse/database/table", payload.Location)
+		r.Len(payload.Schema.Fields(), 2)
+		r.Equal("3", payload.Props[table.PropertyFormatVersion])
+		// an empty order is sent as the unsorted order
+		r.JSONEq(`{"order-id": 0, "fields": []}`, string(payload.WriteOrder))
+
+		w.Write([]byte(`{
+			"metadata-location": "s3://warehouse/database/table/metadata/00000-5f2f8166-244c-4eae-ac36-384ecdec81fc.metadata.json",
+			"metadata": {
+				"format-version": 3,
+				"table-uuid": "b55d9dda-6561-423a-8bfc-787980ce421f",
+				"location": "s3://warehouse/database/table",
+				"last-sequence-number": 0,
+				"next-row-id": 0,
+				"last-updated-ms": 1646787054459,
+				"last-column-id": 2,
+				"current-schema-id": 0,
+				"schemas": [
+					{
+						"type": "struct",
+						"schema-id": 0,
+						"fields": [
+							{"id": 1, "name": "id", "required": false, "type": "int"},
+							{"id": 2, "name": "data", "required": false, "type": "string"}
+						]
+					}
+				],
+				"default-spec-id": 0,
+				"partition-specs": [{"spec-id": 0, "fields": []}],
+				"last-partition-id": 999,
+				"default-sort-order-id": 0,
+				"sort-orders": [{"order-id": 0, "fields": []}],
+				"properties": {"owner": "bryan"}
+			}
+		}`))
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String})
+
+	tbl, err := cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+		catalog.WithLocation("s3://warehouse/database/table"),
+		catalog.WithFormatVersion(3), catalog.WithSortOrder(table.SortOrder{OrderID: 2}))
+	r.Require().NoError(err)
+
+	r.Equal(catalog.ToRestIdentifier("rest", "fokko", "table"), tbl.Identifier())
+	r.Equal(3, tbl.Metadata().Version())
+	r.Equal(table.UnsortedSortOrder, tbl.SortOrder())
+	r.True(sc.Equals(tbl.Schema()))
+}
+
+func (r *RestCatalogSuite) TestCreateTableCloneFrom() {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String})
+
+	srcMeta, err := table.NewMetadata(sc, nil, table.UnsortedSortOrder, "file:///tmp/warehouse/src", nil)
+	r.Require().NoError(err)
+	b, err := table.MetadataBuilderFromBase(srcMeta)
+	r.Require().NoError(err)
+	_, err = b.AddSnapshot(&table.Snapshot{
+		SnapshotID: 10, SequenceNumber: 3, TimestampMs: 1700000000000,
+		ManifestList: "file:///tmp/warehouse/src/metadata/snap-10.avro",
+		Summary: &table.Summary{Operation: table.OpAppend,
+			Properties: map[string]string{"total-records": "7"}},
+	})
+	r.Require().NoError(err)
+	_, err = b.SetCurrentSnapshot(10)
+	r.Require().NoError(err)
+	srcMeta, err = b.Build()
+	r.Require().NoError(err)
+	src := table.New(table.Identifier{"fokko", "src"}, srcMeta, "", iceio.LocalFS{})
+
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		var payload struct {
+			Schema      *iceberg.Schema    `json:"schema"`
+			StageCreate bool               `json:"stage-create"`
+			Props       iceberg.Properties `json:"properties"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.True(payload.StageCreate)
+
+		staged, err := table.NewMetadata(payload.Schema, nil, table.UnsortedSortOrder,
+			"file:///tmp/warehouse/clone", payload.Props)
+		r.Require().NoError(err)
+		json.NewEncoder(w).Encode(map[string]any{"metadata": staged})
+	})
+
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/clone", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		var payload struct {
+			Requirements table.Requirements `json:"requirements"`
+			Updates      table.Updates      `json:"updates"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Require().Len(payload.Requirements, 1)
+		r.Equal(table.ReqAssertCreate, payload.Requirements[0].Type())
+
+		b, err := table.NewMetadataBuilder(2)
+		r.Require().NoError(err)
+		for _, u := range payload.Updates {
+			r.Require().NoError(u.Apply(b))
+		}
+		committed, err := b.Build()
+		r.Require().NoError(err)
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"metadata-location": "file:///tmp/warehouse/clone/metadata/00000.metadata.json",
+			"metadata":          committed,
+		})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	clone, err := cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "clone"), nil,
+		catalog.WithCloneFrom(src))
+	r.Require().NoError(err)
+
+	r.True(sc.Equals(clone.Schema()))
+	r.Equal("file:///tmp/warehouse/clone", clone.Location())
+	snap := clone.CurrentSnapshot()
+	r.Require().NotNil(snap)
+	r.NotEqual(int64(10), snap.SnapshotID)
+	r.Equal(int64(3), snap.SequenceNumber)
+	r.Equal("file:///tmp/warehouse/src/metadata/snap-10.avro", snap.ManifestList)
+	r.Equal("7", snap.Summary.Properties["total-records"])
+
+	other := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "clone"), other,
+		catalog.WithCloneFrom(src))
+	r.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "clone"), nil,
+		catalog.WithCloneFrom(src), catalog.WithFormatVersion(1))
+	r.ErrorIs(err, table.ErrInvalidMetadataFormatVersion)
+}
+
+func (r *RestCatalogSuite) TestCreateTableWithUUID() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Schema      *iceberg.Schema `json:"schema"`
+			StageCreate bool            `json:"stage-create"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.True(payload.StageCreate)
+
+		staged, err := table.NewMetadata(payload.Schema, nil, table.UnsortedSortOrder,
+			"s3://warehouse/fokko/table", nil)
+		r.Require().NoError(err)
+		json.NewEncoder(w).Encode(map[string]any{"metadata": staged})
+	})
+
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Requirements table.Requirements `json:"requirements"`
+			Updates      table.Updates      `json:"updates"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Require().Len(payload.Requirements, 1)
+		r.Equal(table.ReqAssertCreate, payload.Requirements[0].Type())
+		r.Equal(table.UpdateAssignUUID, payload.Updates[0].Action())
+
+		b, err := table.NewMetadataBuilder(2)
+		r.Require().NoError(err)
+		for _, u := range payload.Updates {
+			r.Require().NoError(u.Apply(b))
+		}
+		committed, err := b.Build()
+		r.Require().NoError(err)
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"metadata-location": "s3://warehouse/fokko/table/metadata/00000.metadata.json",
+			"metadata":          committed,
+		})
+	})
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+	nameUUID := func(ident table.Identifier) uuid.UUID {
+		return uuid.NewSHA1(uuid.NameSpaceURL, []byte(strings.Join(ident, ".")))
+	}
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithTableUUIDGenerator(nameUUID))
+	r.Require().NoError(err)
+
+	tbl, err := cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc)
+	r.Require().NoError(err)
+	r.Equal(nameUUID(table.Identifier{"fokko", "table"}), tbl.Metadata().TableUUID())
+
+	id := uuid.MustParse("b55d9dda-6561-423a-8bfc-787980ce421f")
+	tbl, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+		catalog.WithTableUUID(id))
+	r.Require().NoError(err)
+	r.Equal(id, tbl.Metadata().TableUUID())
+}
+
+func (r *RestCatalogSuite) TestCreateTableLocationTemplate() {
+	var location string
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Location string          `json:"location"`
+			Schema   *iceberg.Schema `json:"schema"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		location = payload.Location
+
+		loc := payload.Location
+		if loc == "" {
+			loc = "s3://warehouse/fokko/table"
+		}
+		meta, err := table.NewMetadata(payload.Schema, nil, table.UnsortedSortOrder, loc, nil)
+		r.Require().NoError(err)
+		json.NewEncoder(w).Encode(map[string]any{
+			"metadata-location": loc + "/metadata/00000.metadata.json",
+			"metadata":          meta,
+		})
+	})
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+	lower := func(ns table.Identifier, name string) string {
+		return strings.ToLower(catalog.LocationTemplate("s3://bucket/{namespace}/{table}")(ns, name))
+	}
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithLocationTemplate(lower))
+	r.Require().NoError(err)
+
+	tbl, err := cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "Table"), sc)
+	r.Require().NoError(err)
+	r.Equal("s3://bucket/fokko/table", location)
+	r.Equal("s3://bucket/fokko/table", tbl.Location())
+
+	// the levels of a multi-level namespace are nested directories
+	r.Equal("s3://bucket/a/b/t", catalog.LocationTemplate("s3://bucket/{namespace}/{table}")(
+		table.Identifier{"a", "b"}, "t"))
+
+	// an explicit location takes precedence over the template
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "Table"), sc,
+		catalog.WithLocation("s3://other/location"))
+	r.Require().NoError(err)
+	r.Equal("s3://other/location", location)
+
+	// without a template the server chooses the location
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "Table"), sc)
+	r.Require().NoError(err)
+	r.Empty(location)
+}
+
+func (r *RestCatalogSuite) TestCreateTableInheritsNamespaceProperties() {
+	r.mux.HandleFunc("/v1/namespaces/fokko", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+		json.NewEncoder(w).Encode(map[string]any{
+			"namespace": []string{"fokko"},
+			"properties": map[string]string{
+				"owner":                           "fokko",
+				"location":                        "s3://warehouse/fokko",
+				"write.format.default":            "parquet",
+				"write.parquet.compression-codec": "zstd",
+			},
+		})
+	})
+
+	var props iceberg.Properties
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Schema *iceberg.Schema    `json:"schema"`
+			Props  iceberg.Properties `json:"properties"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		props = payload.Props
+
+		meta, err := table.NewMetadata(payload.Schema, nil, table.UnsortedSortOrder,
+			"s3://warehouse/fokko/table", payload.Props)
+		r.Require().NoError(err)
+		json.NewEncoder(w).Encode(map[string]any{
+			"metadata-location": "s3://warehouse/fokko/table/metadata/00000.metadata.json",
+			"metadata":          meta,
+		})
+	})
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithNamespacePropertyInheritance())
+	r.Require().NoError(err)
+
+	// the compression default is inherited, the table's own format wins and
+	// the namespace bookkeeping properties aren't inherited
+	tbl, err := cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+		catalog.WithProperties(iceberg.Properties{"write.format.default": "orc"}))
+	r.Require().NoError(err)
+	expected := iceberg.Properties{
+		"write.format.default":            "orc",
+		"write.parquet.compression-codec": "zstd",
+	}
+	r.Equal(expected, props)
+	r.Equal(expected, tbl.Properties())
+
+	// only the given properties are inherited
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithNamespacePropertyInheritance("owner"))
+	r.Require().NoError(err)
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc)
+	r.Require().NoError(err)
+	r.Equal(iceberg.Properties{"owner": "fokko"}, props)
+}
+
+func (r *RestCatalogSuite) TestRenameTable() {
+	r.mux.HandleFunc("/v1/tables/rename", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		var payload map[string]any
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		switch dst := payload["destination"].(map[string]any); dst["namespace"].([]any)[0] {
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": "Namespace does not exist: missing",
+				"type":    "NoSuchNamespaceException", "code": 404}})
+			return
+		case "existing":
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": "Table already exists: existing.table",
+				"type":    "AlreadyExistsException", "code": 409}})
+			return
+		}
+
+		r.Equal(map[string]any{
+			"source":      map[string]any{"namespace": []any{"fokko"}, "name": "source"},
+			"destination": map[string]any{"namespace": []any{"other"}, "name": "table"},
+		}, payload)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	r.mux.HandleFunc("/v1/namespaces/other/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+		fmt.Fprint(w, `{
+			"metadata-location": "s3://warehouse/fokko/source/metadata/v1.metadata.json",
+			"metadata": {
+				"format-version": 2,
+				"table-uuid": "b55d9dda-6561-423a-8bfc-787980ce421f",
+				"location": "s3://warehouse/fokko/source",
+				"last-sequence-number": 0,
+				"last-updated-ms": 1646787054459,
+				"last-column-id": 1,
+				"current-schema-id": 0,
+				"schemas": [{"type": "struct", "schema-id": 0, "fields": [
+					{"id": 1, "name": "id", "required": false, "type": "int"}]}],
+				"default-spec-id": 0,
+				"partition-specs": [{"spec-id": 0, "fields": []}],
+				"last-partition-id": 999,
+				"default-sort-order-id": 0,
+				"sort-orders": [{"order-id": 0, "fields": []}]
+			}
+		}`)
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	// the table keeps its location, only its identifier changes
+	tbl, err := cat.RenameTable(context.Background(), catalog.ToRestIdentifier("fokko", "source"),
+		catalog.ToRestIdentifier("other", "table"))
+	r.Require().NoError(err)
+	r.Equal([]string{"rest", "other", "table"}, tbl.Identifier())
+	r.Equal("s3://warehouse/fokko/source", tbl.Location())
+
+	_, err = cat.RenameTable(context.Background(), catalog.ToRestIdentifier("fokko", "source"),
+		catalog.ToRestIdentifier("missing", "table"))
+	r.ErrorIs(err, catalog.ErrNoSuchNamespace)
+
+	_, err = cat.RenameTable(context.Background(), catalog.ToRestIdentifier("fokko", "source"),
+		catalog.ToRestIdentifier("existing", "table"))
+	r.ErrorIs(err, catalog.ErrTableAlreadyExists)
+}
+
+func (r *RestCatalogSuite) TestTypedErrors() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/missing", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+			"message": "Table does not exist: fokko.missing", "type": "NoSuchTableException", "code": 404}})
+	})
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/bad", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+			"message": "Malformed request", "type": "BadRequestException", "code": 400}})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	_, err = cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "missing"), nil)
+	var notFound *catalog.NotFoundError
+	r.ErrorAs(err, &notFound)
+	r.ErrorIs(err, catalog.ErrNoSuchTable)
+	r.ErrorContains(err, "Table does not exist: fokko.missing")
+
+	_, err = cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "bad"), nil)
+	var invalid *catalog.ValidationError
+	r.ErrorAs(err, &invalid)
+	r.ErrorIs(err, catalog.ErrBadRequest)
+}
+
+func (r *RestCatalogSuite) TestTableRefresh() {
+	var (
+		version atomic.Int32
+		dropped atomic.Bool
+	)
+	version.Store(1)
+
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+		if dropped.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": "Table does not exist: fokko.table",
+				"type":    "NoSuchTableException", "code": 404}})
+			return
+		}
+
+		fmt.Fprintf(w, `{
+			"metadata-location": "s3://warehouse/fokko/table/metadata/v%[1]d.metadata.json",
+			"metadata": {
+				"format-version": 2,
+				"table-uuid": "b55d9dda-6561-423a-8bfc-787980ce421f",
+				"location": "s3://warehouse/fokko/table",
+				"last-sequence-number": 0,
+				"last-updated-ms": 1646787054459,
+				"last-column-id": 1,
+				"current-schema-id": 0,
+				"schemas": [{"type": "struct", "schema-id": 0, "fields": [
+					{"id": 1, "name": "id", "required": false, "type": "int"}]}],
+				"default-spec-id": 0,
+				"partition-specs": [{"spec-id": 0, "fields": []}],
+				"last-partition-id": 999,
+				"default-sort-order-id": 0,
+				"sort-orders": [{"order-id": 0, "fields": []}],
+				"properties": {"version": "%[1]d"}
+			}
+		}`, version.Load())
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	tbl, err := cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), nil)
+	r.Require().NoError(err)
+	r.Equal("1", tbl.Properties()["version"])
+
+	version.Store(2)
+	fresh, err := tbl.Refresh(context.Background())
+	r.Require().NoError(err)
+	r.Equal("s3://warehouse/fokko/table/metadata/v2.metadata.json", fresh.MetadataLocation())
+	r.Equal("2", fresh.Properties()["version"])
+	r.Equal(tbl.Identifier(), fresh.Identifier())
+
+	// the table that was refreshed is left unchanged
+	r.Equal("1", tbl.Properties()["version"])
+
+	dropped.Store(true)
+	_, err = fresh.Refresh(context.Background())
+	r.ErrorIs(err, catalog.ErrNoSuchTable)
+
+	_, err = table.New(tbl.Identifier(), tbl.Metadata(), "", nil).Refresh(context.Background())
+	r.ErrorIs(err, iceberg.ErrInvalidArgument)
+}
+
+func (r *RestCatalogSuite) TestUpdateTableProperties() {
+	tableResponse := func(version int, props string) string {
+		return fmt.Sprintf(`{
+			"metadata-location": "s3://warehouse/fokko/table/metadata/v%d.metadata.json",
+			"metadata": {
+				"format-version": 2,
+				"table-uuid": "b55d9dda-6561-423a-8bfc-787980ce421f",
+				"location": "s3://warehouse/fokko/table",
+				"last-sequence-number": 0,
+				"last-updated-ms": 1646787054459,
+				"last-column-id": 1,
+				"current-schema-id": 0,
+				"schemas": [{"type": "struct", "schema-id": 0, "fields": [
+					{"id": 1, "name": "id", "required": false, "type": "int"}]}],
+				"default-spec-id": 0,
+				"partition-specs": [{"spec-id": 0, "fields": []}],
+				"last-partition-id": 999,
+				"default-sort-order-id": 0,
+				"sort-orders": [{"order-id": 0, "fields": []}],
+				"properties": %s
+			}
+		}`, version, props)
+	}
+
+	var conflict atomic.Bool
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			fmt.Fprint(w, tableResponse(1, `{"a": "1", "b": "2"}`))
+			return
+		}
+
+		r.Require().Equal(http.MethodPost, req.Method)
+		if conflict.Load() {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": "Requirement failed: UUID does not match",
+				"type":    "CommitFailedException", "code": 409}})
+			return
+		}
+
+		var payload map[string]any
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Equal(map[string]any{
+			"identifier": map[string]any{"namespace": []any{"fokko"}, "name": "table"},
+			"requirements": []any{
+				map[string]any{"type": "assert-table-uuid", "uuid": "b55d9dda-6561-423a-8bfc-787980ce421f"},
+			},
+			"updates": []any{
+				map[string]any{"action": "set-properties", "updates": map[string]any{"c": "3"}},
+				map[string]any{"action": "remove-properties", "removals": []any{"b", "missing"}},
+			},
+		}, payload)
+
+		fmt.Fprint(w, tableResponse(2, `{"a": "1", "c": "3"}`))
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	tbl, err := cat.LoadTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), nil)
+	r.Require().NoError(err)
+
+	// setting and then removing a key only removes it, and vice versa
+	updated, summary, err := tbl.UpdateProperties().
+		Set("b", "3").Remove("b").
+		Remove("c").Set("c", "3").
+		Remove("missing").
+		Commit(context.Background())
+	r.Require().NoError(err)
+	r.Equal(catalog.PropertiesUpdateSummary{
+		Updated: []string{"c"},
+		Removed: []string{"b"},
+		Missing: []string{"missing"},
+	}, summary)
+	r.Equal("s3://warehouse/fokko/table/metadata/v2.metadata.json", updated.MetadataLocation())
+	r.Equal(iceberg.Properties{"a": "1", "c": "3"}, updated.Properties())
+	r.Equal(tbl.Identifier(), updated.Identifier())
+
+	_, _, err = tbl.UpdateProperties().Set(table.PropertyFormatVersion, "3").Commit(context.Background())
+	r.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	conflict.Store(true)
+	_, _, err = tbl.UpdateProperties().Set("c", "3").Commit(context.Background())
+	var commitFailed *catalog.CommitFailedError
+	r.ErrorAs(err, &commitFailed)
+	r.ErrorIs(err, catalog.ErrCommitFailed)
+}
+
+func (r *RestCatalogSuite) TestErrorResponseTypes() {
+	respond := func(code int, typ, msg string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(code)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": msg, "type": typ, "code": code}})
+		}
+	}
+
+	r.mux.HandleFunc("/v1/namespaces/nope/tables/table",
+		respond(http.StatusNotFound, "NoSuchNamespaceException", "Namespace does not exist: nope"))
+	r.mux.HandleFunc("/v1/namespaces/accounting",
+		respond(http.StatusConflict, "NamespaceNotEmptyException", "Namespace accounting is not empty"))
+	r.mux.HandleFunc("/v1/namespaces",
+		respond(http.StatusConflict, "AlreadyExistsException", "Namespace already exists: accounting"))
+	r.mux.HandleFunc("/v1/namespaces/locked/tables/table",
+		respond(http.StatusForbidden, "ForbiddenException", "Not allowed to load locked.table"))
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	_, err = cat.LoadTable(context.Background(), catalog.ToRestIdentifier("nope", "table"), nil)
+	r.ErrorIs(err, catalog.ErrNoSuchNamespace)
+	r.NotErrorIs(err, catalog.ErrNoSuchTable)
+	r.ErrorContains(err, "NoSuchNamespaceException: Namespace does not exist: nope")
+
+	err = cat.DropNamespace(context.Background(), catalog.ToRestIdentifier("accounting"))
+	r.ErrorIs(err, catalog.ErrNamespaceNotEmpty)
+
+	err = cat.CreateNamespace(context.Background(), catalog.ToRestIdentifier("accounting"), nil)
+	r.ErrorIs(err, catalog.ErrNamespaceAlreadyExists)
+	r.ErrorContains(err, "Namespace already exists: accounting")
+
+	_, err = cat.LoadTable(context.Background(), catalog.ToRestIdentifier("locked", "table"), nil)
+	r.ErrorIs(err, catalog.ErrForbidden)
+}
+
+func (r *RestCatalogSuite) TestRequestTimeout() {
+	var attempts atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+
+	// the server accepts the request but never responds
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		attempts.Add(1)
+		select {
+		case <-req.Context().Done():
+		case <-release:
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpointRetries(3),
+		catalog.WithRequestTimeout[catalog.RestCatalog](50*time.Millisecond))
+	r.Require().NoError(err)
+
+	ident := catalog.ToRestIdentifier("fokko", "table")
+	start := time.Now()
+	_, err = cat.LoadTable(context.Background(), ident, nil)
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.EqualValues(3, attempts.Load())
+	r.Less(time.Since(start), 5*time.Second)
+
+	// a shorter deadline from the caller takes precedence
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpointRetries(3),
+		catalog.WithRequestTimeout[catalog.RestCatalog](time.Minute))
+	r.Require().NoError(err)
+
+	attempts.Store(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = cat.LoadTable(ctx, ident, nil)
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.EqualValues(1, attempts.Load())
+
+	// the operation timeout caps the total time across retries
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpointRetries(10),
+		catalog.WithRequestTimeout[catalog.RestCatalog](50*time.Millisecond),
+		catalog.WithOperationTimeout[catalog.RestCatalog](120*time.Millisecond))
+	r.Require().NoError(err)
+
+	attempts.Store(0)
+	_, err = cat.LoadTable(context.Background(), ident, nil)
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.LessOrEqual(attempts.Load(), int32(3))
+}
+
+func (r *RestCatalogSuite) TestRetryBackoff() {
+	var (
+		attempts   atomic.Int32
+		failures   atomic.Int32
+		retryAfter atomic.Value
+	)
+	retryAfter.Store("")
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		attempts.Add(1)
+		if failures.Add(-1) >= 0 {
+			if v := retryAfter.Load().(string); v != "" {
+				w.Header().Set("Retry-After", v)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": []table.Identifier{}})
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpointRetries(3))
+	r.Require().NoError(err)
+
+	// retries are spaced out by a growing delay
+	failures.Store(2)
+	start := time.Now()
+	_, err = cat.ListNamespaces(context.Background(), nil)
+	r.Require().NoError(err)
+	r.EqualValues(3, attempts.Load())
+	r.GreaterOrEqual(time.Since(start), 150*time.Millisecond)
+
+	// a 503 response's Retry-After is honored
+	attempts.Store(0)
+	failures.Store(1)
+	retryAfter.Store("1")
+	start = time.Now()
+	_, err = cat.ListNamespaces(context.Background(), nil)
+	r.Require().NoError(err)
+	r.EqualValues(2, attempts.Load())
+	r.GreaterOrEqual(time.Since(start), time.Second)
+
+	// the request isn't retried if the delay would outlast the deadline
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpointRetries(3),
+		catalog.WithOperationTimeout[catalog.RestCatalog](500*time.Millisecond))
+	r.Require().NoError(err)
+
+	attempts.Store(0)
+	failures.Store(3)
+	retryAfter.Store("30")
+	start = time.Now()
+	_, err = cat.ListNamespaces(context.Background(), nil)
+	r.ErrorIs(err, catalog.ErrServiceUnavailable)
+	r.EqualValues(1, attempts.Load())
+	r.Less(time.Since(start), 500*time.Millisecond)
+
+	// nor once the caller cancels while waiting
+	cat, err = catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken),
+		catalog.WithEndpointRetries(3))
+	r.Require().NoError(err)
+
+	attempts.Store(0)
+	failures.Store(3)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start = time.Now()
+	_, err = cat.ListNamespaces(ctx, nil)
+	r.ErrorIs(err, catalog.ErrServiceUnavailable)
+	r.EqualValues(1, attempts.Load())
+	r.Less(time.Since(start), 5*time.Second)
+}
+
+func (r *RestCatalogSuite) TestCommitStateUnknown() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Schema *iceberg.Schema `json:"schema"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+
+		staged, err := table.NewMetadata(payload.Schema, nil, table.UnsortedSortOrder,
+			"s3://warehouse/fokko/table", nil)
+		r.Require().NoError(err)
+		json.NewEncoder(w).Encode(map[string]any{"metadata": staged})
+	})
+
+	var (
+		dropConnection bool
+		status         = http.StatusBadGateway
+		errType        string
+	)
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		if dropConnection {
+			// the server received the commit but the response is lost
+			conn, _, err := w.(http.Hijacker).Hijack()
+			r.Require().NoError(err)
+			conn.Close()
+			return
+		}
+
+		w.WriteHeader(status)
+		if errType != "" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"error": map[string]any{"message": "commit failed", "type": errType, "code": status},
+			})
+		}
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+	id := uuid.MustParse("b55d9dda-6561-423a-8bfc-787980ce421f")
+
+	var unknown *catalog.CommitStateUnknownError
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+		catalog.WithTableUUID(id))
+	r.ErrorAs(err, &unknown)
+	r.ErrorIs(err, catalog.ErrCommitStateUnknown)
+
+	// typed server errors don't tell whether the commit was applied either
+	for _, tt := range []struct {
+		status  int
+		errType string
+	}{
+		{http.StatusInternalServerError, "ServiceFailureException"},
+		{http.StatusServiceUnavailable, "ServiceUnavailableException"},
+		{http.StatusGatewayTimeout, "ServiceFailureException"},
+	} {
+		status, errType = tt.status, tt.errType
+
+		_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+			catalog.WithTableUUID(id))
+		r.ErrorAs(err, &unknown, tt.errType)
+		r.ErrorIs(err, catalog.ErrCommitStateUnknown, tt.errType)
+
+		_, _, err = cat.CommitTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), nil,
+			[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "me"})})
+		r.ErrorAs(err, &unknown, tt.errType)
+		r.ErrorIs(err, catalog.ErrCommitStateUnknown, tt.errType)
+		r.NotErrorIs(err, catalog.ErrServiceUnavailable, tt.errType)
+		r.NotErrorIs(err, catalog.ErrServerError, tt.errType)
+	}
+
+	dropConnection = true
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+		catalog.WithTableUUID(id))
+	r.ErrorAs(err, &unknown)
+}
+
+func (r *RestCatalogSuite) TestCreateTableInvalidFormatVersion() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		r.Fail("unexpected create table request")
+	})
+
+	cat, err := catalog.NewRestCatalog("rest", r.srv.URL, catalog.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32})
+
+	_, err = cat.CreateTable(context.Background(), catalog.ToRestIdentifier("fokko", "table"), sc,
+		catalog.WithFormatVersion(4))
+	r.ErrorIs(err, table.ErrInvalidMetadataFormatVersion)
+}
+
 func TestRestCatalog(t *testing.T) {
 	suite.Run(t, new(RestCatalogSuite))
 	suite.Run(t, new(RestTLSCatalogSuite))
@@ -397,7 +397,10 @@ func (r Reference) Bind(s *Schema, caseSensitive bool) (BoundTerm, error) {
 
 	acc, ok := s.accessorForField(field.ID)
 	if !ok {
-		return nil, ErrInvalidSchema
+		// only fields reachable through structs have accessors, the
+		// elements of lists and the keys and values of maps don't
+		return nil, fmt.Errorf("%w: could not bind reference '%s', predicates on list elements and map keys or values are not supported",
+			ErrInvalidSchema, string(r))
 	}
 
 	return createBoundRef(field, acc), nil
@@ -409,6 +412,9 @@ type BoundReference interface {
 	BoundTerm
 
 	Field() NestedField
+	// Pos returns the position of the referenced field within its top-level
+	// struct in the schema that this reference was bound to.
+	Pos() int
 }
 
 type boundRef[T LiteralType] struct {
@@ -434,6 +440,8 @@ func createBoundRef(field NestedField, acc accessor) BoundReference {
 		return &boundRef[Time]{field: field, acc: acc}
 	case TimestampType, TimestampTzType:
 		return &boundRef[Timestamp]{field: field, acc: acc}
+	case TimestampNsType, TimestampTzNsType:
+		return &boundRef[TimestampNano]{field: field, acc: acc}
 	case StringType:
 		return &boundRef[string]{field: field, acc: acc}
 	case FixedType, BinaryType:
@@ -464,6 +472,7 @@ func (b *boundRef[T]) Equals(other BoundTerm) bool {
 func (b *boundRef[T]) Ref() BoundReference { return b }
 func (b *boundRef[T]) Field() NestedField  { return b.field }
 func (b *boundRef[T]) Type() Type          { return b.field.Type }
+func (b *boundRef[T]) Pos() int            { return b.acc.pos }
 
 func (b *boundRef[T]) eval(st structLike) Optional[T] {
 	switch v := b.acc.Get(st).(type) {
@@ -593,6 +602,8 @@ func createBoundUnaryPredicate(op Operation, term BoundTerm) BoundUnaryPredicate
 		return newBoundUnaryPred[Time](op, term)
 	case TimestampType, TimestampTzType:
 		return newBoundUnaryPred[Timestamp](op, term)
+	case TimestampNsType, TimestampTzNsType:
+		return newBoundUnaryPred[TimestampNano](op, term)
 	case StringType:
 		return newBoundUnaryPred[string](op, term)
 	case FixedType, BinaryType:
@@ -753,6 +764,8 @@ func createBoundLiteralPredicate(op Operation, term BoundTerm, lit Literal) (Bou
 		return newBoundLiteralPredicate[Time](op, term, finalLit), nil
 	case TimestampType, TimestampTzType:
 		return newBoundLiteralPredicate[Timestamp](op, term, finalLit), nil
+	case TimestampNsType, TimestampTzNsType:
+		return newBoundLiteralPredicate[TimestampNano](op, term, finalLit), nil
 	case StringType:
 		return newBoundLiteralPredicate[string](op, term, finalLit), nil
 	case FixedType, BinaryType:
@@ -899,6 +912,11 @@ func createBoundSetPredicate(op Operation, term BoundTerm, lits Set[Literal]) (B
 		}
 	}
 
+	// the literals are sorted once when binding, so that evaluators can
+	// find the smallest and largest values, and look up whether any are in
+	// a range, without scanning the whole set
+	typedSet = newSortedLiteralSet(typedSet.(literalSet))
+
 	switch term.Type().(type) {
 	case BooleanType:
 		return newBoundSetPredicate[bool](op, term, typedSet), nil
@@ -916,6 +934,8 @@ func createBoundSetPredicate(op Operation, term BoundTerm, lits Set[Literal]) (B
 		return newBoundSetPredicate[Time](op, term, typedSet), nil
 	case TimestampType, TimestampTzType:
 		return newBoundSetPredicate[Timestamp](op, term, typedSet), nil
+	case TimestampNsType, TimestampTzNsType:
+		return newBoundSetPredicate[TimestampNano](op, term, typedSet), nil
 	case StringType:
 		return newBoundSetPredicate[string](op, term, typedSet), nil
 	case BinaryType, FixedType:
@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var exprJSONTypes = map[Operation]string{
+	OpIsNull:        "is-null",
+	OpNotNull:       "not-null",
+	OpIsNan:         "is-nan",
+	OpNotNan:        "not-nan",
+	OpLT:            "lt",
+	OpLTEQ:          "lt-eq",
+	OpGT:            "gt",
+	OpGTEQ:          "gt-eq",
+	OpEQ:            "eq",
+	OpNEQ:           "not-eq",
+	OpStartsWith:    "starts-with",
+	OpNotStartsWith: "not-starts-with",
+	OpIn:            "in",
+	OpNotIn:         "not-in",
+}
+
+// MarshalExpressionJSON writes a boolean expression as the Expression
+// object of the REST catalog spec, the same form that the Java
+// implementation's ExpressionParser produces. Constant expressions are
+// written as a JSON boolean, and literal values use the JSON single value
+// serialization of their type, such as "2017-11-16" for a date. Bound
+// predicates refer to the name of their bound field.
+func MarshalExpressionJSON(expr BooleanExpression) ([]byte, error) {
+	val, err := VisitExpr[any](expr, exprJSONVisitor{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(val)
+}
+
+type exprJSONVisitor struct{}
+
+type andOrExprJSON struct {
+	Type  string `json:"type"`
+	Left  any    `json:"left"`
+	Right any    `json:"right"`
+}
+
+type notExprJSON struct {
+	Type  string `json:"type"`
+	Child any    `json:"child"`
+}
+
+type predicateJSON struct {
+	Type   string `json:"type"`
+	Term   string `json:"term"`
+	Value  any    `json:"value,omitempty"`
+	Values []any  `json:"values,omitempty"`
+}
+
+func (exprJSONVisitor) VisitTrue() any  { return true }
+func (exprJSONVisitor) VisitFalse() any { return false }
+func (exprJSONVisitor) VisitNot(child any) any {
+	return notExprJSON{Type: "not", Child: child}
+}
+
+func (exprJSONVisitor) VisitAnd(left, right any) any {
+	return andOrExprJSON{Type: "and", Left: left, Right: right}
+}
+
+func (exprJSONVisitor) VisitOr(left, right any) any {
+	return andOrExprJSON{Type: "or", Left: left, Right: right}
+}
+
+func (exprJSONVisitor) VisitUnbound(pred UnboundPredicate) any {
+	ref, ok := pred.Term().(Reference)
+	if !ok {
+		panic(fmt.Errorf("%w: cannot write term %s as JSON", ErrNotImplemented, pred.Term()))
+	}
+
+	switch p := pred.(type) {
+	case *unboundLiteralPredicate:
+		return literalPredicateJSON(p.op, string(ref), p.lit)
+	case *unboundSetPredicate:
+		return setPredicateJSON(p.op, string(ref), p.lits)
+	}
+	return predicateJSON{Type: exprJSONType(pred.Op()), Term: string(ref)}
+}
+
+func (exprJSONVisitor) VisitBound(pred BoundPredicate) any {
+	name := pred.Ref().Field().Name
+	switch p := pred.(type) {
+	case BoundLiteralPredicate:
+		return literalPredicateJSON(p.Op(), name, p.Literal())
+	case BoundSetPredicate:
+		return setPredicateJSON(p.Op(), name, p.Literals())
+	}
+	return predicateJSON{Type: exprJSONType(pred.Op()), Term: name}
+}
+
+func exprJSONType(op Operation) string {
+	typ, ok := exprJSONTypes[op]
+	if !ok {
+		panic(fmt.Errorf("%w: cannot write operation %s as JSON", ErrNotImplemented, op))
+	}
+	return typ
+}
+
+func literalPredicateJSON(op Operation, term string, lit Literal) predicateJSON {
+	return predicateJSON{Type: exprJSONType(op), Term: term, Value: literalJSONValue(lit)}
+}
+
+func setPredicateJSON(op Operation, term string, lits Set[Literal]) predicateJSON {
+	members := lits.Members()
+	values := make([]any, len(members))
+	for i, lit := range members {
+		values[i] = literalJSONValue(lit)
+	}
+	return predicateJSON{Type: exprJSONType(op), Term: term, Values: values}
+}
+
+// literalJSONValue returns the JSON single value serialization of a
+// literal: numbers and booleans as themselves, dates, times and
+// timestamps as ISO-8601 strings, binary values as upper case hex and
+// decimals and UUIDs as their string form.
+func literalJSONValue(lit Literal) any {
+	switch l := lit.(type) {
+	case BoolLiteral:
+		return bool(l)
+	case Int32Literal:
+		return int32(l)
+	case Int64Literal:
+		return int64(l)
+	case Float32Literal:
+		return float32(l)
+	case Float64Literal:
+		return float64(l)
+	case TimeLiteral:
+		return time.UnixMicro(int64(l)).UTC().Format(isoTimeLayout)
+	case TimestampLiteral:
+		return time.UnixMicro(int64(l)).UTC().Format("2006-01-02T" + isoTimeLayout)
+	case TimestampTzLiteral:
+		return time.UnixMicro(int64(l)).UTC().Format("2006-01-02T" + isoTimeLayout + "+00:00")
+	case TimestampNanoLiteral:
+		return time.Unix(0, int64(l)).UTC().Format("2006-01-02T" + isoTimeLayout)
+	case TimestampTzNanoLiteral:
+		return time.Unix(0, int64(l)).UTC().Format("2006-01-02T" + isoTimeLayout + "+00:00")
+	case BinaryLiteral:
+		return strings.ToUpper(hex.EncodeToString(l))
+	case FixedLiteral:
+		return strings.ToUpper(hex.EncodeToString(l))
+	case UUIDLiteral:
+		return uuid.UUID(l).String()
+	}
+	// strings, dates and decimals are already written in their JSON form
+	return lit.String()
+}
+
+// isoTimeLayout formats a time of day like Java's ISO_LOCAL_TIME, which
+// drops trailing zeros of the fraction.
+const isoTimeLayout = "15:04:05.999999999"
@@ -18,6 +18,7 @@
 package iceberg_test
 
 import (
+	"encoding/json"
 	"math"
 	"strconv"
 	"testing"
@@ -195,6 +196,14 @@ func TestRefBindingCaseSensitive(t *testing.T) {
 	assert.ErrorContains(t, err, "could not bind reference 'foot', caseSensitive=false")
 }
 
+func TestRefBindingCollectionElement(t *testing.T) {
+	for _, name := range []string{"qux.element", "quux.key", "location.element.latitude"} {
+		_, err := iceberg.Reference(name).Bind(tableSchemaNested, true)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "predicates on list elements and map keys or values are not supported")
+	}
+}
+
 func TestRefTypes(t *testing.T) {
 	sc := iceberg.NewSchema(1,
 		iceberg.NestedField{ID: 1, Name: "a", Type: iceberg.PrimitiveTypes.Bool},
@@ -209,7 +218,8 @@ func TestRefTypes(t *testing.T) {
 		iceberg.NestedField{ID: 10, Name: "j", Type: iceberg.PrimitiveTypes.String},
 		iceberg.NestedField{ID: 11, Name: "k", Type: iceberg.PrimitiveTypes.Binary},
 		iceberg.NestedField{ID: 12, Name: "l", Type: iceberg.PrimitiveTypes.UUID},
-		iceberg.NestedField{ID: 13, Name: "m", Type: iceberg.FixedTypeOf(5)})
+		iceberg.NestedField{ID: 13, Name: "m", Type: iceberg.FixedTypeOf(5)},
+		iceberg.NestedField{ID: 14, Name: "n", Type: iceberg.PrimitiveTypes.TimestampTzNs})
 
 	t.Run("bind term", func(t *testing.T) {
 		for i := 0; i < sc.NumFields(); i++ {
@@ -287,6 +297,15 @@ func TestRefTypes(t *testing.T) {
 			assert.Equal(t, iceberg.OpEQ, uid.Op())
 			assert.True(t, uid.(iceberg.BoundLiteralPredicate).Literal().Type().Equals(iceberg.PrimitiveTypes.UUID))
 		})
+
+		t.Run("timestamptz_ns", func(t *testing.T) {
+			ts, err := iceberg.GreaterThan(iceberg.Reference("n"), "2017-08-18T14:21:01.919234567+00:00").Bind(sc, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, iceberg.OpGT, ts.Op())
+			assert.Equal(t, iceberg.TimestampTzNanoLiteral(1503066061919234567),
+				ts.(iceberg.BoundLiteralPredicate).Literal())
+		})
 	})
 
 	t.Run("bind set", func(t *testing.T) {
@@ -418,6 +437,21 @@ func TestInNotInSimplifications(t *testing.T) {
 		assert.True(t, bsp.Literals().Contains(iceberg.NewLiteral("world")))
 	})
 
+	t.Run("bind sorts", func(t *testing.T) {
+		isin := iceberg.IsIn(iceberg.Reference("foo"), "world", "hello", "world", "abc")
+		bound, err := isin.(iceberg.UnboundPredicate).Bind(tableSchemaSimple, true)
+		require.NoError(t, err)
+
+		lits := bound.(iceberg.BoundSetPredicate).Literals()
+		assert.Equal(t, []iceberg.Literal{iceberg.NewLiteral("abc"),
+			iceberg.NewLiteral("hello"), iceberg.NewLiteral("world")}, lits.Members())
+
+		other, err := iceberg.IsIn(iceberg.Reference("foo"), "abc", "hello", "world").(iceberg.UnboundPredicate).
+			Bind(tableSchemaSimple, true)
+		require.NoError(t, err)
+		assert.True(t, bound.Equals(other))
+	})
+
 	t.Run("bind dedup to eq", func(t *testing.T) {
 		isin := iceberg.IsIn(iceberg.Reference("foo"), "world", "world")
 		bound, err := isin.(iceberg.UnboundPredicate).Bind(tableSchemaSimple, true)
@@ -740,3 +774,80 @@ func TestBindAboveBelowIntMax(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalExpressionJSON(t *testing.T) {
+	expr := iceberg.NewAnd(
+		iceberg.EqualTo(iceberg.Reference("x"), int64(5)),
+		iceberg.NewOr(
+			iceberg.IsNull(iceberg.Reference("y")),
+			iceberg.NewNot(iceberg.StartsWith(iceberg.Reference("s"), "ab"))),
+		iceberg.LessThan(iceberg.Reference("d"), iceberg.Date(17486)),
+		iceberg.EqualTo(iceberg.Reference("b"), false))
+
+	out, err := iceberg.MarshalExpressionJSON(expr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "and",
+		"left": {
+			"type": "and",
+			"left": {
+				"type": "and",
+				"left": {"type": "eq", "term": "x", "value": 5},
+				"right": {
+					"type": "or",
+					"left": {"type": "is-null", "term": "y"},
+					"right": {"type": "not", "child": {"type": "starts-with", "term": "s", "value": "ab"}}
+				}
+			},
+			"right": {"type": "lt", "term": "d", "value": "2017-11-16"}
+		},
+		"right": {"type": "eq", "term": "b", "value": false}
+	}`, string(out))
+
+	for _, tt := range []struct {
+		expr     iceberg.BooleanExpression
+		expected string
+	}{
+		{iceberg.AlwaysTrue{}, `true`},
+		{iceberg.AlwaysFalse{}, `false`},
+		{iceberg.NotNaN(iceberg.Reference("f")), `{"type": "not-nan", "term": "f"}`},
+		{iceberg.GreaterThanEqual(iceberg.Reference("ts"), iceberg.Timestamp(1510871468123000)),
+			`{"type": "gt-eq", "term": "ts", "value": "2017-11-16T22:31:08.123"}`},
+		{iceberg.NotEqualTo(iceberg.Reference("t"), iceberg.Time(81068000000)),
+			`{"type": "not-eq", "term": "t", "value": "22:31:08"}`},
+		{iceberg.EqualTo(iceberg.Reference("bin"), []byte{0xca, 0xfe}),
+			`{"type": "eq", "term": "bin", "value": "CAFE"}`},
+	} {
+		out, err := iceberg.MarshalExpressionJSON(tt.expr)
+		require.NoError(t, err)
+		assert.JSONEq(t, tt.expected, string(out), tt.expr.String())
+	}
+
+	out, err = iceberg.MarshalExpressionJSON(iceberg.NotIn(iceberg.Reference("x"), int64(1), int64(2)))
+	require.NoError(t, err)
+	var set struct {
+		Type   string `json:"type"`
+		Term   string `json:"term"`
+		Values []any  `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(out, &set))
+	assert.Equal(t, "not-in", set.Type)
+	assert.Equal(t, "x", set.Term)
+	assert.ElementsMatch(t, []any{float64(1), float64(2)}, set.Values)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 2, Name: "amount", Type: iceberg.DecimalTypeOf(10, 2)})
+	bound, err := iceberg.BindExpr(sc, iceberg.NewAnd(
+		iceberg.LessThan(iceberg.Reference("tstz"), iceberg.Timestamp(1510871460000000)),
+		iceberg.GreaterThan(iceberg.Reference("amount"), 10.5)), true)
+	require.NoError(t, err)
+
+	out, err = iceberg.MarshalExpressionJSON(bound)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "and",
+		"left": {"type": "lt", "term": "tstz", "value": "2017-11-16T22:31:00+00:00"},
+		"right": {"type": "gt", "term": "amount", "value": "10.50"}
+	}`, string(out))
+}
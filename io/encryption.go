@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+// EncryptionManager decrypts the files of tables which use Iceberg's
+// table encryption, using the key metadata recorded for each file in
+// the manifest list or manifest that references it.
+type EncryptionManager interface {
+	// Decrypt returns a File which reads the plaintext of the encrypted
+	// file f, using the key metadata of the file.
+	Decrypt(f File, keyMetadata []byte) (File, error)
+}
+
+// EncryptionManagerFunc adapts a function to the EncryptionManager
+// interface. It can be used to plug in a KMS-backed implementation
+// which unwraps the key from the key metadata.
+type EncryptionManagerFunc func(f File, keyMetadata []byte) (File, error)
+
+func (fn EncryptionManagerFunc) Decrypt(f File, keyMetadata []byte) (File, error) {
+	return fn(f, keyMetadata)
+}
+
+// PlaintextEncryptionManager is the EncryptionManager for tables which
+// are not encrypted, it returns files unchanged.
+var PlaintextEncryptionManager EncryptionManager = EncryptionManagerFunc(
+	func(f File, _ []byte) (File, error) { return f, nil })
+
+// DecryptingIO is the interface implemented by a file system which can
+// decrypt the files it opens.
+type DecryptingIO interface {
+	IO
+
+	// OpenDecrypted opens the named file, decrypting it with the given
+	// key metadata.
+	OpenDecrypted(name string, keyMetadata []byte) (File, error)
+}
+
+// WithEncryption wraps fsys so that files opened with OpenFile using
+// key metadata are decrypted by mgr.
+func WithEncryption(fsys IO, mgr EncryptionManager) DecryptingIO {
+	return encryptedIO{IO: fsys, mgr: mgr}
+}
+
+type encryptedIO struct {
+	IO
+
+	mgr EncryptionManager
+}
+
+func (e encryptedIO) OpenDecrypted(name string, keyMetadata []byte) (File, error) {
+	f, err := e.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := e.mgr.Decrypt(f, keyMetadata)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+// OpenFile opens the named file from fsys. If keyMetadata is non-empty
+// and fsys implements DecryptingIO the file is decrypted, otherwise it
+// is opened as is so that the metadata of encrypted tables can still be
+// read.
+func OpenFile(fsys IO, name string, keyMetadata []byte) (File, error) {
+	if dio, ok := fsys.(DecryptingIO); ok && len(keyMetadata) > 0 {
+		return dio.OpenDecrypted(name, keyMetadata)
+	}
+	return fsys.Open(name)
+}
@@ -24,6 +24,9 @@ import (
 	"io/fs"
 	"net/url"
 	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 )
 
 // IO is an interface to a hierarchical file system.
@@ -65,6 +68,24 @@ type ReadFileIO interface {
 	ReadFile(name string) ([]byte, error)
 }
 
+// WriteFileIO is the interface implemented by a file system that can
+// write whole files atomically, which is needed to commit to tables
+// without a catalog.
+type WriteFileIO interface {
+	IO
+
+	// WriteFile replaces the contents of the named file with data,
+	// creating it if it doesn't exist. Readers see either the old or
+	// the new contents, never a partially written file.
+	WriteFile(name string, data []byte) error
+
+	// WriteFileIfAbsent creates the named file with the contents of
+	// data, failing with an error wrapping fs.ErrExist if it already
+	// exists. Of several concurrent writers of the same file, exactly
+	// one succeeds.
+	WriteFileIfAbsent(name string, data []byte) error
+}
+
 // A File provides access to a single file. The File interface is the
 // minimum implementation required for Iceberg to interact with a file.
 // Directory files should also implement
@@ -206,7 +227,11 @@ func (f ioFile) ReadDir(count int) ([]fs.DirEntry, error) {
 	return d.ReadDir(count)
 }
 
-func inferFileIOFromSchema(path string, props map[string]string) (IO, error) {
+func inferFileIOFromSchema(path string, props map[string]string, awsConfig *aws.Config) (IO, error) {
+	if isLocalPath(path) {
+		return LocalFS{}, nil
+	}
+
 	parsed, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -214,7 +239,7 @@ func inferFileIOFromSchema(path string, props map[string]string) (IO, error) {
 
 	switch parsed.Scheme {
 	case "s3", "s3a", "s3n":
-		return createS3FileIO(parsed, props)
+		return createS3FileIO(parsed, props, awsConfig)
 	case "file", "":
 		return LocalFS{}, nil
 	default:
@@ -225,17 +250,22 @@ func inferFileIOFromSchema(path string, props map[string]string) (IO, error) {
 // LoadFS takes a map of properties and an optional URI location
 // and attempts to infer an IO object from it.
 //
-// A schema of "file://" or an empty string will result in a LocalFS
-// implementation. Otherwise this will return an error if the schema
+// A schema of "file://" or an empty string, or a path on a Windows drive,
+// will result in a LocalFS implementation. Otherwise this will return an error if the schema
 // does not yet have an implementation here.
 //
-// Currently only LocalFS and S3 are implemented.
+// Currently only LocalFS and S3 are implemented. The "s3a://" and
+// "s3n://" schemes are accepted as aliases for "s3://".
 func LoadFS(props map[string]string, location string) (IO, error) {
+	return loadFS(props, location, nil)
+}
+
+func loadFS(props map[string]string, location string, awsConfig *aws.Config) (IO, error) {
 	if location == "" {
 		location = props["warehouse"]
 	}
 
-	iofs, err := inferFileIOFromSchema(location, props)
+	iofs, err := inferFileIOFromSchema(location, props, awsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -246,3 +276,115 @@ func LoadFS(props map[string]string, location string) (IO, error) {
 
 	return iofs, nil
 }
+
+// LoadFSForLocations is like LoadFS, but returns an IO which opens each
+// file with the IO for the file's own scheme and bucket rather than those
+// of the given location. This allows reading tables whose data files are
+// stored in other buckets or stores than their metadata. The IOs for
+// other locations are created with the same properties when they're
+// first used, and are reused for other files in the same bucket.
+func LoadFSForLocations(props map[string]string, location string) (IO, error) {
+	return LoadFSForLocationsWithAWSConfig(nil, props, location)
+}
+
+// LoadFSForLocationsWithAWSConfig is like LoadFSForLocations, but S3 IOs
+// are configured with the region, credentials, endpoint resolution and
+// HTTP client of awsConfig, such as the config of a Glue catalog, unless
+// they're overridden by the s3 properties. A nil awsConfig loads the
+// default AWS configuration instead.
+func LoadFSForLocationsWithAWSConfig(awsConfig *aws.Config, props map[string]string, location string) (IO, error) {
+	if location == "" {
+		location = props["warehouse"]
+	}
+
+	base, err := loadFS(props, location, awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &locationIO{
+		props:     props,
+		awsConfig: awsConfig,
+		ios:       map[string]IO{locationKey(location): base},
+	}, nil
+}
+
+type locationIO struct {
+	props     map[string]string
+	awsConfig *aws.Config
+
+	mx  sync.Mutex
+	ios map[string]IO
+}
+
+// locationKey identifies the IO needed for a location by its scheme and
+// bucket, treating the s3 scheme aliases as the same.
+func locationKey(location string) string {
+	if isLocalPath(location) {
+		return "file"
+	}
+
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+
+	switch parsed.Scheme {
+	case "", "file":
+		return "file"
+	case "s3a", "s3n":
+		parsed.Scheme = "s3"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+func (l *locationIO) forLocation(name string) (IO, error) {
+	key := locationKey(name)
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if fsys, ok := l.ios[key]; ok {
+		return fsys, nil
+	}
+
+	fsys, err := loadFS(l.props, name, l.awsConfig)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	l.ios[key] = fsys
+	return fsys, nil
+}
+
+func (l *locationIO) Open(name string) (File, error) {
+	fsys, err := l.forLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	return fsys.Open(name)
+}
+
+func (l *locationIO) ReadFile(name string) ([]byte, error) {
+	fsys, err := l.forLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if rf, ok := fsys.(ReadFileIO); ok {
+		return rf.ReadFile(name)
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func (l *locationIO) Remove(name string) error {
+	fsys, err := l.forLocation(name)
+	if err != nil {
+		return err
+	}
+	return fsys.Remove(name)
+}
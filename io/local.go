@@ -17,16 +17,113 @@
 
 package io
 
-import "os"
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
 
 // LocalFS is an implementation of IO that implements interaction with
-// the local file system.
+// the local file system. Names may be paths or "file://" URIs, such as
+// file:///tmp/table or file:///C:/table on Windows.
 type LocalFS struct{}
 
+// localPath returns the path on the local file system of name, which may
+// be a "file:" URI.
+func localPath(name string) string {
+	if !strings.HasPrefix(name, "file:") {
+		return name
+	}
+
+	parsed, err := url.Parse(name)
+	if err != nil || parsed.Scheme != "file" {
+		return name
+	}
+
+	path := parsed.Path
+	// file:///C:/dir has the path /C:/dir, with the drive after the slash
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// isLocalPath is whether location is a path on a Windows drive, such as
+// C:\dir, which would otherwise be parsed as a URI with the scheme "c".
+func isLocalPath(location string) bool {
+	return filepath.VolumeName(location) != ""
+}
+
 func (LocalFS) Open(name string) (File, error) {
-	return os.Open(name)
+	return os.Open(localPath(name))
 }
 
 func (LocalFS) Remove(name string) error {
-	return os.Remove(name)
+	return os.Remove(localPath(name))
+}
+
+// writeTemp writes data to a new temporary file in the directory of
+// name, so that it can be renamed or linked to name atomically.
+func writeTemp(name string, data []byte) (string, error) {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// WriteFile writes data to a temporary file which is then renamed to
+// name, replacing it atomically. Missing parent directories of name are
+// created.
+func (LocalFS) WriteFile(name string, data []byte) error {
+	name = localPath(name)
+	tmp, err := writeTemp(name, data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, name); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// WriteFileIfAbsent writes data to a temporary file which is then hard
+// linked to name, which fails if name already exists.
+func (LocalFS) WriteFileIfAbsent(name string, data []byte) error {
+	name = localPath(name)
+	tmp, err := writeTemp(name, data)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if err := os.Link(tmp, name); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return &os.PathError{Op: "create", Path: name, Err: os.ErrExist}
+		}
+		return err
+	}
+	return nil
 }
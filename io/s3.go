@@ -44,8 +44,33 @@ const (
 	S3ProxyURI        = "s3.proxy-uri"
 )
 
-func createS3FileIO(parsed *url.URL, props map[string]string) (IO, error) {
+// awsConfigOptions returns options which load a config using the region,
+// credentials, endpoint resolution and HTTP client of cfg, so that an S3
+// IO can share the AWS configuration of a catalog.
+func awsConfigOptions(cfg *aws.Config) []func(*config.LoadOptions) error {
+	var opts []func(*config.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, config.WithRegion(cfg.Region))
+	}
+	if cfg.Credentials != nil {
+		opts = append(opts, config.WithCredentialsProvider(cfg.Credentials))
+	}
+	if cfg.EndpointResolverWithOptions != nil {
+		opts = append(opts, config.WithEndpointResolverWithOptions(cfg.EndpointResolverWithOptions))
+	}
+	if cfg.HTTPClient != nil {
+		opts = append(opts, config.WithHTTPClient(cfg.HTTPClient))
+	}
+	return opts
+}
+
+func createS3FileIO(parsed *url.URL, props map[string]string, base *aws.Config) (IO, error) {
 	opts := []func(*config.LoadOptions) error{}
+	// the s3 properties are applied after the base config, overriding it
+	if base != nil {
+		opts = append(opts, awsConfigOptions(base)...)
+	}
+
 	endpoint, ok := props[S3EndpointURL]
 	if !ok {
 		endpoint = os.Getenv("AWS_S3_ENDPOINT")
@@ -103,14 +128,24 @@ func createS3FileIO(parsed *url.URL, props map[string]string) (IO, error) {
 	}
 
 	preprocess := func(n string) string {
-		_, after, found := strings.Cut(n, "://")
-		if found {
-			n = after
-		}
-
-		return strings.TrimPrefix(n, parsed.Host)
+		return s3Key(n, parsed.Host)
 	}
 
 	s3fs := s3iofs.New(parsed.Host, awscfg)
 	return FSPreProcName(s3fs, preprocess), nil
 }
+
+// s3Key strips the scheme and bucket from a location, returning the
+// object key. The s3, s3a and s3n schemes are all treated as aliases for
+// the same backend, so metadata written by Hadoop-based engines which
+// mixes them still resolves to the same objects.
+func s3Key(location, bucket string) string {
+	for _, scheme := range []string{"s3://", "s3a://", "s3n://"} {
+		if after, found := strings.CutPrefix(location, scheme); found {
+			location = after
+			break
+		}
+	}
+
+	return strings.TrimPrefix(location, bucket)
+}
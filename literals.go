@@ -20,6 +20,7 @@ package iceberg
 import (
 	"bytes"
 	"cmp"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
@@ -36,7 +37,7 @@ import (
 // for literal values. This represents the actual primitive types that exist in Iceberg
 type LiteralType interface {
 	bool | int32 | int64 | float32 | float64 | Date |
-		Time | Timestamp | string | []byte | uuid.UUID | Decimal
+		Time | Timestamp | TimestampNano | string | []byte | uuid.UUID | Decimal
 }
 
 // Comparator is a comparison function for specific literal types:
@@ -85,6 +86,8 @@ func NewLiteral[T LiteralType](val T) Literal {
 		return TimeLiteral(v)
 	case Timestamp:
 		return TimestampLiteral(v)
+	case TimestampNano:
+		return TimestampNanoLiteral(v)
 	case string:
 		return StringLiteral(v)
 	case []byte:
@@ -286,7 +289,11 @@ func (i Int32Literal) To(t Type) (Literal, error) {
 	case TimestampType:
 		return TimestampLiteral(i), nil
 	case TimestampTzType:
-		return TimestampLiteral(i), nil
+		return TimestampTzLiteral(i), nil
+	case TimestampNsType:
+		return TimestampNanoLiteral(i), nil
+	case TimestampTzNsType:
+		return TimestampTzNanoLiteral(i), nil
 	case DecimalType:
 		unscaled := Decimal{Val: decimal128.FromI64(int64(i)), Scale: 0}
 		if t.scale == 0 {
@@ -334,7 +341,11 @@ func (i Int64Literal) To(t Type) (Literal, error) {
 	case TimestampType:
 		return TimestampLiteral(i), nil
 	case TimestampTzType:
-		return TimestampLiteral(i), nil
+		return TimestampTzLiteral(i), nil
+	case TimestampNsType:
+		return TimestampNanoLiteral(i), nil
+	case TimestampTzNsType:
+		return TimestampTzNanoLiteral(i), nil
 	case DecimalType:
 		unscaled := Decimal{Val: decimal128.FromI64(int64(i)), Scale: 0}
 		if t.scale == 0 {
@@ -461,20 +472,106 @@ func (t TimestampLiteral) String() string {
 	return tm.Format("2006-01-02 15:04:05.000000")
 }
 func (t TimestampLiteral) To(typ Type) (Literal, error) {
+	return timestampLiteralTo(Timestamp(t), typ)
+}
+func (t TimestampLiteral) Equals(other Literal) bool {
+	return literalEq(t, other)
+}
+
+// TimestampTzLiteral is a timestamp with microsecond precision which has
+// been adjusted to UTC. It shares its value representation with
+// TimestampLiteral but reports its type as timestamptz, so that a literal
+// bound to a timestamptz column never loses its zone semantics.
+type TimestampTzLiteral Timestamp
+
+func (TimestampTzLiteral) Comparator() Comparator[Timestamp] { return cmp.Compare[Timestamp] }
+func (t TimestampTzLiteral) Type() Type                      { return PrimitiveTypes.TimestampTz }
+func (t TimestampTzLiteral) Value() Timestamp                { return Timestamp(t) }
+func (t TimestampTzLiteral) String() string {
+	tm := time.UnixMicro(int64(t)).UTC()
+	return tm.Format("2006-01-02 15:04:05.000000-07:00")
+}
+func (t TimestampTzLiteral) To(typ Type) (Literal, error) {
+	return timestampLiteralTo(Timestamp(t), typ)
+}
+func (t TimestampTzLiteral) Equals(other Literal) bool {
+	return literalEq(t, other)
+}
+
+func timestampLiteralTo(t Timestamp, typ Type) (Literal, error) {
 	switch typ.(type) {
 	case TimestampType:
-		return t, nil
+		return TimestampLiteral(t), nil
 	case TimestampTzType:
-		return t, nil
+		return TimestampTzLiteral(t), nil
+	case TimestampNsType:
+		return TimestampNanoLiteral(int64(t) * int64(time.Microsecond)), nil
+	case TimestampTzNsType:
+		return TimestampTzNanoLiteral(int64(t) * int64(time.Microsecond)), nil
 	case DateType:
-		return DateLiteral(Timestamp(t).ToDate()), nil
+		return DateLiteral(t.ToDate()), nil
 	}
 	return nil, fmt.Errorf("%w: TimestampLiteral to %s", ErrBadCast, typ)
 }
-func (t TimestampLiteral) Equals(other Literal) bool {
+
+type TimestampNanoLiteral TimestampNano
+
+func (TimestampNanoLiteral) Comparator() Comparator[TimestampNano] {
+	return cmp.Compare[TimestampNano]
+}
+func (t TimestampNanoLiteral) Type() Type           { return PrimitiveTypes.TimestampNs }
+func (t TimestampNanoLiteral) Value() TimestampNano { return TimestampNano(t) }
+func (t TimestampNanoLiteral) String() string {
+	tm := time.Unix(0, int64(t)).UTC()
+	return tm.Format("2006-01-02 15:04:05.000000000")
+}
+func (t TimestampNanoLiteral) To(typ Type) (Literal, error) {
+	return timestampNanoLiteralTo(TimestampNano(t), typ)
+}
+func (t TimestampNanoLiteral) Equals(other Literal) bool {
 	return literalEq(t, other)
 }
 
+// TimestampTzNanoLiteral is the nanosecond precision counterpart of
+// TimestampTzLiteral, reporting its type as timestamptz_ns.
+type TimestampTzNanoLiteral TimestampNano
+
+func (TimestampTzNanoLiteral) Comparator() Comparator[TimestampNano] {
+	return cmp.Compare[TimestampNano]
+}
+func (t TimestampTzNanoLiteral) Type() Type           { return PrimitiveTypes.TimestampTzNs }
+func (t TimestampTzNanoLiteral) Value() TimestampNano { return TimestampNano(t) }
+func (t TimestampTzNanoLiteral) String() string {
+	tm := time.Unix(0, int64(t)).UTC()
+	return tm.Format("2006-01-02 15:04:05.000000000-07:00")
+}
+func (t TimestampTzNanoLiteral) To(typ Type) (Literal, error) {
+	return timestampNanoLiteralTo(TimestampNano(t), typ)
+}
+func (t TimestampTzNanoLiteral) Equals(other Literal) bool {
+	return literalEq(t, other)
+}
+
+func timestampNanoLiteralTo(t TimestampNano, typ Type) (Literal, error) {
+	switch typ.(type) {
+	case TimestampNsType:
+		return TimestampNanoLiteral(t), nil
+	case TimestampTzNsType:
+		return TimestampTzNanoLiteral(t), nil
+	case TimestampType, TimestampTzType:
+		// truncate towards negative infinity, as is done for other
+		// conversions to a coarser unit of time
+		micros := int64(t) / int64(time.Microsecond)
+		if int64(t)%int64(time.Microsecond) < 0 {
+			micros--
+		}
+		return timestampLiteralTo(Timestamp(micros), typ)
+	case DateType:
+		return DateLiteral(t.ToDate()), nil
+	}
+	return nil, fmt.Errorf("%w: TimestampNanoLiteral to %s", ErrBadCast, typ)
+}
+
 type StringLiteral string
 
 func (StringLiteral) Comparator() Comparator[string] { return cmp.Compare[string] }
@@ -553,7 +650,25 @@ func (s StringLiteral) To(typ Type) (Literal, error) {
 				ErrBadCast, s, err.Error())
 		}
 
-		return TimestampLiteral(Timestamp(tm.UTC().UnixMicro())), nil
+		return TimestampTzLiteral(Timestamp(tm.UTC().UnixMicro())), nil
+	case TimestampNsType:
+		// requires RFC3339 with no time zone
+		tm, err := time.Parse("2006-01-02T15:04:05.999999999", string(s))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid TimestampNs format for casting from string '%s': %s",
+				ErrBadCast, s, err.Error())
+		}
+
+		return TimestampNanoLiteral(TimestampNano(tm.UTC().UnixNano())), nil
+	case TimestampTzNsType:
+		// requires RFC3339 format WITH time zone
+		tm, err := time.Parse(time.RFC3339Nano, string(s))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid TimestampTzNs format for casting from string '%s': %s",
+				ErrBadCast, s, err.Error())
+		}
+
+		return TimestampTzNanoLiteral(TimestampNano(tm.UTC().UnixNano())), nil
 	case UUIDType:
 		val, err := uuid.Parse(string(s))
 		if err != nil {
@@ -778,3 +893,130 @@ func (d DecimalLiteral) Equals(other Literal) bool {
 	}
 	return d.Val == rescaled
 }
+
+// LiteralFromBytes decodes a value of the given type using the Iceberg
+// single-value binary serialization, which is used for the lower and upper
+// bounds stored in manifests and manifest lists.
+//
+// For Int64 and Float64 types, a 4-byte value is also accepted as the bounds
+// may have been written before the column was promoted from an Int32 or
+// Float32.
+//
+// https://iceberg.apache.org/spec/#binary-single-value-serialization
+func LiteralFromBytes(typ Type, data []byte) (Literal, error) {
+	if data == nil {
+		return nil, fmt.Errorf("%w: cannot deserialize nil bytes", ErrBadLiteral)
+	}
+
+	checkLen := func(n int) error {
+		if len(data) != n {
+			return fmt.Errorf("%w: expected %d bytes for %s, got %d",
+				ErrBadLiteral, n, typ, len(data))
+		}
+		return nil
+	}
+
+	switch t := typ.(type) {
+	case BooleanType:
+		if err := checkLen(1); err != nil {
+			return nil, err
+		}
+		return BoolLiteral(data[0] != 0), nil
+	case Int32Type:
+		if err := checkLen(4); err != nil {
+			return nil, err
+		}
+		return Int32Literal(int32(binary.LittleEndian.Uint32(data))), nil
+	case Int64Type:
+		if len(data) == 4 {
+			return Int64Literal(int32(binary.LittleEndian.Uint32(data))), nil
+		}
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return Int64Literal(int64(binary.LittleEndian.Uint64(data))), nil
+	case Float32Type:
+		if err := checkLen(4); err != nil {
+			return nil, err
+		}
+		return Float32Literal(math.Float32frombits(binary.LittleEndian.Uint32(data))), nil
+	case Float64Type:
+		if len(data) == 4 {
+			return Float64Literal(math.Float32frombits(binary.LittleEndian.Uint32(data))), nil
+		}
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return Float64Literal(math.Float64frombits(binary.LittleEndian.Uint64(data))), nil
+	case DateType:
+		if err := checkLen(4); err != nil {
+			return nil, err
+		}
+		return DateLiteral(int32(binary.LittleEndian.Uint32(data))), nil
+	case TimeType:
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return TimeLiteral(int64(binary.LittleEndian.Uint64(data))), nil
+	case TimestampType:
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return TimestampLiteral(int64(binary.LittleEndian.Uint64(data))), nil
+	case TimestampTzType:
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return TimestampTzLiteral(int64(binary.LittleEndian.Uint64(data))), nil
+	case TimestampNsType:
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return TimestampNanoLiteral(int64(binary.LittleEndian.Uint64(data))), nil
+	case TimestampTzNsType:
+		if err := checkLen(8); err != nil {
+			return nil, err
+		}
+		return TimestampTzNanoLiteral(int64(binary.LittleEndian.Uint64(data))), nil
+	case StringType:
+		return StringLiteral(data), nil
+	case BinaryType:
+		return BinaryLiteral(bytes.Clone(data)), nil
+	case FixedType:
+		if err := checkLen(t.len); err != nil {
+			return nil, err
+		}
+		return FixedLiteral(bytes.Clone(data)), nil
+	case UUIDType:
+		v, err := uuid.FromBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrBadLiteral, err)
+		}
+		return UUIDLiteral(v), nil
+	case DecimalType:
+		if len(data) == 0 || len(data) > 16 {
+			return nil, fmt.Errorf("%w: invalid length %d for %s",
+				ErrBadLiteral, len(data), typ)
+		}
+
+		// the unscaled value is stored as a big-endian two's complement
+		// integer using the minimum number of bytes, so sign-extend it
+		// to the full 16 bytes.
+		var buf [16]byte
+		if data[0]&0x80 != 0 {
+			for i := range buf {
+				buf[i] = 0xFF
+			}
+		}
+		copy(buf[16-len(data):], data)
+
+		return DecimalLiteral{
+			Val: decimal128.New(int64(binary.BigEndian.Uint64(buf[:8])),
+				binary.BigEndian.Uint64(buf[8:])),
+			Scale: t.scale,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: cannot deserialize literal of type %s",
+		ErrType, typ)
+}
@@ -322,6 +322,96 @@ func TestLiteralTimestampToDate(t *testing.T) {
 	assert.Zero(t, dateLit)
 }
 
+func TestLiteralTimestampNano(t *testing.T) {
+	lit, err := iceberg.NewLiteral("2017-08-18T14:21:01.919234567").To(iceberg.PrimitiveTypes.TimestampNs)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.PrimitiveTypes.TimestampNs, lit.Type())
+	assert.Equal(t, iceberg.TimestampNanoLiteral(1503066061919234567), lit)
+	assert.Equal(t, "2017-08-18 14:21:01.919234567", lit.String())
+
+	tz, err := iceberg.NewLiteral("2017-08-18T07:21:01.919234567-07:00").To(iceberg.PrimitiveTypes.TimestampTzNs)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.PrimitiveTypes.TimestampTzNs, tz.Type())
+	assert.Equal(t, iceberg.TimestampTzNanoLiteral(1503066061919234567), tz)
+	assert.Equal(t, "2017-08-18 14:21:01.919234567+00:00", tz.String())
+	assert.False(t, lit.Equals(tz))
+
+	micros, err := lit.To(iceberg.PrimitiveTypes.Timestamp)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.TimestampLiteral(1503066061919234), micros)
+
+	nanos, err := micros.To(iceberg.PrimitiveTypes.TimestampTzNs)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.TimestampTzNanoLiteral(1503066061919234000), nanos)
+
+	// conversion to a coarser unit rounds down, also before the epoch
+	micros, err = iceberg.TimestampNanoLiteral(-1).To(iceberg.PrimitiveTypes.Timestamp)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.TimestampLiteral(-1), micros)
+
+	date, err := lit.To(iceberg.PrimitiveTypes.Date)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.DateLiteral(17396), date)
+
+	fromLong, err := iceberg.NewLiteral(int64(1503066061919234567)).To(iceberg.PrimitiveTypes.TimestampNs)
+	require.NoError(t, err)
+	assert.Equal(t, lit, fromLong)
+
+	_, err = lit.To(iceberg.PrimitiveTypes.Time)
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+}
+
+func TestLiteralTimestampTz(t *testing.T) {
+	ts, err := iceberg.NewLiteral("2017-08-18T14:21:01.919234").To(iceberg.PrimitiveTypes.Timestamp)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.PrimitiveTypes.Timestamp, ts.Type())
+	assert.Equal(t, "2017-08-18 14:21:01.919234", ts.String())
+
+	tz, err := iceberg.NewLiteral("2017-08-18T07:21:01.919234-07:00").To(iceberg.PrimitiveTypes.TimestampTz)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.PrimitiveTypes.TimestampTz, tz.Type())
+	assert.Equal(t, iceberg.TimestampTzLiteral(1503066061919234), tz)
+	assert.Equal(t, "2017-08-18 14:21:01.919234+00:00", tz.String())
+
+	// the same instant is not equal across the zone distinction
+	assert.False(t, ts.Equals(tz))
+	assert.False(t, tz.Equals(ts))
+
+	// converting explicitly keeps the value and changes the type
+	conv, err := ts.To(iceberg.PrimitiveTypes.TimestampTz)
+	require.NoError(t, err)
+	assert.Equal(t, tz, conv)
+	conv, err = tz.To(iceberg.PrimitiveTypes.Timestamp)
+	require.NoError(t, err)
+	assert.Equal(t, ts, conv)
+
+	nanos, err := tz.To(iceberg.PrimitiveTypes.TimestampTzNs)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.TimestampTzNanoLiteral(1503066061919234000), nanos)
+
+	fromLong, err := iceberg.NewLiteral(int64(1503066061919234)).To(iceberg.PrimitiveTypes.TimestampTz)
+	require.NoError(t, err)
+	assert.Equal(t, tz, fromLong)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+		iceberg.NestedField{ID: 2, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz},
+	)
+
+	bound, err := iceberg.EqualTo(iceberg.Reference("tstz"), iceberg.Timestamp(1503066061919234)).Bind(sc, true)
+	require.NoError(t, err)
+	assert.Equal(t, tz, bound.(iceberg.BoundLiteralPredicate).Literal())
+
+	bound, err = iceberg.EqualTo(iceberg.Reference("ts"), "2017-08-18T14:21:01.919234").Bind(sc, true)
+	require.NoError(t, err)
+	assert.Equal(t, ts, bound.(iceberg.BoundLiteralPredicate).Literal())
+
+	_, err = iceberg.EqualTo(iceberg.Reference("ts"), "2017-08-18T07:21:01.919234-07:00").Bind(sc, true)
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+	_, err = iceberg.EqualTo(iceberg.Reference("tstz"), "2017-08-18T14:21:01.919234").Bind(sc, true)
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+}
+
 func TestStringLiterals(t *testing.T) {
 	sqrt2 := iceberg.NewLiteral("1.414")
 	pi := iceberg.NewLiteral("3.141")
@@ -383,7 +473,7 @@ func TestStringLiteralConversion(t *testing.T) {
 	lit := iceberg.StringLiteral("2017-08-18T14:21:01.919234-07:00")
 	casted, err := lit.To(iceberg.PrimitiveTypes.TimestampTz)
 	require.NoError(t, err)
-	expectedTimestamp := iceberg.NewLiteral(iceberg.Timestamp(1503091261919234))
+	expectedTimestamp := iceberg.TimestampTzLiteral(1503091261919234)
 	assert.Truef(t, casted.Equals(expectedTimestamp), "expected: %s, got: %s",
 		expectedTimestamp, casted)
 
@@ -703,6 +793,8 @@ func TestBadStringLiteralCasts(t *testing.T) {
 		iceberg.PrimitiveTypes.Time,
 		iceberg.PrimitiveTypes.Timestamp,
 		iceberg.PrimitiveTypes.TimestampTz,
+		iceberg.PrimitiveTypes.TimestampNs,
+		iceberg.PrimitiveTypes.TimestampTzNs,
 		iceberg.PrimitiveTypes.Bool,
 		iceberg.DecimalTypeOf(9, 2),
 		iceberg.PrimitiveTypes.UUID,
@@ -727,3 +819,60 @@ func TestStringLiteralToIntMaxMinValue(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, iceberg.Int32BelowMinLiteral(), below)
 }
+
+func TestLiteralFromBytes(t *testing.T) {
+	tests := []struct {
+		typ      iceberg.Type
+		data     []byte
+		expected iceberg.Literal
+	}{
+		{iceberg.PrimitiveTypes.Bool, []byte{0x01}, iceberg.BoolLiteral(true)},
+		{iceberg.PrimitiveTypes.Bool, []byte{0x00}, iceberg.BoolLiteral(false)},
+		{iceberg.PrimitiveTypes.Int32, []byte{0xd2, 0x04, 0x00, 0x00}, iceberg.Int32Literal(1234)},
+		{iceberg.PrimitiveTypes.Int32, []byte{0xff, 0xff, 0xff, 0xff}, iceberg.Int32Literal(-1)},
+		{iceberg.PrimitiveTypes.Int64, []byte{0xd2, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, iceberg.Int64Literal(1234)},
+		// promoted from int32
+		{iceberg.PrimitiveTypes.Int64, []byte{0xfe, 0xff, 0xff, 0xff}, iceberg.Int64Literal(-2)},
+		{iceberg.PrimitiveTypes.Float32, []byte{0x00, 0x00, 0x90, 0xc0}, iceberg.Float32Literal(-4.5)},
+		{iceberg.PrimitiveTypes.Float64, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x12, 0xc0}, iceberg.Float64Literal(-4.5)},
+		// promoted from float32
+		{iceberg.PrimitiveTypes.Float64, []byte{0x00, 0x00, 0x90, 0xc0}, iceberg.Float64Literal(-4.5)},
+		{iceberg.PrimitiveTypes.Date, []byte{0xe8, 0x03, 0x00, 0x00}, iceberg.DateLiteral(1000)},
+		{iceberg.PrimitiveTypes.Time, []byte{0x00, 0xe8, 0x76, 0x48, 0x17, 0x00, 0x00, 0x00}, iceberg.TimeLiteral(100000000000)},
+		{iceberg.PrimitiveTypes.Timestamp, []byte{0x00, 0xe8, 0x76, 0x48, 0x17, 0x00, 0x00, 0x00}, iceberg.TimestampLiteral(100000000000)},
+		{iceberg.PrimitiveTypes.TimestampTz, []byte{0x00, 0xe8, 0x76, 0x48, 0x17, 0x00, 0x00, 0x00}, iceberg.TimestampTzLiteral(100000000000)},
+		{iceberg.PrimitiveTypes.TimestampNs, []byte{0x00, 0xe8, 0x76, 0x48, 0x17, 0x00, 0x00, 0x00}, iceberg.TimestampNanoLiteral(100000000000)},
+		{iceberg.PrimitiveTypes.TimestampTzNs, []byte{0x00, 0xe8, 0x76, 0x48, 0x17, 0x00, 0x00, 0x00}, iceberg.TimestampTzNanoLiteral(100000000000)},
+		{iceberg.PrimitiveTypes.String, []byte("foo"), iceberg.StringLiteral("foo")},
+		{iceberg.PrimitiveTypes.Binary, []byte("foo"), iceberg.BinaryLiteral("foo")},
+		{iceberg.FixedTypeOf(3), []byte("foo"), iceberg.FixedLiteral("foo")},
+		{iceberg.PrimitiveTypes.UUID, []byte{0xf7, 0x9c, 0x3e, 0x09, 0x67, 0x7c, 0x4b, 0xbd, 0xa4, 0x79, 0x3f, 0x34, 0x9c, 0xb7, 0x85, 0xe7},
+			iceberg.UUIDLiteral(uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7"))},
+		{iceberg.DecimalTypeOf(9, 2), []byte{0x30, 0x39},
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(12345), Scale: 2}},
+		{iceberg.DecimalTypeOf(9, 2), []byte{0xcf, 0xc7},
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(-12345), Scale: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			lit, err := iceberg.LiteralFromBytes(tt.typ, tt.data)
+			require.NoError(t, err)
+			assert.Truef(t, tt.expected.Equals(lit), "expected: %s, got: %s", tt.expected, lit)
+		})
+	}
+}
+
+func TestLiteralFromBytesErrors(t *testing.T) {
+	_, err := iceberg.LiteralFromBytes(iceberg.PrimitiveTypes.Int32, nil)
+	assert.ErrorIs(t, err, iceberg.ErrBadLiteral)
+
+	_, err = iceberg.LiteralFromBytes(iceberg.PrimitiveTypes.Int32, []byte{0x01, 0x02})
+	assert.ErrorIs(t, err, iceberg.ErrBadLiteral)
+
+	_, err = iceberg.LiteralFromBytes(iceberg.FixedTypeOf(4), []byte("foo"))
+	assert.ErrorIs(t, err, iceberg.ErrBadLiteral)
+
+	_, err = iceberg.LiteralFromBytes(&iceberg.StructType{}, []byte("foo"))
+	assert.ErrorIs(t, err, iceberg.ErrType)
+}
@@ -201,6 +201,7 @@ func (m *manifestFileV1) HasExistingFiles() bool {
 func (m *manifestFileV1) SequenceNum() int64    { return 0 }
 func (m *manifestFileV1) MinSequenceNum() int64 { return 0 }
 func (m *manifestFileV1) KeyMetadata() []byte   { return m.Key }
+func (m *manifestFileV1) FirstRowID() *int64    { return nil }
 func (m *manifestFileV1) Partitions() []FieldSummary {
 	if m.PartitionList == nil {
 		return nil
@@ -279,6 +280,11 @@ func (b *ManifestV2Builder) KeyMetadata(km []byte) *ManifestV2Builder {
 	return b
 }
 
+func (b *ManifestV2Builder) FirstRowID(id int64) *ManifestV2Builder {
+	b.m.FirstRow = &id
+	return b
+}
+
 // Build returns the constructed manifest file, after calling Build this
 // builder should not be used further as we avoid copying by just returning
 // a pointer to the constructed manifest file. Further calls to the modifier
@@ -303,6 +309,7 @@ type manifestFileV2 struct {
 	DeletedRowsCount   int64           `avro:"deleted_rows_count"`
 	PartitionList      *[]FieldSummary `avro:"partitions"`
 	Key                []byte          `avro:"key_metadata"`
+	FirstRow           *int64          `avro:"first_row_id"`
 }
 
 func (*manifestFileV2) Version() int { return 2 }
@@ -342,6 +349,7 @@ func (m *manifestFileV2) DeletedRows() int64 {
 func (m *manifestFileV2) SequenceNum() int64    { return m.SeqNumber }
 func (m *manifestFileV2) MinSequenceNum() int64 { return m.MinSeqNumber }
 func (m *manifestFileV2) KeyMetadata() []byte   { return m.Key }
+func (m *manifestFileV2) FirstRowID() *int64    { return m.FirstRow }
 
 func (m *manifestFileV2) Partitions() []FieldSummary {
 	if m.PartitionList == nil {
@@ -363,7 +371,7 @@ func (m *manifestFileV2) FetchEntries(fs iceio.IO, discardDeleted bool) ([]Manif
 }
 
 func fetchManifestEntries(m ManifestFile, fs iceio.IO, discardDeleted bool) ([]ManifestEntry, error) {
-	f, err := fs.Open(m.FilePath())
+	f, err := iceio.OpenFile(fs, m.FilePath(), m.KeyMetadata())
 	if err != nil {
 		return nil, err
 	}
@@ -376,9 +384,10 @@ func fetchManifestEntries(m ManifestFile, fs iceio.IO, discardDeleted bool) ([]M
 
 	metadata := dec.Metadata()
 	isVer1, isFallback := true, false
-	if string(metadata["format-version"]) == "2" {
+	switch string(metadata["format-version"]) {
+	case "2", "3":
 		isVer1 = false
-	} else {
+	default:
 		sc, err := avro.ParseBytes(dec.Metadata()["avro.schema"])
 		if err != nil {
 			return nil, err
@@ -394,6 +403,14 @@ func fetchManifestEntries(m ManifestFile, fs iceio.IO, discardDeleted bool) ([]M
 		}
 	}
 
+	// data files without a first row ID are assigned the next IDs after
+	// those of the preceding live files in the manifest
+	var nextRowID *int64
+	if first := m.FirstRowID(); first != nil {
+		next := *first
+		nextRowID = &next
+	}
+
 	results := make([]ManifestEntry, 0)
 	for dec.HasNext() {
 		var tmp ManifestEntry
@@ -415,6 +432,14 @@ func fetchManifestEntries(m ManifestFile, fs iceio.IO, discardDeleted bool) ([]M
 			tmp = tmp.(*fallbackManifestEntryV1).toEntry()
 		}
 
+		if nextRowID != nil && tmp.Status() != EntryStatusDELETED {
+			if df, ok := tmp.DataFile().(*dataFile); ok && df.Content == EntryContentData && df.FirstRow == nil {
+				id := *nextRowID
+				df.FirstRow = &id
+				*nextRowID += df.RecordCount
+			}
+		}
+
 		if !discardDeleted || tmp.Status() != EntryStatusDELETED {
 			tmp.inheritSeqNum(m)
 			results = append(results, tmp)
@@ -470,6 +495,10 @@ type ManifestFile interface {
 	// KeyMetadata returns implementation-specific key metadata for encryption
 	// if it exists in the manifest list.
 	KeyMetadata() []byte
+	// FirstRowID is the first row ID assigned to the rows of the data
+	// files added in this manifest, used for row lineage in v3 tables.
+	// It is nil for manifests of tables which don't track row lineage.
+	FirstRowID() *int64
 	// Partitions returns a list of field summaries for each partition
 	// field in the spec. Each field in the list corresponds to a field in
 	// the manifest file's partition spec.
@@ -482,7 +511,8 @@ type ManifestFile interface {
 	// FetchEntries reads the manifest list file to fetch the list of
 	// manifest entries using the provided file system IO interface.
 	// If discardDeleted is true, entries for files containing deleted rows
-	// will be skipped.
+	// will be skipped. If the manifest has key metadata and fs is an
+	// iceio.DecryptingIO, the manifest is decrypted before being read.
 	FetchEntries(fs iceio.IO, discardDeleted bool) ([]ManifestEntry, error)
 }
 
@@ -512,7 +542,7 @@ func ReadManifestList(in io.Reader) ([]ManifestFile, error) {
 	out := make([]ManifestFile, 0)
 	for dec.HasNext() {
 		var file ManifestFile
-		if string(dec.Metadata()["format-version"]) == "2" {
+		if v := string(dec.Metadata()["format-version"]); v == "2" || v == "3" {
 			file = &manifestFileV2{}
 		} else {
 			if fallbackAddedSnapshot {
@@ -564,6 +594,7 @@ const (
 	AvroFile    FileFormat = "AVRO"
 	OrcFile     FileFormat = "ORC"
 	ParquetFile FileFormat = "PARQUET"
+	PuffinFile  FileFormat = "PUFFIN"
 )
 
 type colMap[K, V any] struct {
@@ -602,6 +633,10 @@ type dataFile struct {
 	Splits           *[]int64               `avro:"split_offsets"`
 	EqualityIDs      *[]int                 `avro:"equality_ids"`
 	SortOrder        *int                   `avro:"sort_order_id"`
+	FirstRow         *int64                 `avro:"first_row_id"`
+	ReferencedFile   *string                `avro:"referenced_data_file"`
+	ContentOffsetPos *int64                 `avro:"content_offset"`
+	ContentSize      *int64                 `avro:"content_size_in_bytes"`
 
 	colSizeMap     map[int]int64
 	valCntMap      map[int]int64
@@ -686,10 +721,14 @@ func (d *dataFile) EqualityFieldIDs() []int {
 	if d.EqualityIDs == nil {
 		return nil
 	}
-	return d.EqualityFieldIDs()
+	return *d.EqualityIDs
 }
 
-func (d *dataFile) SortOrderID() *int { return d.SortOrder }
+func (d *dataFile) SortOrderID() *int           { return d.SortOrder }
+func (d *dataFile) FirstRowID() *int64          { return d.FirstRow }
+func (d *dataFile) ReferencedDataFile() *string { return d.ReferencedFile }
+func (d *dataFile) ContentOffset() *int64       { return d.ContentOffsetPos }
+func (d *dataFile) ContentSizeInBytes() *int64  { return d.ContentSize }
 
 type manifestEntryV1 struct {
 	EntryStatus ManifestEntryStatus `avro:"status"`
@@ -831,6 +870,23 @@ type DataFile interface {
 	// SortOrderID returns the id representing the sort order for this
 	// file, or nil if there is no sort order.
 	SortOrderID() *int
+	// FirstRowID returns the row ID of the first row in the data file,
+	// the following rows are assigned IDs by their position in the file.
+	// Files without an explicit first row ID inherit one from their
+	// manifest when it's read. It is nil for tables which don't track
+	// row lineage, and for delete files.
+	FirstRowID() *int64
+	// ReferencedDataFile is the location of the data file which all the
+	// deleted rows of a position delete file or deletion vector belong
+	// to, or nil if the deletes may apply to several data files.
+	ReferencedDataFile() *string
+	// ContentOffset is the offset in the file of the blob holding a
+	// deletion vector, which is stored in a Puffin file along with the
+	// deletion vectors of other data files. It is nil for other files.
+	ContentOffset() *int64
+	// ContentSizeInBytes is the length of the deletion vector blob at
+	// ContentOffset, or nil for files which aren't deletion vectors.
+	ContentSizeInBytes() *int64
 }
 
 // ManifestEntry is an interface for both v1 and v2 manifest entries.
@@ -19,12 +19,18 @@ package iceberg
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/hamba/avro/v2/ocf"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -567,6 +573,425 @@ func (m *ManifestTestSuite) TestManifestEntriesV1() {
 	m.Zero(*datafile.SortOrderID())
 }
 
+func (m *ManifestTestSuite) TestDecodedPartitionData() {
+	var mockfs internal.MockFS
+	mockfs.Test(m.T())
+	mockfs.On("Open", manifestFileRecordsV1[0].FilePath()).Return(&internal.MockFile{
+		Contents: bytes.NewReader(m.v1ManifestEntries.Bytes())}, nil)
+	defer mockfs.AssertExpectations(m.T())
+
+	entries, err := manifestFileRecordsV1[0].FetchEntries(&mockfs, false)
+	m.Require().NoError(err)
+	m.Require().NotEmpty(entries)
+
+	sc := NewSchema(0,
+		NestedField{ID: 1, Name: "VendorID", Type: PrimitiveTypes.Int32},
+		NestedField{ID: 2, Name: "tpep_pickup_datetime", Type: PrimitiveTypes.Timestamp})
+	spec := NewPartitionSpec(
+		PartitionField{SourceID: 1, FieldID: 1000, Name: "VendorID", Transform: IdentityTransform{}},
+		PartitionField{SourceID: 2, FieldID: 1001, Name: "tpep_pickup_datetime", Transform: DayTransform{}})
+
+	// optional partition values are decoded as unions of int and time.Time
+	for _, e := range entries {
+		m.NoError(spec.ValidatePartitionData(sc, e.DataFile().Partition()))
+	}
+}
+
+func (m *ManifestTestSuite) TestManifestEntriesEncrypted() {
+	xor := func(data []byte, key byte) []byte {
+		out := make([]byte, len(data))
+		for i, b := range data {
+			out[i] = b ^ key
+		}
+		return out
+	}
+
+	var mockfs internal.MockFS
+	manifest := manifestFileV2{
+		Path: manifestFileRecordsV2[0].FilePath(),
+		Key:  []byte{0x5A},
+	}
+
+	mockfs.Test(m.T())
+	mockfs.On("Open", manifest.FilePath()).Return(&internal.MockFile{
+		Contents: bytes.NewReader(xor(m.v2ManifestEntries.Bytes(), 0x5A))}, nil)
+	defer mockfs.AssertExpectations(m.T())
+
+	fs := iceio.WithEncryption(&mockfs, iceio.EncryptionManagerFunc(
+		func(f iceio.File, keyMetadata []byte) (iceio.File, error) {
+			data, err := io.ReadAll(f)
+			if err != nil {
+				return nil, err
+			}
+			return &internal.MockFile{Contents: bytes.NewReader(xor(data, keyMetadata[0]))}, nil
+		}))
+
+	entries, err := manifest.FetchEntries(fs, false)
+	m.Require().NoError(err)
+	m.Len(entries, 2)
+	m.Equal(manifestEntryV2Records[0].Data.Path, entries[0].DataFile().FilePath())
+}
+
+func (m *ManifestTestSuite) TestReadCompressedManifests() {
+	for _, codec := range []ocf.CodecName{ocf.Null, ocf.Deflate, ocf.Snappy, ocf.ZStandard} {
+		m.Run(string(codec), func() {
+			var list, entries bytes.Buffer
+			enc, err := ocf.NewEncoder(internal.AvroSchemaCache.Get(internal.ManifestListV2Key).String(),
+				&list, ocf.WithMetadata(map[string][]byte{"format-version": []byte("2")}),
+				ocf.WithCodec(codec))
+			m.Require().NoError(err)
+			m.Require().NoError(enc.Encode(manifestFileRecordsV2[0]))
+			m.Require().NoError(enc.Close())
+
+			enc, err = ocf.NewEncoder(internal.AvroSchemaCache.Get(internal.ManifestEntryV2Key).String(),
+				&entries, ocf.WithMetadata(map[string][]byte{"format-version": []byte("2")}),
+				ocf.WithCodec(codec))
+			m.Require().NoError(err)
+			for _, ent := range manifestEntryV2Records {
+				m.Require().NoError(enc.Encode(ent))
+			}
+			m.Require().NoError(enc.Close())
+
+			files, err := ReadManifestList(&list)
+			m.Require().NoError(err)
+			m.Require().Len(files, 1)
+			m.Equal(manifestFileRecordsV2[0].FilePath(), files[0].FilePath())
+
+			var mockfs internal.MockFS
+			mockfs.Test(m.T())
+			mockfs.On("Open", files[0].FilePath()).Return(&internal.MockFile{
+				Contents: bytes.NewReader(entries.Bytes())}, nil)
+			defer mockfs.AssertExpectations(m.T())
+
+			result, err := files[0].FetchEntries(&mockfs, false)
+			m.Require().NoError(err)
+			m.Len(result, len(manifestEntryV2Records))
+			m.Equal(manifestEntryV2Records[0].Data.Path, result[0].DataFile().FilePath())
+		})
+	}
+}
+
+func (m *ManifestTestSuite) TestReadManifestBlockLengths() {
+	// entries are decoded one Avro block at a time, whether each block
+	// holds a single entry or all of them
+	for _, blockLength := range []int{1, 2, 1000} {
+		var entries bytes.Buffer
+		enc, err := ocf.NewEncoder(internal.AvroSchemaCache.Get(internal.ManifestEntryV2Key).String(),
+			&entries, ocf.WithMetadata(map[string][]byte{"format-version": []byte("2")}),
+			ocf.WithBlockLength(blockLength))
+		m.Require().NoError(err)
+		for _, ent := range manifestEntryV2Records {
+			m.Require().NoError(enc.Encode(ent))
+		}
+		m.Require().NoError(enc.Close())
+
+		var mockfs internal.MockFS
+		mockfs.Test(m.T())
+		mockfs.On("Open", manifestFileRecordsV2[0].FilePath()).Return(&internal.MockFile{
+			Contents: bytes.NewReader(entries.Bytes())}, nil)
+
+		result, err := manifestFileRecordsV2[0].FetchEntries(&mockfs, false)
+		m.Require().NoError(err)
+		m.Require().Len(result, len(manifestEntryV2Records))
+		for i, ent := range manifestEntryV2Records {
+			m.Equal(ent.Data.Path, result[i].DataFile().FilePath(), "block length %d", blockLength)
+		}
+		mockfs.AssertExpectations(m.T())
+	}
+}
+
+func (m *ManifestTestSuite) TestManifestEntriesPartialStats() {
+	// some writers leave optional stats out of the manifest schema, or
+	// write them as empty maps
+	var sc map[string]any
+	m.Require().NoError(json.Unmarshal([]byte(
+		internal.AvroSchemaCache.Get(internal.ManifestEntryV2Key).String()), &sc))
+	for _, f := range sc["fields"].([]any) {
+		if f := f.(map[string]any); f["name"] == "data_file" {
+			dataFileType := f["type"].(map[string]any)
+			dataFileType["fields"] = slices.DeleteFunc(dataFileType["fields"].([]any), func(f any) bool {
+				switch f.(map[string]any)["name"] {
+				case "column_sizes", "null_value_counts", "upper_bounds":
+					return true
+				}
+				return false
+			})
+		}
+	}
+	partialSchema, err := json.Marshal(sc)
+	m.Require().NoError(err)
+
+	src := manifestEntryV2Records[0]
+	entry := manifestEntryV2{
+		EntryStatus: src.EntryStatus,
+		Snapshot:    src.Snapshot,
+		Data: dataFile{
+			Path:          src.Data.Path,
+			Format:        src.Data.Format,
+			PartitionData: src.Data.PartitionData,
+			RecordCount:   src.Data.RecordCount,
+			FileSize:      src.Data.FileSize,
+			ValCounts:     src.Data.ValCounts,
+			NaNCounts:     &[]colMap[int, int64]{},
+			LowerBounds:   src.Data.LowerBounds,
+		},
+	}
+
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoder(string(partialSchema), &buf, ocf.WithMetadata(map[string][]byte{
+		"format-version": []byte("2"),
+	}))
+	m.Require().NoError(err)
+	m.Require().NoError(enc.Encode(&entry))
+	m.Require().NoError(enc.Close())
+
+	manifest := manifestFileV2{Path: manifestFileRecordsV2[0].FilePath()}
+	fetch := func(contents []byte) DataFile {
+		var mockfs internal.MockFS
+		mockfs.Test(m.T())
+		mockfs.On("Open", manifest.FilePath()).Return(&internal.MockFile{
+			Contents: bytes.NewReader(contents)}, nil)
+		defer mockfs.AssertExpectations(m.T())
+
+		entries, err := manifest.FetchEntries(&mockfs, false)
+		m.Require().NoError(err)
+		m.Require().NotEmpty(entries)
+		return entries[0].DataFile()
+	}
+
+	full, partial := fetch(m.v2ManifestEntries.Bytes()), fetch(buf.Bytes())
+	m.Nil(partial.ColumnSizes())
+	m.Nil(partial.NullValueCounts())
+	m.Nil(partial.UpperBoundValues())
+	m.Empty(partial.NaNValueCounts())
+	m.Equal(full.LowerBoundValues(), partial.LowerBoundValues())
+	m.Equal(full.ValueCounts(), partial.ValueCounts())
+
+	schema := NewSchema(0, NestedField{ID: 2, Name: "pickup", Type: PrimitiveTypes.String})
+	tests := []struct {
+		expr                    BooleanExpression
+		fullStats, partialStats bool
+	}{
+		// the lower bound is present in both
+		{EqualTo(Reference("pickup"), "2019"), false, false},
+		{LessThan(Reference("pickup"), "2020"), false, false},
+		// missing upper bounds and null counts are unknown
+		{GreaterThan(Reference("pickup"), "2021"), false, true},
+		{IsNull(Reference("pickup")), false, true},
+		{EqualTo(Reference("pickup"), "2020-04-15"), true, true},
+	}
+
+	for _, tt := range tests {
+		eval, err := NewInclusiveMetricsEvaluator(schema, tt.expr, true, false)
+		m.Require().NoError(err)
+
+		result, err := eval(full)
+		m.Require().NoError(err)
+		m.Equal(tt.fullStats, result, tt.expr.String())
+
+		result, err = eval(partial)
+		m.Require().NoError(err)
+		m.Equal(tt.partialStats, result, tt.expr.String())
+	}
+}
+
+func (m *ManifestTestSuite) TestManifestEntriesEqualityDeletes() {
+	src := manifestEntryV2Records[0]
+	newEntry := func(path string, ids []int) *manifestEntryV2 {
+		return &manifestEntryV2{
+			EntryStatus: src.EntryStatus,
+			Snapshot:    src.Snapshot,
+			Data: dataFile{
+				Content:       EntryContentEqDeletes,
+				Path:          path,
+				Format:        src.Data.Format,
+				PartitionData: src.Data.PartitionData,
+				RecordCount:   3,
+				FileSize:      512,
+				EqualityIDs:   &ids,
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoder(internal.AvroSchemaCache.Get(internal.ManifestEntryV2Key).String(),
+		&buf, ocf.WithMetadata(map[string][]byte{"format-version": []byte("2")}))
+	m.Require().NoError(err)
+	m.Require().NoError(enc.Encode(newEntry("/deletes/by-id.parquet", []int{1})))
+	m.Require().NoError(enc.Encode(newEntry("/deletes/by-vendor-and-time.parquet", []int{2, 5})))
+	m.Require().NoError(enc.Close())
+
+	var mockfs internal.MockFS
+	manifest := manifestFileV2{Path: manifestFileRecordsV2[0].FilePath(), Content: ManifestContentDeletes}
+	mockfs.Test(m.T())
+	mockfs.On("Open", manifest.FilePath()).Return(&internal.MockFile{
+		Contents: bytes.NewReader(buf.Bytes())}, nil)
+	defer mockfs.AssertExpectations(m.T())
+
+	entries, err := manifest.FetchEntries(&mockfs, false)
+	m.Require().NoError(err)
+	m.Require().Len(entries, 2)
+
+	m.Equal(EntryContentEqDeletes, entries[0].DataFile().ContentType())
+	m.Equal([]int{1}, entries[0].DataFile().EqualityFieldIDs())
+	m.Equal(EntryContentEqDeletes, entries[1].DataFile().ContentType())
+	m.Equal([]int{2, 5}, entries[1].DataFile().EqualityFieldIDs())
+}
+
+// v3Schema returns the schema for key with the first_row_id field added
+// to the record with the given name, like the schemas of v3 writers, along
+// with any extra fields.
+func (m *ManifestTestSuite) v3Schema(key, record string, fieldID int, extra ...map[string]any) string {
+	var sc map[string]any
+	m.Require().NoError(json.Unmarshal([]byte(internal.AvroSchemaCache.Get(key).String()), &sc))
+
+	rec := sc
+	for _, f := range sc["fields"].([]any) {
+		if f := f.(map[string]any); f["name"] == record {
+			rec = f["type"].(map[string]any)
+		}
+	}
+	rec["fields"] = append(rec["fields"].([]any), map[string]any{
+		"name": "first_row_id", "type": []any{"null", "long"}, "field-id": fieldID})
+	for _, f := range extra {
+		rec["fields"] = append(rec["fields"].([]any), f)
+	}
+
+	out, err := json.Marshal(sc)
+	m.Require().NoError(err)
+	return string(out)
+}
+
+func (m *ManifestTestSuite) TestRowLineageV3() {
+	var list bytes.Buffer
+	enc, err := ocf.NewEncoder(m.v3Schema(internal.ManifestListV2Key, "", 520),
+		&list, ocf.WithMetadata(map[string][]byte{"format-version": []byte("3")}))
+	m.Require().NoError(err)
+	m.Require().NoError(enc.Encode(NewManifestV2Builder(manifestFileRecordsV2[0].FilePath(),
+		7989, 0, ManifestContentData, snapshotID).SequenceNum(3, 3).FirstRowID(1000).Build()))
+	m.Require().NoError(enc.Close())
+
+	manifests, err := ReadManifestList(&list)
+	m.Require().NoError(err)
+	m.Require().Len(manifests, 1)
+	m.Require().NotNil(manifests[0].FirstRowID())
+	m.EqualValues(1000, *manifests[0].FirstRowID())
+
+	src := manifestEntryV2Records[0]
+	newEntry := func(status ManifestEntryStatus, count int64, firstRowID *int64) *manifestEntryV2 {
+		return &manifestEntryV2{
+			EntryStatus: status,
+			Snapshot:    src.Snapshot,
+			Data: dataFile{
+				Path:          src.Data.Path,
+				Format:        src.Data.Format,
+				PartitionData: src.Data.PartitionData,
+				RecordCount:   count,
+				FileSize:      src.Data.FileSize,
+				FirstRow:      firstRowID,
+			},
+		}
+	}
+
+	explicit := int64(500)
+	var entries bytes.Buffer
+	enc, err = ocf.NewEncoder(m.v3Schema(internal.ManifestEntryV2Key, "data_file", 142),
+		&entries, ocf.WithMetadata(map[string][]byte{"format-version": []byte("3")}))
+	m.Require().NoError(err)
+	m.Require().NoError(enc.Encode(newEntry(EntryStatusADDED, 10, nil)))
+	m.Require().NoError(enc.Encode(newEntry(EntryStatusADDED, 5, &explicit)))
+	m.Require().NoError(enc.Encode(newEntry(EntryStatusDELETED, 7, nil)))
+	m.Require().NoError(enc.Encode(newEntry(EntryStatusEXISTING, 3, nil)))
+	m.Require().NoError(enc.Close())
+
+	var mockfs internal.MockFS
+	mockfs.Test(m.T())
+	mockfs.On("Open", manifests[0].FilePath()).Return(&internal.MockFile{
+		Contents: bytes.NewReader(entries.Bytes())}, nil)
+	defer mockfs.AssertExpectations(m.T())
+
+	fetched, err := manifests[0].FetchEntries(&mockfs, false)
+	m.Require().NoError(err)
+	m.Require().Len(fetched, 4)
+
+	// files without a first row ID inherit the next IDs of the manifest,
+	// skipping deleted files and keeping explicitly assigned IDs
+	m.EqualValues(1000, *fetched[0].DataFile().FirstRowID())
+	m.EqualValues(500, *fetched[1].DataFile().FirstRowID())
+	m.Nil(fetched[2].DataFile().FirstRowID())
+	m.EqualValues(1010, *fetched[3].DataFile().FirstRowID())
+
+	// manifests of tables without row lineage don't assign row IDs
+	var v2fs internal.MockFS
+	v2fs.Test(m.T())
+	v2fs.On("Open", manifestFileRecordsV2[0].FilePath()).Return(&internal.MockFile{
+		Contents: bytes.NewReader(m.v2ManifestEntries.Bytes())}, nil)
+	defer v2fs.AssertExpectations(m.T())
+
+	v2Entries, err := manifestFileRecordsV2[0].FetchEntries(&v2fs, false)
+	m.Require().NoError(err)
+	m.Nil(v2Entries[0].DataFile().FirstRowID())
+}
+
+func (m *ManifestTestSuite) TestDeletionVectorFieldsV3() {
+	src := manifestEntryV2Records[0]
+	dvPath := "s3://bucket/metadata/deletes.puffin"
+	offset, size := int64(4), int64(42)
+	entry := &manifestEntryV2{
+		EntryStatus: EntryStatusADDED,
+		Snapshot:    src.Snapshot,
+		Data: dataFile{
+			Content:          EntryContentPosDeletes,
+			Path:             dvPath,
+			Format:           PuffinFile,
+			PartitionData:    src.Data.PartitionData,
+			RecordCount:      2,
+			FileSize:         100,
+			ReferencedFile:   &src.Data.Path,
+			ContentOffsetPos: &offset,
+			ContentSize:      &size,
+		},
+	}
+
+	var entries bytes.Buffer
+	enc, err := ocf.NewEncoder(m.v3Schema(internal.ManifestEntryV2Key, "data_file", 142,
+		map[string]any{"name": "referenced_data_file", "type": []any{"null", "string"}, "field-id": 143},
+		map[string]any{"name": "content_offset", "type": []any{"null", "long"}, "field-id": 144},
+		map[string]any{"name": "content_size_in_bytes", "type": []any{"null", "long"}, "field-id": 145}),
+		&entries, ocf.WithMetadata(map[string][]byte{"format-version": []byte("3")}))
+	m.Require().NoError(err)
+	m.Require().NoError(enc.Encode(entry))
+	m.Require().NoError(enc.Close())
+
+	manifest := NewManifestV2Builder("s3://bucket/metadata/delete-m0.avro", 100, 0,
+		ManifestContentDeletes, snapshotID).Build()
+
+	var mockfs internal.MockFS
+	mockfs.Test(m.T())
+	mockfs.On("Open", manifest.FilePath()).Return(&internal.MockFile{
+		Contents: bytes.NewReader(entries.Bytes())}, nil)
+	defer mockfs.AssertExpectations(m.T())
+
+	fetched, err := manifest.FetchEntries(&mockfs, false)
+	m.Require().NoError(err)
+	m.Require().Len(fetched, 1)
+
+	df := fetched[0].DataFile()
+	m.Equal(PuffinFile, df.FileFormat())
+	m.Require().NotNil(df.ReferencedDataFile())
+	m.Equal(src.Data.Path, *df.ReferencedDataFile())
+	m.Require().NotNil(df.ContentOffset())
+	m.EqualValues(4, *df.ContentOffset())
+	m.Require().NotNil(df.ContentSizeInBytes())
+	m.EqualValues(42, *df.ContentSizeInBytes())
+
+	// the fields are absent from older manifests
+	v2 := manifestEntryV2Records[0].DataFile()
+	m.Nil(v2.ReferencedDataFile())
+	m.Nil(v2.ContentOffset())
+	m.Nil(v2.ContentSizeInBytes())
+}
+
 func (m *ManifestTestSuite) TestReadManifestListV1() {
 	list, err := ReadManifestList(&m.v1ManifestList)
 	m.Require().NoError(err)
@@ -767,6 +1192,302 @@ func (m *ManifestTestSuite) TestManifestEntriesV2() {
 	m.Zero(*datafile.SortOrderID())
 }
 
+func TestInclusiveMetricsEvaluator(t *testing.T) {
+	schema := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32, Required: true},
+		NestedField{ID: 2, Name: "no_stats", Type: PrimitiveTypes.Int32},
+		NestedField{ID: 3, Name: "required", Type: PrimitiveTypes.String, Required: true},
+		NestedField{ID: 4, Name: "all_nulls", Type: PrimitiveTypes.String},
+		NestedField{ID: 5, Name: "some_nulls", Type: PrimitiveTypes.String},
+		NestedField{ID: 6, Name: "no_nulls", Type: PrimitiveTypes.String},
+		NestedField{ID: 7, Name: "all_nans", Type: PrimitiveTypes.Float64},
+		NestedField{ID: 8, Name: "some_nans", Type: PrimitiveTypes.Float32},
+		NestedField{ID: 9, Name: "no_nans", Type: PrimitiveTypes.Float32},
+		NestedField{ID: 10, Name: "all_nulls_double", Type: PrimitiveTypes.Float64},
+		NestedField{ID: 11, Name: "all_nans_v1_stats", Type: PrimitiveTypes.Float32},
+		NestedField{ID: 12, Name: "nan_and_null_only", Type: PrimitiveTypes.Float64},
+		NestedField{ID: 13, Name: "no_nan_stats", Type: PrimitiveTypes.Float64},
+	)
+
+	int32Bound := func(v int32) []byte { return binary.LittleEndian.AppendUint32(nil, uint32(v)) }
+	float32NaN := binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(math.NaN())))
+	float64NaN := binary.LittleEndian.AppendUint64(nil, math.Float64bits(math.NaN()))
+
+	// ids are between 30 and 79, and the v1 stats of all_nans_v1_stats
+	// and nan_and_null_only have NaN bounds rather than NaN counts
+	file := &dataFile{
+		RecordCount: 50,
+		ValCounts: &[]colMap[int, int64]{
+			{Key: 4, Value: 50}, {Key: 5, Value: 50}, {Key: 6, Value: 50},
+			{Key: 7, Value: 50}, {Key: 8, Value: 50}, {Key: 9, Value: 50},
+			{Key: 10, Value: 50}, {Key: 11, Value: 50}, {Key: 12, Value: 50},
+			{Key: 13, Value: 50},
+		},
+		NullCounts: &[]colMap[int, int64]{
+			{Key: 4, Value: 50}, {Key: 5, Value: 10}, {Key: 6, Value: 0},
+			{Key: 10, Value: 50}, {Key: 11, Value: 0}, {Key: 12, Value: 1},
+		},
+		NaNCounts: &[]colMap[int, int64]{
+			{Key: 7, Value: 50}, {Key: 8, Value: 10}, {Key: 9, Value: 0},
+		},
+		LowerBounds: &[]colMap[int, []byte]{
+			{Key: 1, Value: int32Bound(30)}, {Key: 11, Value: float32NaN}, {Key: 12, Value: float64NaN},
+		},
+		UpperBounds: &[]colMap[int, []byte]{
+			{Key: 1, Value: int32Bound(79)}, {Key: 11, Value: float32NaN}, {Key: 12, Value: float64NaN},
+		},
+	}
+
+	tests := []struct {
+		expr     BooleanExpression
+		expected bool
+	}{
+		// missing statistics are unknown, so nothing can be pruned
+		{LessThan(Reference("no_stats"), int32(5)), true},
+		{LessThanEqual(Reference("no_stats"), int32(30)), true},
+		{EqualTo(Reference("no_stats"), int32(70)), true},
+		{GreaterThan(Reference("no_stats"), int32(78)), true},
+		{GreaterThanEqual(Reference("no_stats"), int32(90)), true},
+		{NotEqualTo(Reference("no_stats"), int32(101)), true},
+		{IsNull(Reference("no_stats")), true},
+		{NotNull(Reference("no_stats")), true},
+		{IsNaN(Reference("some_nans")), true},
+		{NotNaN(Reference("some_nans")), true},
+
+		// null counts
+		{NotNull(Reference("all_nulls")), false},
+		{NotNull(Reference("some_nulls")), true},
+		{NotNull(Reference("no_nulls")), true},
+		{IsNull(Reference("all_nulls")), true},
+		{IsNull(Reference("some_nulls")), true},
+		{IsNull(Reference("no_nulls")), false},
+		{IsNull(Reference("required")), false},
+		{NotNull(Reference("required")), true},
+
+		// comparisons can't match a column of only nulls
+		{LessThan(Reference("all_nulls"), "a"), false},
+		{LessThanEqual(Reference("all_nulls"), "a"), false},
+		{GreaterThan(Reference("all_nulls"), "a"), false},
+		{GreaterThanEqual(Reference("all_nulls"), "a"), false},
+		{EqualTo(Reference("all_nulls"), "a"), false},
+		{StartsWith(Reference("all_nulls"), "a"), false},
+		{IsIn(Reference("all_nulls"), "a", "b"), false},
+		{NotEqualTo(Reference("all_nulls"), "a"), true},
+		{NotStartsWith(Reference("all_nulls"), "a"), true},
+		{NotIn(Reference("all_nulls"), "a", "b"), true},
+		{LessThan(Reference("some_nulls"), "ggg"), true},
+		{GreaterThanEqual(Reference("some_nulls"), "ggg"), true},
+		{StartsWith(Reference("some_nulls"), "gg"), true},
+		{NotStartsWith(Reference("some_nulls"), "gg"), true},
+
+		// NaN counts
+		{IsNaN(Reference("all_nans")), true},
+		{IsNaN(Reference("some_nans")), true},
+		{IsNaN(Reference("no_nans")), false},
+		{IsNaN(Reference("all_nulls_double")), false},
+		{IsNaN(Reference("no_nan_stats")), true},
+		{IsNaN(Reference("all_nans_v1_stats")), true},
+		{IsNaN(Reference("nan_and_null_only")), true},
+		{NotNaN(Reference("all_nans")), false},
+		{NotNaN(Reference("some_nans")), true},
+		{NotNaN(Reference("no_nans")), true},
+		{NotNaN(Reference("all_nulls_double")), true},
+		{NotNaN(Reference("no_nan_stats")), true},
+		{NotNaN(Reference("all_nans_v1_stats")), true},
+		{NotNaN(Reference("nan_and_null_only")), true},
+
+		// comparisons can't match a column of only NaNs, and NaN bounds
+		// are not ordered so they can't prune
+		{LessThan(Reference("all_nans"), 1.0), false},
+		{GreaterThanEqual(Reference("all_nans"), 1.0), false},
+		{EqualTo(Reference("all_nans"), 1.0), false},
+		{IsIn(Reference("all_nans"), 1.0, 2.0), false},
+		{NotEqualTo(Reference("all_nans"), 1.0), true},
+		{LessThan(Reference("some_nans"), float32(1)), true},
+		{LessThan(Reference("all_nans_v1_stats"), float32(1)), true},
+		{GreaterThan(Reference("all_nans_v1_stats"), float32(1)), true},
+		{EqualTo(Reference("all_nans_v1_stats"), float32(1)), true},
+		{LessThan(Reference("nan_and_null_only"), 1.0), true},
+		{GreaterThanEqual(Reference("nan_and_null_only"), 1.0), true},
+
+		// bounds
+		{LessThan(Reference("id"), int32(5)), false},
+		{LessThan(Reference("id"), int32(30)), false},
+		{LessThan(Reference("id"), int32(31)), true},
+		{LessThan(Reference("id"), int32(79)), true},
+		{LessThanEqual(Reference("id"), int32(5)), false},
+		{LessThanEqual(Reference("id"), int32(29)), false},
+		{LessThanEqual(Reference("id"), int32(30)), true},
+		{LessThanEqual(Reference("id"), int32(79)), true},
+		{GreaterThan(Reference("id"), int32(85)), false},
+		{GreaterThan(Reference("id"), int32(79)), false},
+		{GreaterThan(Reference("id"), int32(78)), true},
+		{GreaterThan(Reference("id"), int32(75)), true},
+		{GreaterThanEqual(Reference("id"), int32(85)), false},
+		{GreaterThanEqual(Reference("id"), int32(80)), false},
+		{GreaterThanEqual(Reference("id"), int32(79)), true},
+		{GreaterThanEqual(Reference("id"), int32(75)), true},
+		{EqualTo(Reference("id"), int32(5)), false},
+		{EqualTo(Reference("id"), int32(29)), false},
+		{EqualTo(Reference("id"), int32(30)), true},
+		{EqualTo(Reference("id"), int32(75)), true},
+		{EqualTo(Reference("id"), int32(79)), true},
+		{EqualTo(Reference("id"), int32(80)), false},
+		{EqualTo(Reference("id"), int32(85)), false},
+		{NotEqualTo(Reference("id"), int32(5)), true},
+		{NotEqualTo(Reference("id"), int32(30)), true},
+		{NotEqualTo(Reference("id"), int32(85)), true},
+		{NewNot(EqualTo(Reference("id"), int32(5))), true},
+		{NewNot(LessThan(Reference("id"), int32(85))), false},
+		{NewNot(GreaterThan(Reference("id"), int32(5))), false},
+		{NewAnd(LessThan(Reference("id"), int32(5)), GreaterThanEqual(Reference("id"), int32(0))), false},
+		{NewAnd(GreaterThan(Reference("id"), int32(5)), LessThanEqual(Reference("id"), int32(30))), true},
+		{NewOr(LessThan(Reference("id"), int32(5)), GreaterThanEqual(Reference("id"), int32(80))), false},
+		{NewOr(LessThan(Reference("id"), int32(5)), GreaterThanEqual(Reference("id"), int32(60))), true},
+		{IsIn(Reference("id"), int32(5), int32(6)), false},
+		{IsIn(Reference("id"), int32(29), int32(30)), true},
+		{IsIn(Reference("id"), int32(75), int32(76)), true},
+		{IsIn(Reference("id"), int32(79), int32(80)), true},
+		{IsIn(Reference("id"), int32(80), int32(81)), false},
+		{NotIn(Reference("id"), int32(5), int32(6)), true},
+		{NotIn(Reference("id"), int32(30), int32(31)), true},
+		{NotIn(Reference("id"), int32(85), int32(86)), true},
+	}
+
+	for _, tt := range tests {
+		eval, err := NewInclusiveMetricsEvaluator(schema, tt.expr, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := eval(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != tt.expected {
+			t.Errorf("%s: expected %t, got %t", tt.expr, tt.expected, result)
+		}
+	}
+
+	// counts and bounds of columns in the file can be referenced
+	// ignoring the case of their names
+	eval, err := NewInclusiveMetricsEvaluator(schema, EqualTo(Reference("ID"), int32(5)), false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := eval(file); err != nil || result {
+		t.Errorf("case insensitive ID = 5: expected false, got %t (%v)", result, err)
+	}
+
+	if _, err := NewInclusiveMetricsEvaluator(schema, EqualTo(Reference("missing"), int32(5)), true, false); err == nil {
+		t.Error("expected an error binding a missing column")
+	}
+
+	// a file without records has no rows to match, unless empty files
+	// are included
+	empty := &dataFile{RecordCount: 0}
+	for _, includeEmpty := range []bool{false, true} {
+		eval, err := NewInclusiveMetricsEvaluator(schema, NotNull(Reference("some_nulls")), true, includeEmpty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result, err := eval(empty); err != nil || result != includeEmpty {
+			t.Errorf("empty file with includeEmptyFiles=%t: got %t (%v)", includeEmpty, result, err)
+		}
+	}
+}
+
+func TestInclusiveMetricsEvaluatorLargeInSet(t *testing.T) {
+	schema := NewSchema(0, NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true})
+
+	values := make([]int64, 10000)
+	for i := range values {
+		values[i] = int64(len(values)-i) * 10
+	}
+
+	eval, err := NewInclusiveMetricsEvaluator(schema, IsIn(Reference("id"), values...), true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bounds := func(v int64) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(v)) }
+	tests := []struct {
+		lower, upper int64
+		expected     bool
+	}{
+		{0, 9, false},
+		{0, 10, true},
+		{11, 19, false},
+		{55, 65, true},
+		{100000, 200000, true},
+		{100001, 200000, false},
+	}
+
+	for _, tt := range tests {
+		result, err := eval(&dataFile{
+			RecordCount: 10,
+			LowerBounds: &[]colMap[int, []byte]{{Key: 1, Value: bounds(tt.lower)}},
+			UpperBounds: &[]colMap[int, []byte]{{Key: 1, Value: bounds(tt.upper)}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != tt.expected {
+			t.Errorf("bounds [%d, %d]: expected %t, got %t", tt.lower, tt.upper, tt.expected, result)
+		}
+	}
+}
+
+func TestInclusiveMetricsEvaluatorTruncatedStartsWith(t *testing.T) {
+	schema := NewSchema(0, NestedField{ID: 1, Name: "s", Type: PrimitiveTypes.String, Required: true})
+
+	// the values of the file are between "abcde" and "abzzz", and the
+	// bounds are truncated to two characters, with the upper bound
+	// incremented so it stays above every value
+	file := &dataFile{
+		RecordCount: 10,
+		NullCounts:  &[]colMap[int, int64]{{Key: 1, Value: 0}},
+		LowerBounds: &[]colMap[int, []byte]{{Key: 1, Value: []byte("ab")}},
+		UpperBounds: &[]colMap[int, []byte]{{Key: 1, Value: []byte("ac")}},
+	}
+
+	tests := []struct {
+		expr     BooleanExpression
+		expected bool
+	}{
+		// prefixes longer than the bounds can't be excluded by them
+		{StartsWith(Reference("s"), "abc"), true},
+		{StartsWith(Reference("s"), "abz"), true},
+		{StartsWith(Reference("s"), "ab"), true},
+		{StartsWith(Reference("s"), "ac"), true},
+		{StartsWith(Reference("s"), "acz"), false},
+		{StartsWith(Reference("s"), "aa"), false},
+		{StartsWith(Reference("s"), "aaz"), false},
+		{StartsWith(Reference("s"), "ad"), false},
+		{StartsWith(Reference("s"), "b"), false},
+		// only prefixes shared by both bounds prove every value has them
+		{NotStartsWith(Reference("s"), "a"), false},
+		{NotStartsWith(Reference("s"), "ab"), true},
+		{NotStartsWith(Reference("s"), "abc"), true},
+		{NotStartsWith(Reference("s"), "b"), true},
+	}
+
+	for _, tt := range tests {
+		eval, err := NewInclusiveMetricsEvaluator(schema, tt.expr, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := eval(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != tt.expected {
+			t.Errorf("%s: expected %t, got %t", tt.expr, tt.expected, result)
+		}
+	}
+}
+
 func TestManifests(t *testing.T) {
 	suite.Run(t, new(ManifestTestSuite))
 }
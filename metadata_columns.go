@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import "math"
+
+// Reserved field IDs for the metadata columns defined by the Iceberg
+// spec. These columns are not stored in data files, but are instead
+// materialized by readers when they are requested by a projection.
+//
+// https://iceberg.apache.org/spec/#reserved-field-ids
+const (
+	FilePathFieldID  = math.MaxInt32 - 1
+	RowPosFieldID    = math.MaxInt32 - 2
+	IsDeletedFieldID = math.MaxInt32 - 3
+	SpecIDFieldID    = math.MaxInt32 - 4
+	PartitionFieldID = math.MaxInt32 - 5
+
+	RowIDFieldID                     = math.MaxInt32 - 107
+	LastUpdatedSequenceNumberFieldID = math.MaxInt32 - 108
+)
+
+var (
+	// MetadataColumnFilePath is the "_file" metadata column containing
+	// the location of the data file a row was read from.
+	MetadataColumnFilePath = NestedField{
+		ID: FilePathFieldID, Name: "_file", Type: PrimitiveTypes.String,
+		Required: true, Doc: "Path of the file in which a row is stored"}
+	// MetadataColumnRowPos is the "_pos" metadata column containing the
+	// ordinal position of a row within its source data file. This is the
+	// position in the file, regardless of any deletes which are applied.
+	MetadataColumnRowPos = NestedField{
+		ID: RowPosFieldID, Name: "_pos", Type: PrimitiveTypes.Int64,
+		Required: true, Doc: "Ordinal position of a row in the source data file"}
+	// MetadataColumnIsDeleted is the "_deleted" metadata column which
+	// indicates whether a row has been deleted by a delete file.
+	MetadataColumnIsDeleted = NestedField{
+		ID: IsDeletedFieldID, Name: "_deleted", Type: PrimitiveTypes.Bool,
+		Required: true, Doc: "Whether the row has been deleted"}
+	// MetadataColumnSpecID is the "_spec_id" metadata column containing
+	// the ID of the partition spec used to write the row's data file.
+	MetadataColumnSpecID = NestedField{
+		ID: SpecIDFieldID, Name: "_spec_id", Type: PrimitiveTypes.Int32,
+		Required: true, Doc: "Spec ID used to track the file containing a row"}
+	// MetadataColumnRowID is the "_row_id" row lineage column of v3
+	// tables. Rows without a row ID stored in the data file are assigned
+	// the first row ID of their data file plus their position in it.
+	MetadataColumnRowID = NestedField{
+		ID: RowIDFieldID, Name: "_row_id", Type: PrimitiveTypes.Int64,
+		Required: false, Doc: "Implicit row ID that is automatically assigned"}
+	// MetadataColumnLastUpdatedSequenceNumber is the
+	// "_last_updated_sequence_number" row lineage column of v3 tables,
+	// containing the sequence number of the commit that last changed a row.
+	MetadataColumnLastUpdatedSequenceNumber = NestedField{
+		ID: LastUpdatedSequenceNumberFieldID, Name: "_last_updated_sequence_number",
+		Type: PrimitiveTypes.Int64, Required: false,
+		Doc: "Sequence number when the row was last updated"}
+)
+
+const partitionColumnName = "_partition"
+
+// MetadataColumnPartition returns the "_partition" metadata column for
+// the given partition type. Unlike the other metadata columns, the type
+// of the partition column depends on the table's partition specs, so it
+// must be constructed from the (unified) partition struct type.
+func MetadataColumnPartition(partitionType *StructType) NestedField {
+	return NestedField{
+		ID: PartitionFieldID, Name: partitionColumnName, Type: partitionType,
+		Required: false, Doc: "Partition to which a row belongs to",
+	}
+}
+
+var metadataColumnsByName = map[string]NestedField{
+	MetadataColumnFilePath.Name:  MetadataColumnFilePath,
+	MetadataColumnRowPos.Name:    MetadataColumnRowPos,
+	MetadataColumnIsDeleted.Name: MetadataColumnIsDeleted,
+	MetadataColumnSpecID.Name:    MetadataColumnSpecID,
+
+	MetadataColumnRowID.Name:                     MetadataColumnRowID,
+	MetadataColumnLastUpdatedSequenceNumber.Name: MetadataColumnLastUpdatedSequenceNumber,
+}
+
+// IsMetadataColumn returns true if the given name is the name of one
+// of the reserved metadata columns, such as "_file" or "_pos".
+func IsMetadataColumn(name string) bool {
+	if name == partitionColumnName {
+		return true
+	}
+	_, ok := metadataColumnsByName[name]
+	return ok
+}
+
+// IsMetadataColumnID returns true if the given field ID is reserved for
+// one of the metadata columns.
+func IsMetadataColumnID(id int) bool {
+	switch id {
+	case FilePathFieldID, RowPosFieldID, IsDeletedFieldID,
+		SpecIDFieldID, PartitionFieldID, RowIDFieldID,
+		LastUpdatedSequenceNumberFieldID:
+		return true
+	}
+	return false
+}
+
+// MetadataColumnByName returns the metadata column with the given name.
+// The "_partition" column is not returned by this function as its type
+// depends on the table, use [MetadataColumnPartition] instead.
+func MetadataColumnByName(name string) (NestedField, bool) {
+	f, ok := metadataColumnsByName[name]
+	return f, ok
+}
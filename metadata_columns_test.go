@@ -0,0 +1,77 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+		typ  iceberg.Type
+	}{
+		{"_file", 2147483646, iceberg.PrimitiveTypes.String},
+		{"_pos", 2147483645, iceberg.PrimitiveTypes.Int64},
+		{"_deleted", 2147483644, iceberg.PrimitiveTypes.Bool},
+		{"_spec_id", 2147483643, iceberg.PrimitiveTypes.Int32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, iceberg.IsMetadataColumn(tt.name))
+			assert.True(t, iceberg.IsMetadataColumnID(tt.id))
+
+			f, ok := iceberg.MetadataColumnByName(tt.name)
+			assert.True(t, ok)
+			assert.Equal(t, tt.id, f.ID)
+			assert.Equal(t, tt.typ, f.Type)
+			assert.True(t, f.Required)
+		})
+	}
+
+	// the row lineage columns are optional, as rows may not have lineage
+	for name, id := range map[string]int{"_row_id": 2147483540, "_last_updated_sequence_number": 2147483539} {
+		f, ok := iceberg.MetadataColumnByName(name)
+		assert.True(t, ok)
+		assert.Equal(t, id, f.ID)
+		assert.Equal(t, iceberg.PrimitiveTypes.Int64, f.Type)
+		assert.False(t, f.Required)
+		assert.True(t, iceberg.IsMetadataColumnID(id))
+	}
+
+	partType := &iceberg.StructType{FieldList: []iceberg.NestedField{
+		{ID: 1000, Name: "x", Type: iceberg.PrimitiveTypes.Int32},
+	}}
+	part := iceberg.MetadataColumnPartition(partType)
+	assert.Equal(t, 2147483642, part.ID)
+	assert.Equal(t, "_partition", part.Name)
+	assert.Same(t, partType, part.Type)
+	assert.False(t, part.Required)
+	assert.True(t, iceberg.IsMetadataColumn("_partition"))
+	assert.True(t, iceberg.IsMetadataColumnID(part.ID))
+
+	_, ok := iceberg.MetadataColumnByName("_partition")
+	assert.False(t, ok)
+	assert.False(t, iceberg.IsMetadataColumn("x"))
+	assert.False(t, iceberg.IsMetadataColumnID(1))
+}
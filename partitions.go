@@ -18,10 +18,18 @@
 package iceberg
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/apache/arrow/go/v16/arrow/decimal128"
+	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
 )
 
@@ -48,6 +56,17 @@ type PartitionField struct {
 	Transform Transform `json:"transform"`
 }
 
+// MarshalJSON writes the fields in the same order as the Java
+// implementation.
+func (p PartitionField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name      string    `json:"name"`
+		Transform Transform `json:"transform"`
+		SourceID  int       `json:"source-id"`
+		FieldID   int       `json:"field-id"`
+	}{p.Name, p.Transform, p.SourceID, p.FieldID})
+}
+
 func (p *PartitionField) String() string {
 	return fmt.Sprintf("%d: %s: %s(%d)", p.FieldID, p.Name, p.Transform, p.SourceID)
 }
@@ -230,3 +249,413 @@ func (ps *PartitionSpec) PartitionType(schema *Schema) *StructType {
 	}
 	return &StructType{FieldList: nestedFields}
 }
+
+// ValidatePartitionData checks that the partition tuple of a data file, keyed
+// by partition field name as returned by DataFile.Partition, matches this
+// spec for the given schema. The tuple must contain exactly the fields of
+// the spec and each non-null value must hold the spec's result type, either
+// as the Go type of its literals or as it's decoded from a manifest, so that
+// files written with a mismatched tuple can be rejected before they are
+// committed to the table.
+func (ps *PartitionSpec) ValidatePartitionData(schema *Schema, data map[string]any) error {
+	if len(data) != len(ps.fields) {
+		return fmt.Errorf("%w: partition data has %d fields, but spec %d has %d",
+			ErrInvalidArgument, len(data), ps.id, len(ps.fields))
+	}
+
+	for _, field := range ps.fields {
+		val, ok := data[field.Name]
+		if !ok {
+			return fmt.Errorf("%w: partition data is missing field '%s' of spec %d",
+				ErrInvalidArgument, field.Name, ps.id)
+		}
+
+		sourceType, ok := schema.FindTypeByID(field.SourceID)
+		if !ok {
+			return fmt.Errorf("%w: cannot find source column %d for partition field '%s'",
+				ErrInvalidSchema, field.SourceID, field.Name)
+		}
+
+		if val == nil {
+			continue
+		}
+
+		if _, err := normalizePartitionValue(field.Transform.ResultType(sourceType), val); err != nil {
+			return fmt.Errorf("partition field '%s': %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// normalizePartitionValue returns the value of a partition field of the
+// given result type as the Go type used for its literals, such as int32 or
+// Date. Besides those types, it accepts the representations values are
+// decoded from manifests as: int for int and date fields, time.Time for
+// dates and timestamps, time.Duration for times, *big.Rat for decimals,
+// byte arrays for fixed and strings for UUIDs, as well as the physical
+// types used to write them. Values of optional fields decoded as a single
+// entry union map, such as {"int": 1}, are unwrapped first. An error
+// wrapping ErrType is returned for any other value.
+func normalizePartitionValue(typ Type, val any) (any, error) {
+	if union, ok := val.(map[string]any); ok && len(union) == 1 {
+		for _, v := range union {
+			val = v
+		}
+	}
+
+	switch typ.(type) {
+	case BooleanType:
+		if v, ok := val.(bool); ok {
+			return v, nil
+		}
+	case Int32Type:
+		switch v := val.(type) {
+		case int32:
+			return v, nil
+		case int:
+			if v >= math.MinInt32 && v <= math.MaxInt32 {
+				return int32(v), nil
+			}
+		}
+	case Int64Type:
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case int:
+			return int64(v), nil
+		}
+	case Float32Type:
+		if v, ok := val.(float32); ok {
+			return v, nil
+		}
+	case Float64Type:
+		if v, ok := val.(float64); ok {
+			return v, nil
+		}
+	case DateType:
+		switch v := val.(type) {
+		case Date:
+			return v, nil
+		case int32:
+			return Date(v), nil
+		case int:
+			if v >= math.MinInt32 && v <= math.MaxInt32 {
+				return Date(v), nil
+			}
+		case time.Time:
+			days := v.Unix() / 86400
+			if v.Unix() < 0 && v.Unix()%86400 != 0 {
+				days--
+			}
+			return Date(days), nil
+		}
+	case TimeType:
+		switch v := val.(type) {
+		case Time:
+			return v, nil
+		case int64:
+			return Time(v), nil
+		case time.Duration:
+			return Time(v.Microseconds()), nil
+		}
+	case TimestampType, TimestampTzType:
+		switch v := val.(type) {
+		case Timestamp:
+			return v, nil
+		case int64:
+			return Timestamp(v), nil
+		case time.Time:
+			return Timestamp(v.UnixMicro()), nil
+		}
+	case TimestampNsType, TimestampTzNsType:
+		switch v := val.(type) {
+		case TimestampNano:
+			return v, nil
+		case int64:
+			return TimestampNano(v), nil
+		case time.Time:
+			return TimestampNano(v.UnixNano()), nil
+		}
+	case StringType:
+		if v, ok := val.(string); ok {
+			return v, nil
+		}
+	case BinaryType:
+		if v, ok := val.([]byte); ok {
+			return v, nil
+		}
+	case FixedType:
+		b, ok := val.([]byte)
+		if rv := reflect.ValueOf(val); !ok && rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			b, ok = make([]byte, rv.Len()), true
+			reflect.Copy(reflect.ValueOf(b), rv)
+		}
+		if ok && len(b) == typ.(FixedType).Len() {
+			return b, nil
+		}
+	case UUIDType:
+		switch v := val.(type) {
+		case uuid.UUID:
+			return v, nil
+		case []byte:
+			if id, err := uuid.FromBytes(v); err == nil {
+				return id, nil
+			}
+		case string:
+			if id, err := uuid.Parse(v); err == nil {
+				return id, nil
+			}
+		}
+	case DecimalType:
+		scale := typ.(DecimalType).Scale()
+		switch v := val.(type) {
+		case Decimal:
+			return v, nil
+		case []byte:
+			if lit, err := LiteralFromBytes(typ, v); err == nil {
+				return Decimal(lit.(DecimalLiteral)), nil
+			}
+		case *big.Rat:
+			unscaled := new(big.Rat).Mul(v, new(big.Rat).SetInt(
+				new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)))
+			if unscaled.IsInt() && unscaled.Num().BitLen() < 128 {
+				return Decimal{Val: decimal128.FromBigInt(unscaled.Num()), Scale: scale}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: partition type %s cannot hold Go value %v of type %T",
+		ErrType, typ, val, val)
+}
+
+// HiveDefaultPartition is the directory value Hive uses for a null
+// partition value.
+const HiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// ParsePartitionFromPath extracts the partition tuple of a data file from
+// the Hive-style "name=value" directories in its path, such as
+// "s3://bucket/tbl/year=2023/month=01/data.parquet", for importing files
+// into a table. The result is keyed by partition field name like
+// DataFile.Partition.
+//
+// Only identity partitions are supported as other transforms can't be
+// reversed from the value in the path. Each partition field is matched to
+// a directory by its name or the name of its source column. Values are
+// unescaped and cast to the source column's type, and the Hive default
+// partition is treated as null. Directories that don't match a partition
+// field are ignored.
+func ParsePartitionFromPath(spec *PartitionSpec, schema *Schema, path string) (map[string]any, error) {
+	segments := strings.Split(path, "/")
+	dirs := make(map[string]string)
+	// the last segment is the file name
+	for _, seg := range segments[:len(segments)-1] {
+		name, val, ok := strings.Cut(seg, "=")
+		if !ok {
+			continue
+		}
+
+		var err error
+		if name, err = url.PathUnescape(name); err != nil {
+			return nil, fmt.Errorf("%w: invalid partition directory '%s': %s",
+				ErrInvalidArgument, seg, err)
+		}
+		if dirs[name], err = url.PathUnescape(val); err != nil {
+			return nil, fmt.Errorf("%w: invalid partition directory '%s': %s",
+				ErrInvalidArgument, seg, err)
+		}
+	}
+
+	out := make(map[string]any, len(spec.fields))
+	for _, field := range spec.fields {
+		if _, ok := field.Transform.(IdentityTransform); !ok {
+			return nil, fmt.Errorf("%w: cannot parse %s partition field '%s' from a path, only identity partitions are supported",
+				ErrNotImplemented, field.Transform, field.Name)
+		}
+
+		srcName, ok := schema.FindColumnName(field.SourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot find source column %d for partition field '%s'",
+				ErrInvalidSchema, field.SourceID, field.Name)
+		}
+		srcType, _ := schema.FindTypeByID(field.SourceID)
+
+		val, ok := dirs[field.Name]
+		if !ok {
+			if val, ok = dirs[srcName]; !ok {
+				return nil, fmt.Errorf("%w: path '%s' has no directory for partition field '%s'",
+					ErrInvalidArgument, path, field.Name)
+			}
+		}
+
+		if val == HiveDefaultPartition {
+			out[field.Name] = nil
+			continue
+		}
+
+		switch srcType.(type) {
+		case TimestampType, TimestampNsType:
+			// Hive writes timestamps with a space between date and time
+			val = strings.Replace(val, " ", "T", 1)
+		}
+
+		lit, err := StringLiteral(val).To(srcType)
+		if err != nil {
+			return nil, fmt.Errorf("partition field '%s': %w", field.Name, err)
+		}
+
+		if out[field.Name], err = literalValue(lit); err != nil {
+			return nil, fmt.Errorf("partition field '%s': %w", field.Name, err)
+		}
+	}
+
+	return out, nil
+}
+
+// literalValue returns the Go value of a literal, as used for partition
+// values, or an error if the literal is out of range for its type.
+func literalValue(lit Literal) (any, error) {
+	switch l := lit.(type) {
+	case BoolLiteral:
+		return bool(l), nil
+	case Int32Literal:
+		return int32(l), nil
+	case Int64Literal:
+		return int64(l), nil
+	case Float32Literal:
+		return float32(l), nil
+	case Float64Literal:
+		return float64(l), nil
+	case DateLiteral:
+		return Date(l), nil
+	case TimeLiteral:
+		return Time(l), nil
+	case TimestampLiteral:
+		return Timestamp(l), nil
+	case TimestampTzLiteral:
+		return Timestamp(l), nil
+	case TimestampNanoLiteral:
+		return TimestampNano(l), nil
+	case TimestampTzNanoLiteral:
+		return TimestampNano(l), nil
+	case StringLiteral:
+		return string(l), nil
+	case BinaryLiteral:
+		return []byte(l), nil
+	case FixedLiteral:
+		return []byte(l), nil
+	case UUIDLiteral:
+		return uuid.UUID(l), nil
+	case DecimalLiteral:
+		return Decimal(l), nil
+	}
+	return nil, fmt.Errorf("%w: value %s is out of range for %s", ErrBadCast, lit, lit.Type())
+}
+
+// PartitionToPath returns the Hive-style path of a partition tuple, keyed
+// by partition field name like DataFile.Partition, such as
+// "ts_day=2017-11-16/region=eu". Values are formatted the same way as the
+// Java implementation's human readable partition strings, and both names
+// and values are URL encoded, so that data files are laid out the same way
+// by every engine.
+func (ps *PartitionSpec) PartitionToPath(schema *Schema, data map[string]any) (string, error) {
+	segments := make([]string, 0, len(ps.fields))
+	for _, field := range ps.fields {
+		val, ok := data[field.Name]
+		if !ok {
+			return "", fmt.Errorf("%w: partition data is missing field '%s' of spec %d",
+				ErrInvalidArgument, field.Name, ps.id)
+		}
+
+		sourceType, ok := schema.FindTypeByID(field.SourceID)
+		if !ok {
+			return "", fmt.Errorf("%w: cannot find source column %d for partition field '%s'",
+				ErrInvalidSchema, field.SourceID, field.Name)
+		}
+
+		human, err := partitionHumanString(field.Transform, sourceType, val)
+		if err != nil {
+			return "", fmt.Errorf("partition field '%s': %w", field.Name, err)
+		}
+		segments = append(segments, url.QueryEscape(field.Name)+"="+url.QueryEscape(human))
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// partitionHumanString formats a partition value produced by the transform
+// like Java's Transform.toHumanString. The value may be given in any of the
+// representations accepted by normalizePartitionValue for the transform's
+// result type.
+func partitionHumanString(t Transform, sourceType Type, val any) (string, error) {
+	if val == nil {
+		return "null", nil
+	}
+
+	val, err := normalizePartitionValue(t.ResultType(sourceType), val)
+	if err != nil {
+		return "", err
+	}
+
+	switch t.(type) {
+	case YearTransform:
+		return fmt.Sprintf("%04d", 1970+val.(int32)), nil
+	case MonthTransform:
+		m := int(val.(int32))
+		years, months := m/12, m%12
+		if months < 0 {
+			years, months = years-1, months+12
+		}
+		return fmt.Sprintf("%04d-%02d", 1970+years, months+1), nil
+	case HourTransform:
+		return time.Unix(int64(val.(int32))*3600, 0).UTC().Format("2006-01-02-15"), nil
+	}
+
+	switch v := val.(type) {
+	case Date:
+		return DateLiteral(v).String(), nil
+	case Time:
+		return javaLocalTime(time.UnixMicro(int64(v)).UTC()), nil
+	case Timestamp:
+		return javaLocalDateTime(time.UnixMicro(int64(v)).UTC(), sourceType), nil
+	case TimestampNano:
+		return javaLocalDateTime(time.Unix(0, int64(v)).UTC(), sourceType), nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	case Decimal:
+		return v.Val.ToString(int32(v.Scale)), nil
+	}
+	return fmt.Sprint(val), nil
+}
+
+// javaLocalDateTime formats a timestamp like Java's LocalDateTime, or
+// OffsetDateTime in UTC for types with a timezone.
+func javaLocalDateTime(tm time.Time, typ Type) string {
+	s := tm.Format("2006-01-02") + "T" + javaLocalTime(tm)
+	switch typ.(type) {
+	case TimestampTzType, TimestampTzNsType:
+		s += "Z"
+	}
+	return s
+}
+
+// javaLocalTime formats a time of day like Java's LocalTime, which omits
+// the seconds and fraction when they are zero.
+func javaLocalTime(tm time.Time) string {
+	s := tm.Format("15:04")
+	sec, nanos := tm.Second(), tm.Nanosecond()
+	if sec == 0 && nanos == 0 {
+		return s
+	}
+
+	s += fmt.Sprintf(":%02d", sec)
+	switch {
+	case nanos == 0:
+	case nanos%1e6 == 0:
+		s += fmt.Sprintf(".%03d", nanos/1e6)
+	case nanos%1e3 == 0:
+		s += fmt.Sprintf(".%06d", nanos/1e3)
+	default:
+		s += fmt.Sprintf(".%09d", nanos)
+	}
+	return s
+}
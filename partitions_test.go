@@ -19,11 +19,15 @@ package iceberg_test
 
 import (
 	"encoding/json"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v16/arrow/decimal128"
 	"github.com/apache/iceberg-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 )
 
 func TestPartitionSpec(t *testing.T) {
@@ -141,3 +145,237 @@ func TestPartitionType(t *testing.T) {
 	actual := spec.PartitionType(tableSchemaSimple)
 	assert.Truef(t, expected.Equals(actual), "expected: %s, got: %s", expected, actual)
 }
+
+func TestValidatePartitionData(t *testing.T) {
+	spec := iceberg.NewPartitionSpecID(3,
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000,
+			Transform: iceberg.TruncateTransform{Width: 19}, Name: "str_truncate"},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001,
+			Transform: iceberg.BucketTransform{NumBuckets: 25}, Name: "int_bucket"},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002,
+			Transform: iceberg.IdentityTransform{}, Name: "bool_identity"},
+	)
+
+	assert.NoError(t, spec.ValidatePartitionData(tableSchemaSimple, map[string]any{
+		"str_truncate": "foo", "int_bucket": int32(3), "bool_identity": true}))
+	assert.NoError(t, spec.ValidatePartitionData(tableSchemaSimple, map[string]any{
+		"str_truncate": nil, "int_bucket": int32(3), "bool_identity": nil}))
+
+	err := spec.ValidatePartitionData(tableSchemaSimple, map[string]any{
+		"str_truncate": "foo", "int_bucket": int64(3), "bool_identity": true})
+	assert.ErrorIs(t, err, iceberg.ErrType)
+	assert.ErrorContains(t, err, "int_bucket")
+
+	err = spec.ValidatePartitionData(tableSchemaSimple, map[string]any{
+		"str_truncate": "foo", "int_bucket": int32(3)})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	err = spec.ValidatePartitionData(tableSchemaSimple, map[string]any{
+		"str_truncate": "foo", "int_bucket": int32(3), "bool": true})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "bool_identity")
+
+	unpartitioned := iceberg.NewPartitionSpec()
+	assert.NoError(t, unpartitioned.ValidatePartitionData(tableSchemaSimple, map[string]any{}))
+}
+
+func TestValidatePartitionDataDecoded(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+		iceberg.NestedField{ID: 3, Name: "amount", Type: iceberg.DecimalTypeOf(10, 2)},
+		iceberg.NestedField{ID: 4, Name: "key", Type: iceberg.FixedTypeOf(4)},
+		iceberg.NestedField{ID: 5, Name: "uuid", Type: iceberg.PrimitiveTypes.UUID},
+		iceberg.NestedField{ID: 6, Name: "time", Type: iceberg.PrimitiveTypes.Time},
+	)
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1002, Name: "ts", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1003, Name: "amount", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1004, Name: "key", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 5, FieldID: 1005, Name: "uuid", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 6, FieldID: 1006, Name: "time", Transform: iceberg.IdentityTransform{}},
+	)
+
+	// the values as they're decoded from a manifest
+	decoded := map[string]any{
+		"id":     int(7),
+		"ts_day": time.Date(2017, 11, 16, 0, 0, 0, 0, time.UTC),
+		"ts":     time.Date(2017, 11, 16, 22, 31, 8, 0, time.UTC),
+		"amount": big.NewRat(1050, 100),
+		"key":    [4]byte{1, 2, 3, 4},
+		"uuid":   "f79c3e09-677c-4bbd-a479-3f349cb785e7",
+		"time":   22*time.Hour + 31*time.Minute,
+	}
+	assert.NoError(t, spec.ValidatePartitionData(sc, decoded))
+
+	for field, val := range map[string]any{
+		"id":     int64(7),
+		"ts_day": "2017-11-16",
+		"amount": big.NewRat(1, 1000),
+		"key":    [3]byte{1, 2, 3},
+		"uuid":   "not a uuid",
+	} {
+		invalid := maps.Clone(decoded)
+		invalid[field] = val
+		err := spec.ValidatePartitionData(sc, invalid)
+		assert.ErrorIs(t, err, iceberg.ErrType, field)
+		assert.ErrorContains(t, err, "'"+field+"'", field)
+	}
+
+	invalid := maps.Clone(decoded)
+	invalid["id"] = int64(7)
+	assert.ErrorContains(t, spec.ValidatePartitionData(sc, invalid),
+		"partition field 'id': type error: partition type int cannot hold Go value 7 of type int64")
+}
+
+func TestParsePartitionFromPath(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "year", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 2, Name: "region", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "day", Type: iceberg.PrimitiveTypes.Date},
+		iceberg.NestedField{ID: 4, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+	)
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "year", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "region_part", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "day", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1003, Name: "ts", Transform: iceberg.IdentityTransform{}},
+	)
+
+	got, err := iceberg.ParsePartitionFromPath(&spec, schema,
+		"s3://bucket/warehouse/tbl/year=2023/region=us%2Feast/day=2023-01-02/ts=2023-01-02%2010%3A30%3A00/part-0.parquet")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"year":        int32(2023),
+		"region_part": "us/east",
+		"day":         iceberg.Date(19359),
+		"ts":          iceberg.Timestamp(1672655400000000),
+	}, got)
+	assert.NoError(t, spec.ValidatePartitionData(schema, got))
+
+	got, err = iceberg.ParsePartitionFromPath(&spec, schema,
+		"/tbl/year=__HIVE_DEFAULT_PARTITION__/region=eu/day=2023-01-02/ts=__HIVE_DEFAULT_PARTITION__/part-0.parquet")
+	require.NoError(t, err)
+	assert.Nil(t, got["year"])
+	assert.Nil(t, got["ts"])
+	assert.Equal(t, "eu", got["region_part"])
+
+	_, err = iceberg.ParsePartitionFromPath(&spec, schema, "/tbl/year=2023/region=eu/part-0.parquet")
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "partition field 'day'")
+
+	_, err = iceberg.ParsePartitionFromPath(&spec, schema,
+		"/tbl/year=twenty/region=eu/day=2023-01-02/ts=2023-01-02 10:30:00/part-0.parquet")
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+
+	_, err = iceberg.ParsePartitionFromPath(&spec, schema,
+		"/tbl/year=9999999999/region=eu/day=2023-01-02/ts=2023-01-02 10:30:00/part-0.parquet")
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+
+	bucketed := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "year_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4}})
+	_, err = iceberg.ParsePartitionFromPath(&bucketed, schema, "/tbl/year_bucket=1/part-0.parquet")
+	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
+}
+
+func TestPartitionToPath(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+		iceberg.NestedField{ID: 4, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 5, Name: "amount", Type: iceberg.DecimalTypeOf(10, 2)},
+	)
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "name", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1001, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 8}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "ts_year", Transform: iceberg.YearTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1003, Name: "ts_month", Transform: iceberg.MonthTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1004, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1005, Name: "ts_hour", Transform: iceberg.HourTransform{}},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1006, Name: "tstz", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 5, FieldID: 1007, Name: "amount", Transform: iceberg.TruncateTransform{Width: 50}},
+	)
+
+	path, err := spec.PartitionToPath(sc, map[string]any{
+		"name":      "a/b c",
+		"id_bucket": int32(3),
+		"ts_year":   int32(47),
+		"ts_month":  int32(574),
+		"ts_day":    iceberg.Date(17486),
+		"ts_hour":   int32(419686),
+		"tstz":      iceberg.Timestamp(1510871460000000),
+		"amount":    iceberg.Decimal{Val: decimal128.FromI64(1050), Scale: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "name=a%2Fb+c/id_bucket=3/ts_year=2017/ts_month=2017-11/ts_day=2017-11-16/"+
+		"ts_hour=2017-11-16-22/tstz=2017-11-16T22%3A31Z/amount=10.50", path)
+
+	spec = iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 3, FieldID: 1000, Name: "ts", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1001, Name: "ts_month", Transform: iceberg.MonthTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1002, Name: "name", Transform: iceberg.IdentityTransform{}},
+	)
+	path, err = spec.PartitionToPath(sc, map[string]any{
+		"ts":       iceberg.Timestamp(1510871468123000),
+		"ts_month": int32(-1),
+		"name":     nil,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ts=2017-11-16T22%3A31%3A08.123/ts_month=1969-12/name=null", path)
+
+	_, err = spec.PartitionToPath(sc, map[string]any{"ts": nil})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestPartitionToPathDecoded(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+		iceberg.NestedField{ID: 4, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 5, Name: "amount", Type: iceberg.DecimalTypeOf(10, 2)},
+	)
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "name", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1001, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 8}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "ts_year", Transform: iceberg.YearTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1003, Name: "ts_month", Transform: iceberg.MonthTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1004, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1005, Name: "ts_hour", Transform: iceberg.HourTransform{}},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1006, Name: "tstz", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 5, FieldID: 1007, Name: "amount", Transform: iceberg.TruncateTransform{Width: 50}},
+	)
+
+	// values as they are decoded from a manifest's partition tuple
+	path, err := spec.PartitionToPath(sc, map[string]any{
+		"name":      map[string]any{"string": "a/b c"},
+		"id_bucket": int(3),
+		"ts_year":   int(47),
+		"ts_month":  int(574),
+		"ts_day":    time.Date(2017, 11, 16, 0, 0, 0, 0, time.UTC),
+		"ts_hour":   map[string]any{"int": int(419686)},
+		"tstz":      time.UnixMicro(1510871460000000),
+		"amount":    big.NewRat(105, 10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "name=a%2Fb+c/id_bucket=3/ts_year=2017/ts_month=2017-11/ts_day=2017-11-16/"+
+		"ts_hour=2017-11-16-22/tstz=2017-11-16T22%3A31Z/amount=10.50", path)
+
+	spec = iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 3, FieldID: 1000, Name: "ts_year", Transform: iceberg.YearTransform{}},
+	)
+	path, err = spec.PartitionToPath(sc, map[string]any{"ts_year": int(53)})
+	require.NoError(t, err)
+	assert.Equal(t, "ts_year=2023", path)
+
+	for _, val := range []any{int64(53), "53", time.Now()} {
+		_, err = spec.PartitionToPath(sc, map[string]any{"ts_year": val})
+		assert.ErrorIs(t, err, iceberg.ErrType)
+		assert.ErrorContains(t, err, "partition field 'ts_year'")
+	}
+}
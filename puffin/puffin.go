@@ -0,0 +1,254 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package puffin reads Puffin files, which store statistics and indexes
+// such as sketches of the distinct values of columns or deletion vectors
+// as blobs along with a JSON footer describing them.
+//
+// https://iceberg.apache.org/puffin-spec/
+package puffin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Magic is the four bytes which start a Puffin file and surround its footer.
+var Magic = [4]byte{'P', 'F', 'A', '1'}
+
+var ErrInvalidFile = errors.New("invalid puffin file")
+
+const (
+	// BlobTypeThetaSketch is a serialized Apache DataSketches compact theta
+	// sketch of the distinct values of a column.
+	BlobTypeThetaSketch = "apache-datasketches-theta-v1"
+	// BlobTypeDeletionVector is a serialized roaring bitmap of the deleted
+	// positions of a data file.
+	BlobTypeDeletionVector = "deletion-vector-v1"
+
+	// PropertyNDV is the property of a theta sketch blob holding the
+	// estimated number of distinct values.
+	PropertyNDV = "ndv"
+)
+
+// CompressionCodec is the codec a blob or the footer is compressed with.
+type CompressionCodec string
+
+const (
+	CompressionNone CompressionCodec = ""
+	CompressionLZ4  CompressionCodec = "lz4"
+	CompressionZstd CompressionCodec = "zstd"
+)
+
+const (
+	footerStructLen = 12
+	// the smallest valid file is the leading magic and a footer with
+	// an empty payload
+	minFileLen = len(Magic) + len(Magic) + footerStructLen
+
+	flagFooterCompressed = 1
+)
+
+// BlobMetadata describes a blob in a Puffin file. Blobs of types this
+// package doesn't know are described in the same way, their payload can
+// still be read with [Reader.ReadBlob].
+type BlobMetadata struct {
+	Type string `json:"type"`
+	// Fields are the ids of the source columns the blob was computed from.
+	Fields           []int32           `json:"fields"`
+	SnapshotID       int64             `json:"snapshot-id"`
+	SequenceNumber   int64             `json:"sequence-number"`
+	Offset           int64             `json:"offset"`
+	Length           int64             `json:"length"`
+	CompressionCodec CompressionCodec  `json:"compression-codec,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+// NDV returns the estimated number of distinct values recorded in the
+// properties of a theta sketch blob. It reports false for other blob
+// types or if the property is missing or invalid.
+func (b BlobMetadata) NDV() (int64, bool) {
+	if b.Type != BlobTypeThetaSketch {
+		return 0, false
+	}
+
+	v, ok := b.Properties[PropertyNDV]
+	if !ok {
+		return 0, false
+	}
+
+	ndv, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return ndv, true
+}
+
+// Footer is the footer of a Puffin file, describing its blobs.
+type Footer struct {
+	Blobs      []BlobMetadata    `json:"blobs"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Reader reads the footer and blobs of a Puffin file.
+type Reader struct {
+	r      io.ReaderAt
+	size   int64
+	footer Footer
+}
+
+// NewReader reads the footer of the Puffin file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(minFileLen) {
+		return nil, fmt.Errorf("%w: file of %d bytes is too small", ErrInvalidFile, size)
+	}
+
+	var magic [4]byte
+	if _, err := r.ReadAt(magic[:], 0); err != nil {
+		return nil, err
+	}
+	if magic != Magic {
+		return nil, fmt.Errorf("%w: bad magic at start of file", ErrInvalidFile)
+	}
+
+	// the footer ends with the payload size, the flags and the magic
+	var tail [footerStructLen]byte
+	if _, err := r.ReadAt(tail[:], size-footerStructLen); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tail[8:], Magic[:]) {
+		return nil, fmt.Errorf("%w: bad magic at end of file", ErrInvalidFile)
+	}
+
+	payloadLen := int64(binary.LittleEndian.Uint32(tail[:4]))
+	flags := tail[4:8]
+
+	footerStart := size - footerStructLen - payloadLen - int64(len(Magic))
+	if footerStart < int64(len(Magic)) {
+		return nil, fmt.Errorf("%w: footer payload of %d bytes exceeds the file",
+			ErrInvalidFile, payloadLen)
+	}
+
+	buf := make([]byte, int64(len(Magic))+payloadLen)
+	if _, err := r.ReadAt(buf, footerStart); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(buf[:len(Magic)], Magic[:]) {
+		return nil, fmt.Errorf("%w: bad magic at start of footer", ErrInvalidFile)
+	}
+
+	payload := buf[len(Magic):]
+	if flags[0]&flagFooterCompressed != 0 {
+		var err error
+		if payload, err = decompress(CompressionLZ4, payload); err != nil {
+			return nil, fmt.Errorf("%w: could not decompress footer: %s", ErrInvalidFile, err)
+		}
+	}
+
+	rdr := &Reader{r: r, size: size}
+	if err := json.Unmarshal(payload, &rdr.footer); err != nil {
+		return nil, fmt.Errorf("%w: could not parse footer: %s", ErrInvalidFile, err)
+	}
+
+	for _, b := range rdr.footer.Blobs {
+		if b.Offset < int64(len(Magic)) || b.Length < 0 || b.Offset+b.Length > footerStart {
+			return nil, fmt.Errorf("%w: blob of type %s at offset %d with length %d is outside the blob section",
+				ErrInvalidFile, b.Type, b.Offset, b.Length)
+		}
+	}
+
+	return rdr, nil
+}
+
+// Footer returns the footer of the file.
+func (r *Reader) Footer() Footer { return r.footer }
+
+// Blobs returns the metadata of the blobs in the file, in file order.
+func (r *Reader) Blobs() []BlobMetadata { return r.footer.Blobs }
+
+// Properties returns the file level properties of the footer.
+func (r *Reader) Properties() map[string]string { return r.footer.Properties }
+
+// ReadBlob reads the payload of a blob of the file and decompresses it.
+func (r *Reader) ReadBlob(b BlobMetadata) ([]byte, error) {
+	return ReadBlobAt(r.r, b.Offset, b.Length, b.CompressionCodec)
+}
+
+// ReadBlobAt reads the blob at the given offset and length from a Puffin
+// file, without reading the footer. This is used when the location of the
+// blob is already known, as for deletion vectors referenced by manifests.
+func ReadBlobAt(r io.ReaderAt, offset, length int64, codec CompressionCodec) ([]byte, error) {
+	if offset < int64(len(Magic)) || length < 0 {
+		return nil, fmt.Errorf("%w: invalid blob offset %d and length %d",
+			ErrInvalidFile, offset, length)
+	}
+
+	buf := make([]byte, length)
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		return nil, err
+	}
+
+	return decompress(codec, buf)
+}
+
+// ReadDeletionVector reads the serialized deletion vector of df, a
+// deletion vector from a manifest, from its Puffin file. Only the blob
+// located by the content offset and size recorded in the manifest is
+// read, as a Puffin file holds the deletion vectors of many data files.
+func ReadDeletionVector(fsys iceio.IO, df iceberg.DataFile) ([]byte, error) {
+	offset, size := df.ContentOffset(), df.ContentSizeInBytes()
+	if df.FileFormat() != iceberg.PuffinFile || offset == nil || size == nil {
+		return nil, fmt.Errorf("%w: %s is not a deletion vector", iceberg.ErrInvalidArgument, df.FilePath())
+	}
+
+	f, err := fsys.Open(df.FilePath())
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// deletion vector blobs are never compressed
+	return ReadBlobAt(f, *offset, *size, CompressionNone)
+}
+
+func decompress(codec CompressionCodec, data []byte) ([]byte, error) {
+	switch codec {
+	case CompressionNone:
+		return data, nil
+	case CompressionLZ4:
+		return io.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+	case CompressionZstd:
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		return dec.DecodeAll(data, nil)
+	}
+
+	return nil, fmt.Errorf("%w: unsupported compression codec %s", ErrInvalidFile, codec)
+}
@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package puffin_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/puffin"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlob struct {
+	meta    puffin.BlobMetadata
+	payload []byte
+}
+
+// writePuffin writes a Puffin file with the given blobs, setting their
+// offsets and lengths, and returns the file along with the footer.
+func writePuffin(t *testing.T, compressFooter bool, props map[string]string, blobs ...testBlob) ([]byte, puffin.Footer) {
+	var buf bytes.Buffer
+	buf.Write(puffin.Magic[:])
+
+	footer := puffin.Footer{Properties: props}
+	for _, b := range blobs {
+		b.meta.Offset, b.meta.Length = int64(buf.Len()), int64(len(b.payload))
+		buf.Write(b.payload)
+		footer.Blobs = append(footer.Blobs, b.meta)
+	}
+
+	payload, err := json.Marshal(footer)
+	require.NoError(t, err)
+
+	var flags [4]byte
+	if compressFooter {
+		var compressed bytes.Buffer
+		w := lz4.NewWriter(&compressed)
+		_, err = w.Write(payload)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		payload, flags[0] = compressed.Bytes(), 1
+	}
+
+	buf.Write(puffin.Magic[:])
+	buf.Write(payload)
+	buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(payload))))
+	buf.Write(flags[:])
+	buf.Write(puffin.Magic[:])
+	return buf.Bytes(), footer
+}
+
+func TestReaderMultipleBlobTypes(t *testing.T) {
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	custom := []byte("some custom statistics")
+	compressed := enc.EncodeAll(custom, nil)
+	require.NoError(t, enc.Close())
+
+	blobs := []testBlob{
+		{meta: puffin.BlobMetadata{Type: puffin.BlobTypeThetaSketch, Fields: []int32{1},
+			SnapshotID: 10, SequenceNumber: 3, Properties: map[string]string{"ndv": "42"}},
+			payload: []byte{0x01, 0x02, 0x03}},
+		{meta: puffin.BlobMetadata{Type: "custom-stats-v1", Fields: []int32{2, 3},
+			SnapshotID: 10, SequenceNumber: 3, CompressionCodec: puffin.CompressionZstd,
+			Properties: map[string]string{"min-length": "4"}},
+			payload: compressed},
+		{meta: puffin.BlobMetadata{Type: puffin.BlobTypeDeletionVector, Fields: []int32{2147483645},
+			SnapshotID: 11, SequenceNumber: 4,
+			Properties: map[string]string{"referenced-data-file": "s3://bucket/data/a.parquet", "cardinality": "2"}},
+			payload: []byte("bitmap")},
+	}
+
+	for _, compressFooter := range []bool{false, true} {
+		data, footer := writePuffin(t, compressFooter, map[string]string{"created-by": "test"}, blobs...)
+
+		rdr, err := puffin.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, footer, rdr.Footer())
+		assert.Equal(t, map[string]string{"created-by": "test"}, rdr.Properties())
+		require.Len(t, rdr.Blobs(), 3)
+
+		theta := rdr.Blobs()[0]
+		assert.Equal(t, []int32{1}, theta.Fields)
+		assert.EqualValues(t, 10, theta.SnapshotID)
+		assert.EqualValues(t, 3, theta.SequenceNumber)
+		ndv, ok := theta.NDV()
+		assert.True(t, ok)
+		assert.EqualValues(t, 42, ndv)
+
+		payload, err := rdr.ReadBlob(theta)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02, 0x03}, payload)
+
+		unknown := rdr.Blobs()[1]
+		assert.Equal(t, "custom-stats-v1", unknown.Type)
+		assert.Equal(t, []int32{2, 3}, unknown.Fields)
+		assert.Equal(t, "4", unknown.Properties["min-length"])
+		_, ok = unknown.NDV()
+		assert.False(t, ok)
+		payload, err = rdr.ReadBlob(unknown)
+		require.NoError(t, err)
+		assert.Equal(t, custom, payload)
+
+		dv := rdr.Blobs()[2]
+		payload, err = puffin.ReadBlobAt(bytes.NewReader(data), dv.Offset, dv.Length, dv.CompressionCodec)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("bitmap"), payload)
+	}
+}
+
+func TestReaderEmptyFile(t *testing.T) {
+	data, _ := writePuffin(t, false, nil)
+
+	rdr, err := puffin.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Empty(t, rdr.Blobs())
+}
+
+func TestReaderInvalidFiles(t *testing.T) {
+	valid, _ := writePuffin(t, false, nil, testBlob{
+		meta: puffin.BlobMetadata{Type: puffin.BlobTypeThetaSketch}, payload: []byte("abc")})
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"too small", valid[:10]},
+		{"bad leading magic", append([]byte("XXXX"), valid[4:]...)},
+		{"bad trailing magic", append(append([]byte{}, valid[:len(valid)-4]...), "XXXX"...)},
+		{"truncated", valid[4:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := puffin.NewReader(bytes.NewReader(tt.data), int64(len(tt.data)))
+			assert.ErrorIs(t, err, puffin.ErrInvalidFile)
+		})
+	}
+
+	data, _ := writePuffin(t, false, nil, testBlob{
+		meta:    puffin.BlobMetadata{Type: "custom", CompressionCodec: "snappy"},
+		payload: []byte("abc")})
+	rdr, err := puffin.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	_, err = rdr.ReadBlob(rdr.Blobs()[0])
+	assert.ErrorIs(t, err, puffin.ErrInvalidFile)
+	assert.ErrorContains(t, err, "unsupported compression codec snappy")
+}
+
+// dvFile is a deletion vector entry of a manifest.
+type dvFile struct {
+	iceberg.DataFile
+
+	path         string
+	format       iceberg.FileFormat
+	offset, size *int64
+}
+
+func (d dvFile) FilePath() string               { return d.path }
+func (d dvFile) FileFormat() iceberg.FileFormat { return d.format }
+func (d dvFile) ContentOffset() *int64          { return d.offset }
+func (d dvFile) ContentSizeInBytes() *int64     { return d.size }
+
+func TestReadDeletionVector(t *testing.T) {
+	dv := func(file string, payload string) testBlob {
+		return testBlob{meta: puffin.BlobMetadata{Type: puffin.BlobTypeDeletionVector,
+			Fields: []int32{2147483645}, SnapshotID: 1, SequenceNumber: 1,
+			Properties: map[string]string{"referenced-data-file": file}},
+			payload: []byte(payload)}
+	}
+
+	data, footer := writePuffin(t, false, nil,
+		dv("s3://bucket/data/a.parquet", "first vector"),
+		dv("s3://bucket/data/b.parquet", "second vector"),
+		dv("s3://bucket/data/c.parquet", "third"))
+
+	path := filepath.Join(t.TempDir(), "deletes.puffin")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	for i, want := range []string{"first vector", "second vector", "third"} {
+		b := footer.Blobs[i]
+		got, err := puffin.ReadDeletionVector(io.LocalFS{}, dvFile{
+			path: path, format: iceberg.PuffinFile, offset: &b.Offset, size: &b.Length})
+		require.NoError(t, err)
+		assert.Equal(t, []byte(want), got)
+	}
+
+	offset := int64(4)
+	_, err := puffin.ReadDeletionVector(io.LocalFS{}, dvFile{
+		path: path, format: iceberg.ParquetFile, offset: &offset, size: &offset})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	_, err = puffin.ReadDeletionVector(io.LocalFS{}, dvFile{path: path, format: iceberg.PuffinFile})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -19,6 +19,7 @@ package iceberg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync/atomic"
@@ -178,17 +179,15 @@ func (s *Schema) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON writes the fields in the same order as the Java
+// implementation, which leaves out empty identifier field IDs.
 func (s *Schema) MarshalJSON() ([]byte, error) {
-	if s.IdentifierFieldIDs == nil {
-		s.IdentifierFieldIDs = []int{}
-	}
-
-	type Alias Schema
 	return json.Marshal(struct {
-		Type   string        `json:"type"`
-		Fields []NestedField `json:"fields"`
-		*Alias
-	}{Type: "struct", Fields: s.fields, Alias: (*Alias)(s)})
+		Type               string        `json:"type"`
+		ID                 int           `json:"schema-id"`
+		IdentifierFieldIDs []int         `json:"identifier-field-ids,omitempty"`
+		Fields             []NestedField `json:"fields"`
+	}{"struct", s.ID, s.IdentifierFieldIDs, s.fields})
 }
 
 // FindColumnName returns the name of the column identified by the
@@ -305,8 +304,127 @@ func (s *Schema) Equals(other *Schema) bool {
 	})
 }
 
+// CheckCompatible checks that data written with writeSchema can be
+// appended to a table with this schema, following Iceberg's write
+// compatibility rules. Fields are matched by ID:
+//
+//   - every required field of the table schema must be present
+//   - a required field cannot be written from an optional one
+//   - the written type must be equal to, or promotable to, the table type
+//   - fields which do not exist in the table schema are rejected
+//
+// All of the problems found are reported in the returned error, which
+// wraps ErrInvalidSchema.
+func (s *Schema) CheckCompatible(writeSchema *Schema) error {
+	if writeSchema == nil {
+		return fmt.Errorf("%w: missing write schema", ErrInvalidArgument)
+	}
+
+	errs := checkStructCompatible("", s.AsStruct(), writeSchema.AsStruct())
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: write schema is not compatible with table schema:\n%w",
+		ErrInvalidSchema, errors.Join(errs...))
+}
+
+func joinFieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func checkStructCompatible(path string, table, write StructType) []error {
+	var errs []error
+
+	writeByID := make(map[int]NestedField, len(write.FieldList))
+	for _, f := range write.FieldList {
+		writeByID[f.ID] = f
+	}
+
+	for _, tf := range table.FieldList {
+		wf, ok := writeByID[tf.ID]
+		if !ok {
+			if tf.Required {
+				errs = append(errs, fmt.Errorf("required field is missing: %s (id %d)",
+					joinFieldPath(path, tf.Name), tf.ID))
+			}
+			continue
+		}
+
+		delete(writeByID, tf.ID)
+		errs = append(errs, checkFieldCompatible(joinFieldPath(path, tf.Name), tf, wf)...)
+	}
+
+	for _, wf := range write.FieldList {
+		if _, extra := writeByID[wf.ID]; extra {
+			errs = append(errs, fmt.Errorf("field does not exist in table schema: %s (id %d)",
+				joinFieldPath(path, wf.Name), wf.ID))
+		}
+	}
+
+	return errs
+}
+
+func checkFieldCompatible(path string, table, write NestedField) []error {
+	var errs []error
+	if table.Required && !write.Required {
+		errs = append(errs, fmt.Errorf("cannot write optional field to required field: %s", path))
+	}
+
+	switch tt := table.Type.(type) {
+	case *StructType:
+		wt, ok := write.Type.(*StructType)
+		if !ok {
+			return append(errs, fmt.Errorf("cannot write %s to %s field: %s", write.Type, tt, path))
+		}
+		return append(errs, checkStructCompatible(path, *tt, *wt)...)
+	case *ListType:
+		wt, ok := write.Type.(*ListType)
+		if !ok {
+			return append(errs, fmt.Errorf("cannot write %s to %s field: %s", write.Type, tt, path))
+		}
+		return append(errs, checkFieldCompatible(path+".element", tt.ElementField(), wt.ElementField())...)
+	case *MapType:
+		wt, ok := write.Type.(*MapType)
+		if !ok {
+			return append(errs, fmt.Errorf("cannot write %s to %s field: %s", write.Type, tt, path))
+		}
+		errs = append(errs, checkFieldCompatible(path+".key", tt.KeyField(), wt.KeyField())...)
+		return append(errs, checkFieldCompatible(path+".value", tt.ValueField(), wt.ValueField())...)
+	}
+
+	if !table.Type.Equals(write.Type) && !CanPromoteType(write.Type, table.Type) {
+		errs = append(errs, fmt.Errorf("cannot write %s to %s field: %s", write.Type, table.Type, path))
+	}
+
+	return errs
+}
+
+// CanPromoteType reports whether values of type from can be widened
+// losslessly to type to, as allowed by Iceberg schema evolution: int to
+// long, float to double and decimal(P, S) to decimal(P', S) where P' > P.
+func CanPromoteType(from, to Type) bool {
+	switch from := from.(type) {
+	case Int32Type:
+		_, ok := to.(Int64Type)
+		return ok
+	case Float32Type:
+		_, ok := to.(Float64Type)
+		return ok
+	case DecimalType:
+		to, ok := to.(DecimalType)
+		return ok && from.scale == to.scale && from.precision <= to.precision
+	}
+
+	return false
+}
+
 // HighestFieldID returns the value of the numerically highest field ID
-// in this schema.
+// in this schema, including the IDs of nested fields, list elements, and
+// map keys and values. It returns 0 for a schema without fields.
 func (s *Schema) HighestFieldID() int {
 	id, _ := Visit[int](s, findLastFieldID{})
 	return id
@@ -870,17 +988,19 @@ func (findLastFieldID) Schema(_ *Schema, result int) int {
 }
 
 func (findLastFieldID) Struct(_ StructType, fieldResults []int) int {
-	return max(fieldResults...)
+	return max(append(fieldResults, 0)...)
 }
 
 func (findLastFieldID) Field(field NestedField, fieldResult int) int {
 	return max(field.ID, fieldResult)
 }
 
-func (findLastFieldID) List(_ ListType, elemResult int) int { return elemResult }
+func (findLastFieldID) List(list ListType, elemResult int) int {
+	return max(list.ElementID, elemResult)
+}
 
-func (findLastFieldID) Map(_ MapType, keyResult, valueResult int) int {
-	return max(keyResult, valueResult)
+func (findLastFieldID) Map(m MapType, keyResult, valueResult int) int {
+	return max(m.KeyID, m.ValueID, keyResult, valueResult)
 }
 
 func (findLastFieldID) Primitive(PrimitiveType) int { return 0 }
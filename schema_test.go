@@ -623,6 +623,49 @@ func TestPruneColumnsStructInMapFull(t *testing.T) {
 	assert.Truef(t, sc.Equals(expected), "expected: %s\ngot: %s", expected, sc)
 }
 
+func TestSchemaHighestFieldID(t *testing.T) {
+	assert.Equal(t, 17, tableSchemaNested.HighestFieldID())
+	assert.Equal(t, 0, iceberg.NewSchema(0).HighestFieldID())
+
+	tests := []struct {
+		name   string
+		field  iceberg.NestedField
+		expect int
+	}{
+		{"list element", iceberg.NestedField{ID: 1, Name: "l", Type: &iceberg.ListType{
+			ElementID: 9, Element: iceberg.PrimitiveTypes.Int32}}, 9},
+		{"map key", iceberg.NestedField{ID: 1, Name: "m", Type: &iceberg.MapType{
+			KeyID: 9, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 2, ValueType: iceberg.PrimitiveTypes.Int32}}, 9},
+		{"map value", iceberg.NestedField{ID: 1, Name: "m", Type: &iceberg.MapType{
+			KeyID: 2, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 9, ValueType: iceberg.PrimitiveTypes.Int32}}, 9},
+		{"empty struct", iceberg.NestedField{ID: 9, Name: "s", Type: &iceberg.StructType{}}, 9},
+		{"deeply nested", iceberg.NestedField{ID: 1, Name: "s", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 2, Name: "empty", Type: &iceberg.StructType{}},
+				{ID: 3, Name: "l", Type: &iceberg.ListType{
+					ElementID: 4, Element: &iceberg.MapType{
+						KeyID: 5, KeyType: iceberg.PrimitiveTypes.String,
+						ValueID: 6, ValueType: &iceberg.ListType{
+							ElementID: 7, Element: &iceberg.StructType{
+								FieldList: []iceberg.NestedField{
+									{ID: 8, Name: "x", Type: &iceberg.ListType{
+										ElementID: 42, Element: iceberg.PrimitiveTypes.Int64}},
+								}}}}}},
+			}}}, 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := iceberg.NewSchema(0,
+				iceberg.NestedField{ID: 3, Name: "a", Type: iceberg.PrimitiveTypes.Int32},
+				tt.field)
+			assert.Equal(t, tt.expect, sc.HighestFieldID())
+		})
+	}
+}
+
 func TestPruneColumnsSelectOriginalSchema(t *testing.T) {
 	id := tableSchemaNested.HighestFieldID()
 	selected := make(map[int]iceberg.Void)
@@ -641,6 +684,45 @@ func TestPruneNilSchema(t *testing.T) {
 	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
 }
 
+func TestSchemaSelectNestedElements(t *testing.T) {
+	sc, err := tableSchemaNested.Select(true, "location.element.latitude")
+	require.NoError(t, err)
+	assert.True(t, sc.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{
+			ID: 11, Name: "location", Type: &iceberg.ListType{
+				ElementID: 12, Element: &iceberg.StructType{
+					FieldList: []iceberg.NestedField{
+						{ID: 13, Name: "latitude", Type: iceberg.PrimitiveTypes.Float32},
+					},
+				},
+				ElementRequired: true,
+			},
+			Required: true,
+		})), sc.String())
+
+	sc, err = tableSchemaNested.Select(true, "quux.value.value")
+	require.NoError(t, err)
+	assert.True(t, sc.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{
+			ID: 6, Name: "quux", Type: &iceberg.MapType{
+				KeyID: 7, KeyType: iceberg.PrimitiveTypes.String,
+				ValueID: 8, ValueType: &iceberg.MapType{
+					KeyID: 9, KeyType: iceberg.PrimitiveTypes.String,
+					ValueID: 10, ValueType: iceberg.PrimitiveTypes.Int32,
+					ValueRequired: true,
+				},
+				ValueRequired: true,
+			},
+			Required: true,
+		})), sc.String())
+
+	sc, err = tableSchemaNested.Select(false, "QUX.Element")
+	require.NoError(t, err)
+	field, ok := sc.FindFieldByID(5)
+	require.True(t, ok)
+	assert.Equal(t, iceberg.PrimitiveTypes.String, field.Type)
+}
+
 func TestSchemaRoundTrip(t *testing.T) {
 	data, err := json.Marshal(tableSchemaNested)
 	require.NoError(t, err)
@@ -756,3 +838,43 @@ func TestSchemaRoundTrip(t *testing.T) {
 
 	assert.Truef(t, tableSchemaNested.Equals(&sc), "expected: %s\ngot: %s", tableSchemaNested, &sc)
 }
+
+func TestSchemaCheckCompatible(t *testing.T) {
+	tableSchema := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "price", Type: iceberg.DecimalTypeOf(18, 2)},
+		iceberg.NestedField{ID: 4, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 5, Element: iceberg.PrimitiveTypes.String, ElementRequired: true}},
+	)
+
+	t.Run("equal", func(t *testing.T) {
+		assert.NoError(t, tableSchema.CheckCompatible(tableSchema))
+	})
+
+	t.Run("promotions and missing optional", func(t *testing.T) {
+		write := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+			iceberg.NestedField{ID: 3, Name: "price", Type: iceberg.DecimalTypeOf(10, 2), Required: true},
+		)
+		assert.NoError(t, tableSchema.CheckCompatible(write))
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+		write := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.Int32},
+			iceberg.NestedField{ID: 3, Name: "price", Type: iceberg.DecimalTypeOf(10, 3)},
+			iceberg.NestedField{ID: 4, Name: "tags", Type: &iceberg.ListType{
+				ElementID: 5, Element: iceberg.PrimitiveTypes.String, ElementRequired: false}},
+			iceberg.NestedField{ID: 6, Name: "extra", Type: iceberg.PrimitiveTypes.Bool},
+		)
+
+		err := tableSchema.CheckCompatible(write)
+		require.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "required field is missing: id (id 1)")
+		assert.ErrorContains(t, err, "cannot write int to string field: data")
+		assert.ErrorContains(t, err, "cannot write decimal(10, 3) to decimal(18, 2) field: price")
+		assert.ErrorContains(t, err, "cannot write optional field to required field: tags.element")
+		assert.ErrorContains(t, err, "field does not exist in table schema: extra (id 6)")
+	})
+}
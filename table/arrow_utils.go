@@ -0,0 +1,671 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v16/arrow"
+	"github.com/apache/arrow/go/v16/arrow/array"
+	"github.com/apache/arrow/go/v16/arrow/compute"
+	"github.com/apache/arrow/go/v16/arrow/memory"
+	"github.com/apache/iceberg-go"
+)
+
+const (
+	// ArrowFieldDocKey is the metadata key used to store the doc string
+	// of an iceberg field in the metadata of the corresponding arrow field.
+	ArrowFieldDocKey = "doc"
+	// ArrowParquetFieldIDKey is the metadata key for field IDs used by the
+	// parquet-arrow integration, it's used to map arrow fields back to
+	// iceberg fields.
+	ArrowParquetFieldIDKey = "PARQUET:field_id"
+	// ArrowLegacyFieldIDKey is the metadata key for field IDs used by
+	// some older engines, it's only written when requested with
+	// WithArrowLegacyFieldIDs but always read as a fallback.
+	ArrowLegacyFieldIDKey = "field_id"
+	// ArrowIcebergTypeKey is the metadata key used to store the iceberg type
+	// of fields which have no equivalent arrow type, such as variant or
+	// geometry, which are represented as binary arrow fields, and uuid,
+	// which is represented as a 16 byte fixed size binary arrow field.
+	ArrowIcebergTypeKey = "iceberg.type"
+)
+
+type convertToArrow struct {
+	includeFieldIDs bool
+	legacyFieldIDs  bool
+}
+
+// ArrowSchemaOption configures the conversion of iceberg schemas and
+// types to arrow.
+type ArrowSchemaOption func(*convertToArrow)
+
+// WithArrowLegacyFieldIDs additionally stores the field IDs under
+// ArrowLegacyFieldIDKey, for readers which don't look for them under
+// ArrowParquetFieldIDKey. It has no effect unless field IDs are included.
+func WithArrowLegacyFieldIDs() ArrowSchemaOption {
+	return func(c *convertToArrow) { c.legacyFieldIDs = true }
+}
+
+func newConvertToArrow(includeFieldIDs bool, opts []ArrowSchemaOption) convertToArrow {
+	c := convertToArrow{includeFieldIDs: includeFieldIDs}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+func (c convertToArrow) Schema(_ *iceberg.Schema, result arrow.Field) arrow.Field {
+	return result
+}
+
+func (c convertToArrow) Struct(_ iceberg.StructType, results []arrow.Field) arrow.Field {
+	return arrow.Field{Type: arrow.StructOf(results...)}
+}
+
+func (c convertToArrow) Field(field iceberg.NestedField, result arrow.Field) arrow.Field {
+	meta := map[string]string{}
+	for i, k := range result.Metadata.Keys() {
+		meta[k] = result.Metadata.Values()[i]
+	}
+	if len(field.Doc) > 0 {
+		meta[ArrowFieldDocKey] = field.Doc
+	}
+
+	if c.includeFieldIDs {
+		meta[ArrowParquetFieldIDKey] = strconv.Itoa(field.ID)
+		if c.legacyFieldIDs {
+			meta[ArrowLegacyFieldIDKey] = strconv.Itoa(field.ID)
+		}
+	}
+
+	if len(meta) > 0 {
+		result.Metadata = arrow.MetadataFrom(meta)
+	}
+
+	result.Name, result.Nullable = field.Name, !field.Required
+	return result
+}
+
+func (c convertToArrow) List(list iceberg.ListType, elemResult arrow.Field) arrow.Field {
+	elemField := c.Field(list.ElementField(), elemResult)
+	return arrow.Field{Type: arrow.ListOfField(elemField)}
+}
+
+func (c convertToArrow) Map(m iceberg.MapType, keyResult, valResult arrow.Field) arrow.Field {
+	keyField := c.Field(m.KeyField(), keyResult)
+	valField := c.Field(m.ValueField(), valResult)
+	mapType := arrow.MapOfWithMetadata(keyField.Type, keyField.Metadata,
+		valField.Type, valField.Metadata)
+	mapType.SetItemNullable(valField.Nullable)
+	return arrow.Field{Type: mapType}
+}
+
+func (c convertToArrow) Primitive(p iceberg.PrimitiveType) arrow.Field {
+	result := arrow.Field{Type: primitiveToArrow(p)}
+	switch p.(type) {
+	case iceberg.VariantType, iceberg.GeometryType, iceberg.GeographyType, iceberg.UUIDType:
+		result.Metadata = arrow.NewMetadata([]string{ArrowIcebergTypeKey}, []string{p.Type()})
+	}
+	return result
+}
+
+func primitiveToArrow(p iceberg.PrimitiveType) arrow.DataType {
+	switch p := p.(type) {
+	case iceberg.BooleanType:
+		return arrow.FixedWidthTypes.Boolean
+	case iceberg.Int32Type:
+		return arrow.PrimitiveTypes.Int32
+	case iceberg.Int64Type:
+		return arrow.PrimitiveTypes.Int64
+	case iceberg.Float32Type:
+		return arrow.PrimitiveTypes.Float32
+	case iceberg.Float64Type:
+		return arrow.PrimitiveTypes.Float64
+	case iceberg.DateType:
+		return arrow.FixedWidthTypes.Date32
+	case iceberg.TimeType:
+		return arrow.FixedWidthTypes.Time64us
+	case iceberg.TimestampType:
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	case iceberg.TimestampTzType:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	case iceberg.TimestampNsType:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}
+	case iceberg.TimestampTzNsType:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+	case iceberg.StringType:
+		return arrow.BinaryTypes.String
+	case iceberg.BinaryType:
+		return arrow.BinaryTypes.Binary
+	case iceberg.FixedType:
+		return &arrow.FixedSizeBinaryType{ByteWidth: p.Len()}
+	case iceberg.UUIDType:
+		return &arrow.FixedSizeBinaryType{ByteWidth: 16}
+	case iceberg.DecimalType:
+		return &arrow.Decimal128Type{Precision: int32(p.Precision()), Scale: int32(p.Scale())}
+	case iceberg.UnknownType:
+		return arrow.Null
+	case iceberg.VariantType, iceberg.GeometryType, iceberg.GeographyType:
+		// variant values and geospatial features in well-known binary
+		// are passed through as binary, the iceberg type is kept in the
+		// field metadata
+		return arrow.BinaryTypes.Binary
+	}
+	panic(fmt.Errorf("%w: unsupported type for arrow conversion: %s", iceberg.ErrType, p))
+}
+
+// SchemaToArrowSchema converts an iceberg schema to the equivalent arrow
+// schema, attaching the given metadata to the schema. If includeFieldIDs
+// is true, the iceberg field IDs are stored in the metadata of each arrow
+// field so that the schema can be converted back with ArrowSchemaToIceberg.
+// The parquet-arrow writer stores the IDs found under ArrowParquetFieldIDKey
+// as the field_id of primitive parquet columns, but it doesn't write the
+// IDs of lists, maps and structs themselves, nor those of list elements.
+func SchemaToArrowSchema(sc *iceberg.Schema, metadata map[string]string, includeFieldIDs bool, opts ...ArrowSchemaOption) (*arrow.Schema, error) {
+	top, err := iceberg.Visit[arrow.Field](sc, newConvertToArrow(includeFieldIDs, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	var md *arrow.Metadata
+	if len(metadata) > 0 {
+		m := arrow.MetadataFrom(metadata)
+		md = &m
+	}
+	return arrow.NewSchema(top.Type.(*arrow.StructType).Fields(), md), nil
+}
+
+// TypeToArrowType converts an iceberg type to the equivalent arrow type.
+// Field IDs of nested types are stored in the arrow field metadata if
+// includeFieldIDs is true.
+func TypeToArrowType(t iceberg.Type, includeFieldIDs bool, opts ...ArrowSchemaOption) (arrow.DataType, error) {
+	top, err := iceberg.Visit[arrow.Field](iceberg.NewSchema(0,
+		iceberg.NestedField{Type: t, Name: "field", Required: true}),
+		newConvertToArrow(includeFieldIDs, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return top.Type.(*arrow.StructType).Field(0).Type, nil
+}
+
+// ArrowSchemaToIceberg converts an arrow schema to an iceberg schema. Each
+// field must have its iceberg field ID stored in the field metadata under
+// ArrowParquetFieldIDKey, as is done by SchemaToArrowSchema and by the
+// parquet-arrow reader for parquet files with field IDs, or else under
+// ArrowLegacyFieldIDKey.
+func ArrowSchemaToIceberg(sc *arrow.Schema) (*iceberg.Schema, error) {
+	fields := make([]iceberg.NestedField, sc.NumFields())
+	for i, f := range sc.Fields() {
+		field, err := arrowFieldToIceberg(f)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = field
+	}
+
+	return iceberg.NewSchema(0, fields...), nil
+}
+
+func arrowFieldID(f arrow.Field) (int, error) {
+	idx := f.Metadata.FindKey(ArrowParquetFieldIDKey)
+	if idx == -1 {
+		idx = f.Metadata.FindKey(ArrowLegacyFieldIDKey)
+	}
+	if idx == -1 {
+		return 0, fmt.Errorf("%w: arrow field '%s' is missing a field id",
+			iceberg.ErrInvalidSchema, f.Name)
+	}
+
+	id, err := strconv.Atoi(f.Metadata.Values()[idx])
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid field id for arrow field '%s': %s",
+			iceberg.ErrInvalidSchema, f.Name, err)
+	}
+	return id, nil
+}
+
+func arrowFieldToIceberg(f arrow.Field) (iceberg.NestedField, error) {
+	id, err := arrowFieldID(f)
+	if err != nil {
+		return iceberg.NestedField{}, err
+	}
+
+	var typ iceberg.Type
+	if idx := f.Metadata.FindKey(ArrowIcebergTypeKey); idx != -1 {
+		typ, err = parseIcebergType(f.Metadata.Values()[idx])
+	} else {
+		typ, err = arrowTypeToIceberg(f.Type)
+	}
+	if err != nil {
+		return iceberg.NestedField{}, fmt.Errorf("%w: field '%s'", err, f.Name)
+	}
+
+	field := iceberg.NestedField{ID: id, Name: f.Name, Type: typ, Required: !f.Nullable}
+	if idx := f.Metadata.FindKey(ArrowFieldDocKey); idx != -1 {
+		field.Doc = f.Metadata.Values()[idx]
+	}
+	return field, nil
+}
+
+func parseIcebergType(s string) (iceberg.Type, error) {
+	var field iceberg.NestedField
+	err := json.Unmarshal([]byte(`{"id": 0, "name": "", "type": `+strconv.Quote(s)+`}`), &field)
+	return field.Type, err
+}
+
+func arrowTypeToIceberg(dt arrow.DataType) (iceberg.Type, error) {
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return iceberg.PrimitiveTypes.Unknown, nil
+	case *arrow.BooleanType:
+		return iceberg.PrimitiveTypes.Bool, nil
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type,
+		*arrow.Uint8Type, *arrow.Uint16Type:
+		return iceberg.PrimitiveTypes.Int32, nil
+	case *arrow.Int64Type, *arrow.Uint32Type:
+		return iceberg.PrimitiveTypes.Int64, nil
+	case *arrow.Float16Type, *arrow.Float32Type:
+		return iceberg.PrimitiveTypes.Float32, nil
+	case *arrow.Float64Type:
+		return iceberg.PrimitiveTypes.Float64, nil
+	case *arrow.Date32Type:
+		return iceberg.PrimitiveTypes.Date, nil
+	case *arrow.Time64Type:
+		if dt.Unit == arrow.Microsecond {
+			return iceberg.PrimitiveTypes.Time, nil
+		}
+	case *arrow.TimestampType:
+		switch dt.Unit {
+		case arrow.Microsecond:
+			if dt.TimeZone == "" {
+				return iceberg.PrimitiveTypes.Timestamp, nil
+			}
+			return iceberg.PrimitiveTypes.TimestampTz, nil
+		case arrow.Nanosecond:
+			if dt.TimeZone == "" {
+				return iceberg.PrimitiveTypes.TimestampNs, nil
+			}
+			return iceberg.PrimitiveTypes.TimestampTzNs, nil
+		}
+	case *arrow.StringType, *arrow.LargeStringType:
+		return iceberg.PrimitiveTypes.String, nil
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		return iceberg.PrimitiveTypes.Binary, nil
+	case *arrow.FixedSizeBinaryType:
+		return iceberg.FixedTypeOf(dt.ByteWidth), nil
+	case *arrow.Decimal128Type:
+		return iceberg.DecimalTypeOf(int(dt.Precision), int(dt.Scale)), nil
+	case *arrow.StructType:
+		fields := make([]iceberg.NestedField, dt.NumFields())
+		for i, f := range dt.Fields() {
+			field, err := arrowFieldToIceberg(f)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = field
+		}
+		return &iceberg.StructType{FieldList: fields}, nil
+	case *arrow.MapType:
+		key, err := arrowFieldToIceberg(dt.KeyField())
+		if err != nil {
+			return nil, err
+		}
+		val, err := arrowFieldToIceberg(dt.ItemField())
+		if err != nil {
+			return nil, err
+		}
+		return &iceberg.MapType{KeyID: key.ID, KeyType: key.Type,
+			ValueID: val.ID, ValueType: val.Type, ValueRequired: val.Required}, nil
+	case arrow.ListLikeType:
+		elem, err := arrowFieldToIceberg(dt.ElemField())
+		if err != nil {
+			return nil, err
+		}
+		return &iceberg.ListType{ElementID: elem.ID, Element: elem.Type,
+			ElementRequired: elem.Required}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported arrow type %s", iceberg.ErrType, dt)
+}
+
+// RowIDs materializes the "_row_id" row lineage column for rows read from
+// the given positions of a data file, with the file's first row ID from
+// DataFile.FirstRowID. Row IDs which were stored in the data file are
+// kept, the others are computed as the first row ID plus the row's
+// position. If first is nil, the computed row IDs are null. stored may be
+// nil if the data file has no row ID column.
+func RowIDs(mem memory.Allocator, first *int64, positions, stored *array.Int64) *array.Int64 {
+	bldr := array.NewInt64Builder(mem)
+	defer bldr.Release()
+
+	bldr.Reserve(positions.Len())
+	for i := 0; i < positions.Len(); i++ {
+		switch {
+		case stored != nil && stored.IsValid(i):
+			bldr.UnsafeAppend(stored.Value(i))
+		case first != nil && positions.IsValid(i):
+			bldr.UnsafeAppend(*first + positions.Value(i))
+		default:
+			bldr.UnsafeAppendBoolToBitmap(false)
+		}
+	}
+
+	return bldr.NewInt64Array()
+}
+
+// ToRequestedSchema projects a record read from a data file onto the
+// requested schema, matching columns by field ID. This allows files written
+// before a schema evolution to be read with the current table schema:
+//
+//   - columns are renamed to the requested field names
+//   - columns whose type was promoted (int to long, float to double,
+//     decimal precision widening) are widened losslessly
+//   - columns missing from the file are filled with the field's
+//     initial-default, or with nulls if it has none
+//
+// Fields are matched the same way inside structs, list elements and map
+// keys and values, so fields added to a nested struct are filled in at
+// their position within it.
+//
+// Requesting a required column which is missing from the file and has no
+// initial-default, or a type which the stored type cannot be promoted to,
+// returns an error wrapping iceberg.ErrType. The returned record must be
+// released by the caller.
+func ToRequestedSchema(ctx context.Context, requested *iceberg.Schema, rec arrow.Record) (arrow.Record, error) {
+	fileSchema, err := ArrowSchemaToIceberg(rec.Schema())
+	if err != nil {
+		return nil, err
+	}
+
+	outSchema, err := SchemaToArrowSchema(requested, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	p := projector{ctx: ctx, mem: compute.GetAllocator(ctx)}
+	cols, err := p.fields(requested.Fields(), outSchema.Fields(),
+		fileSchema.Fields(), rec.Columns(), int(rec.NumRows()))
+	if err != nil {
+		return nil, err
+	}
+	defer releaseAll(cols)
+
+	return array.NewRecord(outSchema, cols, rec.NumRows()), nil
+}
+
+// projector reconciles arrays read from a file with the requested types.
+type projector struct {
+	ctx context.Context
+	mem memory.Allocator
+}
+
+// fields projects the columns of a record or the children of a struct,
+// which were stored as the file fields, onto the requested fields. Missing
+// fields are filled in with arrays of the given length.
+func (p projector) fields(requested []iceberg.NestedField, out []arrow.Field, file []iceberg.NestedField, cols []arrow.Array, length int) (result []arrow.Array, err error) {
+	colsByID := make(map[int]int, len(file))
+	for i, f := range file {
+		colsByID[f.ID] = i
+	}
+
+	result = make([]arrow.Array, 0, len(requested))
+	defer func() {
+		if err != nil {
+			releaseAll(result)
+		}
+	}()
+
+	for i, field := range requested {
+		var col arrow.Array
+		if idx, ok := colsByID[field.ID]; ok {
+			col, err = p.project(field, file[idx].Type, out[i].Type, cols[idx])
+		} else {
+			col, err = p.missing(field, out[i].Type, length)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, col)
+	}
+	return result, nil
+}
+
+// missing returns an array of the given length for a field which isn't in
+// the file, filled with the field's initial-default or nulls.
+func (p projector) missing(field iceberg.NestedField, dt arrow.DataType, length int) (arrow.Array, error) {
+	if field.InitialDefault == nil {
+		if field.Required {
+			return nil, fmt.Errorf("%w: required field '%s' (id %d) is missing from file",
+				iceberg.ErrType, field.Name, field.ID)
+		}
+		return array.MakeArrayOfNull(p.mem, dt, length), nil
+	}
+
+	val, err := json.Marshal(field.InitialDefault)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid initial-default for field '%s': %s",
+			iceberg.ErrType, field.Name, err)
+	}
+
+	var b strings.Builder
+	b.WriteByte('[')
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(val)
+	}
+	b.WriteByte(']')
+
+	arr, _, err := array.FromJSON(p.mem, dt, strings.NewReader(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid initial-default for field '%s': %s",
+			iceberg.ErrType, field.Name, err)
+	}
+	return arr, nil
+}
+
+// project converts col, stored in the file as fileType, to the requested
+// field's type, recursing into nested types.
+func (p projector) project(field iceberg.NestedField, fileType iceberg.Type, dt arrow.DataType, col arrow.Array) (arrow.Array, error) {
+	mismatch := func() error {
+		return fmt.Errorf("%w: cannot read field '%s' of type %s as %s",
+			iceberg.ErrType, field.Name, fileType, field.Type)
+	}
+
+	switch req := field.Type.(type) {
+	case *iceberg.StructType:
+		fileStruct, ok := fileType.(*iceberg.StructType)
+		if !ok || col.DataType().ID() != arrow.STRUCT {
+			return nil, mismatch()
+		}
+
+		data := col.Data()
+		children := make([]arrow.Array, len(data.Children()))
+		for i, c := range data.Children() {
+			children[i] = array.MakeFromData(c)
+		}
+		defer releaseAll(children)
+
+		// children aren't sliced with the struct, so they're projected
+		// whole and the struct's offset is kept
+		projected, err := p.fields(req.FieldList, dt.(*arrow.StructType).Fields(),
+			fileStruct.FieldList, children, data.Offset()+data.Len())
+		if err != nil {
+			return nil, err
+		}
+		defer releaseAll(projected)
+
+		return p.rebuild(dt, data, projected...), nil
+	case *iceberg.ListType:
+		fileList, ok := fileType.(*iceberg.ListType)
+		if !ok || col.DataType().ID() != arrow.LIST {
+			return nil, mismatch()
+		}
+
+		data := col.Data()
+		elems := array.MakeFromData(data.Children()[0])
+		defer elems.Release()
+
+		elem, err := p.project(req.ElementField(), fileList.Element,
+			dt.(*arrow.ListType).Elem(), elems)
+		if err != nil {
+			return nil, err
+		}
+		defer elem.Release()
+
+		return p.rebuild(dt, data, elem), nil
+	case *iceberg.MapType:
+		fileMap, ok := fileType.(*iceberg.MapType)
+		if !ok || col.DataType().ID() != arrow.MAP {
+			return nil, mismatch()
+		}
+
+		mapType := dt.(*arrow.MapType)
+		data := col.Data()
+		entries := data.Children()[0]
+		keys := array.MakeFromData(entries.Children()[0])
+		defer keys.Release()
+		vals := array.MakeFromData(entries.Children()[1])
+		defer vals.Release()
+
+		key, err := p.project(req.KeyField(), fileMap.KeyType, mapType.KeyType(), keys)
+		if err != nil {
+			return nil, err
+		}
+		defer key.Release()
+
+		val, err := p.project(req.ValueField(), fileMap.ValueType, mapType.ItemType(), vals)
+		if err != nil {
+			return nil, err
+		}
+		defer val.Release()
+
+		entriesArr := p.rebuild(mapType.Elem(), entries, key, val)
+		defer entriesArr.Release()
+		return p.rebuild(dt, data, entriesArr), nil
+	}
+
+	// files written without the arrow schema lose the iceberg type of uuid
+	// columns, which are read back as 16 byte fixed columns
+	if _, ok := field.Type.(iceberg.UUIDType); ok && fileType.Equals(iceberg.FixedTypeOf(16)) {
+		fileType = field.Type
+	}
+
+	switch {
+	case fileType.Equals(field.Type):
+		if arrow.TypeEqual(col.DataType(), dt) {
+			col.Retain()
+			return col, nil
+		}
+		// the same iceberg type may be stored with a different arrow
+		// layout, such as large strings
+		fallthrough
+	case iceberg.CanPromoteType(fileType, field.Type):
+		out, err := compute.CastArray(p.ctx, col, compute.SafeCastOptions(dt))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to promote field '%s' from %s to %s: %s",
+				iceberg.ErrType, field.Name, fileType, field.Type, err)
+		}
+		return out, nil
+	}
+	return nil, mismatch()
+}
+
+// rebuild returns an array of type dt which shares the buffers of data,
+// such as its validity bitmap and list offsets, with new children.
+func (p projector) rebuild(dt arrow.DataType, data arrow.ArrayData, children ...arrow.Array) arrow.Array {
+	childData := make([]arrow.ArrayData, len(children))
+	for i, c := range children {
+		childData[i] = c.Data()
+	}
+
+	out := array.NewData(dt, data.Len(), data.Buffers(), childData, data.NullN(), data.Offset())
+	defer out.Release()
+	return array.MakeFromData(out)
+}
+
+func releaseAll(arrs []arrow.Array) {
+	for _, a := range arrs {
+		a.Release()
+	}
+}
+
+type orderedArray[T cmp.Ordered] interface {
+	Value(int) T
+}
+
+func orderedComparator[T cmp.Ordered](arr orderedArray[T]) func(i, j int) int {
+	return func(i, j int) int { return cmp.Compare(arr.Value(i), arr.Value(j)) }
+}
+
+// arrowValueComparator returns a function comparing the non-null values at
+// two indices of arr, for the primitive arrow types iceberg columns are
+// converted to.
+func arrowValueComparator(arr arrow.Array) (func(i, j int) int, error) {
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return func(i, j int) int {
+			vi, vj := a.Value(i), a.Value(j)
+			switch {
+			case vi == vj:
+				return 0
+			case vj:
+				return -1
+			}
+			return 1
+		}, nil
+	case *array.Int32:
+		return orderedComparator[int32](a), nil
+	case *array.Int64:
+		return orderedComparator[int64](a), nil
+	case *array.Float32:
+		return orderedComparator[float32](a), nil
+	case *array.Float64:
+		return orderedComparator[float64](a), nil
+	case *array.Date32:
+		return orderedComparator[arrow.Date32](a), nil
+	case *array.Time64:
+		return orderedComparator[arrow.Time64](a), nil
+	case *array.Timestamp:
+		return orderedComparator[arrow.Timestamp](a), nil
+	case *array.String:
+		return orderedComparator[string](a), nil
+	case *array.LargeString:
+		return orderedComparator[string](a), nil
+	case *array.Binary:
+		return func(i, j int) int { return bytes.Compare(a.Value(i), a.Value(j)) }, nil
+	case *array.LargeBinary:
+		return func(i, j int) int { return bytes.Compare(a.Value(i), a.Value(j)) }, nil
+	case *array.FixedSizeBinary:
+		return func(i, j int) int { return bytes.Compare(a.Value(i), a.Value(j)) }, nil
+	case *array.Decimal128:
+		return func(i, j int) int { return a.Value(i).Cmp(a.Value(j)) }, nil
+	}
+
+	return nil, fmt.Errorf("%w: comparing values of arrow type %s",
+		iceberg.ErrNotImplemented, arr.DataType())
+}
@@ -0,0 +1,580 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v16/arrow"
+	"github.com/apache/arrow/go/v16/arrow/array"
+	"github.com/apache/arrow/go/v16/arrow/compute"
+	"github.com/apache/arrow/go/v16/arrow/decimal128"
+	"github.com/apache/arrow/go/v16/arrow/memory"
+	"github.com/apache/arrow/go/v16/parquet"
+	"github.com/apache/arrow/go/v16/parquet/file"
+	"github.com/apache/arrow/go/v16/parquet/pqarrow"
+	"github.com/apache/arrow/go/v16/parquet/schema"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var arrowTestSchema = iceberg.NewSchema(0,
+	iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true, Doc: "the id"},
+	iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+	iceberg.NestedField{ID: 3, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz},
+	iceberg.NestedField{ID: 4, Name: "ts_ns", Type: iceberg.PrimitiveTypes.TimestampNs},
+	iceberg.NestedField{ID: 5, Name: "tstz_ns", Type: iceberg.PrimitiveTypes.TimestampTzNs, Required: true},
+	iceberg.NestedField{ID: 6, Name: "amount", Type: iceberg.DecimalTypeOf(9, 2)},
+	iceberg.NestedField{ID: 7, Name: "tags", Type: &iceberg.ListType{
+		ElementID: 8, Element: iceberg.PrimitiveTypes.String, ElementRequired: true}},
+	iceberg.NestedField{ID: 9, Name: "props", Type: &iceberg.MapType{
+		KeyID: 10, KeyType: iceberg.PrimitiveTypes.String,
+		ValueID: 11, ValueType: iceberg.PrimitiveTypes.Int32}},
+	iceberg.NestedField{ID: 12, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+		{ID: 13, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+		{ID: 14, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+	}}},
+)
+
+func TestSchemaToArrowSchema(t *testing.T) {
+	sc, err := table.SchemaToArrowSchema(arrowTestSchema, map[string]string{"foo": "bar"}, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "bar", sc.Metadata().Values()[sc.Metadata().FindKey("foo")])
+	assert.True(t, arrow.TypeEqual(&arrow.TimestampType{Unit: arrow.Microsecond}, sc.Field(1).Type))
+	assert.True(t, arrow.TypeEqual(&arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, sc.Field(2).Type))
+	assert.True(t, arrow.TypeEqual(&arrow.TimestampType{Unit: arrow.Nanosecond}, sc.Field(3).Type))
+	assert.True(t, arrow.TypeEqual(&arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}, sc.Field(4).Type))
+	assert.False(t, sc.Field(4).Nullable)
+
+	idx := sc.Field(0).Metadata.FindKey(table.ArrowParquetFieldIDKey)
+	require.NotEqual(t, -1, idx)
+	assert.Equal(t, "1", sc.Field(0).Metadata.Values()[idx])
+
+	roundTrip, err := table.ArrowSchemaToIceberg(sc)
+	require.NoError(t, err)
+	assert.Truef(t, arrowTestSchema.Equals(roundTrip), "expected: %s\ngot: %s", arrowTestSchema, roundTrip)
+
+	noIDs, err := table.SchemaToArrowSchema(arrowTestSchema, nil, false)
+	require.NoError(t, err)
+	_, err = table.ArrowSchemaToIceberg(noIDs)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
+
+func TestArrowFieldIDParquetRoundTrip(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 3, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 7, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 12, Name: "amount", Type: iceberg.DecimalTypeOf(9, 2)},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true, table.WithArrowLegacyFieldIDs())
+	require.NoError(t, err)
+
+	md := arrSchema.Field(0).Metadata
+	assert.Equal(t, "3", md.Values()[md.FindKey(table.ArrowParquetFieldIDKey)])
+	assert.Equal(t, "3", md.Values()[md.FindKey(table.ArrowLegacyFieldIDKey)])
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewRecordBuilder(mem, arrSchema)
+	defer bldr.Release()
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+	defer tbl.Release()
+	require.NoError(t, pqarrow.WriteTable(tbl, &buf, 1024, nil, pqarrow.DefaultWriterProps()))
+
+	rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	pqSchema := rdr.MetaData().Schema
+	ids := make([]int32, pqSchema.NumColumns())
+	for i := range ids {
+		ids[i] = pqSchema.Column(i).SchemaNode().FieldID()
+	}
+	assert.Equal(t, []int32{3, 7, 12}, ids)
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, mem)
+	require.NoError(t, err)
+
+	readArrowSchema, err := fr.Schema()
+	require.NoError(t, err)
+	readSchema, err := table.ArrowSchemaToIceberg(readArrowSchema)
+	require.NoError(t, err)
+	assert.Truef(t, sc.Equals(readSchema), "expected: %s\ngot: %s", sc, readSchema)
+}
+
+func TestArrowSchemaLegacyFieldIDKey(t *testing.T) {
+	sc := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true,
+			Metadata: arrow.NewMetadata([]string{table.ArrowLegacyFieldIDKey}, []string{"7"})},
+	}, nil)
+
+	result, err := table.ArrowSchemaToIceberg(sc)
+	require.NoError(t, err)
+	assert.True(t, result.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 7, Name: "id", Type: iceberg.PrimitiveTypes.Int64})))
+
+	noLegacy, err := table.SchemaToArrowSchema(arrowTestSchema, nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, -1, noLegacy.Field(0).Metadata.FindKey(table.ArrowLegacyFieldIDKey))
+}
+
+func TestArrowTimestampParquetRoundTrip(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp, Required: true},
+		iceberg.NestedField{ID: 2, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz, Required: true},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true)
+	require.NoError(t, err)
+	assert.True(t, arrow.TypeEqual(&arrow.TimestampType{Unit: arrow.Microsecond}, arrSchema.Field(0).Type))
+	assert.True(t, arrow.TypeEqual(&arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, arrSchema.Field(1).Type))
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewRecordBuilder(mem, arrSchema)
+	defer bldr.Release()
+
+	vals := []arrow.Timestamp{1503066061919234, -1, 0}
+	bldr.Field(0).(*array.TimestampBuilder).AppendValues(vals, nil)
+	bldr.Field(1).(*array.TimestampBuilder).AppendValues(vals, nil)
+
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+	defer tbl.Release()
+	require.NoError(t, pqarrow.WriteTable(tbl, &buf, 1024, nil, pqarrow.DefaultWriterProps()))
+
+	rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	pqSchema := rdr.MetaData().Schema
+	for i, adjusted := range []bool{false, true} {
+		logical, ok := pqSchema.Column(i).LogicalType().(*schema.TimestampLogicalType)
+		require.True(t, ok)
+		assert.Equal(t, schema.TimeUnitMicros, logical.TimeUnit())
+		assert.Equal(t, adjusted, logical.IsAdjustedToUTC())
+	}
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, mem)
+	require.NoError(t, err)
+
+	result, err := fr.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer result.Release()
+
+	readSchema, err := table.ArrowSchemaToIceberg(result.Schema())
+	require.NoError(t, err)
+	assert.Truef(t, sc.Equals(readSchema), "expected: %s\ngot: %s", sc, readSchema)
+
+	for i := 0; i < 2; i++ {
+		col := result.Column(i).Data().Chunk(0).(*array.Timestamp)
+		assert.Equal(t, vals, col.TimestampValues())
+	}
+}
+
+func TestArrowTimestampNsParquetRoundTrip(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "ts_ns", Type: iceberg.PrimitiveTypes.TimestampNs, Required: true},
+		iceberg.NestedField{ID: 2, Name: "tstz_ns", Type: iceberg.PrimitiveTypes.TimestampTzNs},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true)
+	require.NoError(t, err)
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewRecordBuilder(mem, arrSchema)
+	defer bldr.Release()
+
+	vals := []arrow.Timestamp{1503066061919234567, -1, 0}
+	bldr.Field(0).(*array.TimestampBuilder).AppendValues(vals, nil)
+	bldr.Field(1).(*array.TimestampBuilder).AppendValues(vals, []bool{true, false, true})
+
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+	defer tbl.Release()
+	require.NoError(t, pqarrow.WriteTable(tbl, &buf, 1024, nil, pqarrow.DefaultWriterProps()))
+
+	rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, mem)
+	require.NoError(t, err)
+
+	result, err := fr.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer result.Release()
+
+	readSchema, err := table.ArrowSchemaToIceberg(result.Schema())
+	require.NoError(t, err)
+	assert.Truef(t, sc.Equals(readSchema), "expected: %s\ngot: %s", sc, readSchema)
+
+	col := result.Column(0).Data().Chunk(0).(*array.Timestamp)
+	assert.Equal(t, vals, col.TimestampValues())
+	tzCol := result.Column(1).Data().Chunk(0).(*array.Timestamp)
+	assert.True(t, tzCol.IsNull(1))
+	assert.Equal(t, vals[2], tzCol.Value(2))
+}
+
+func TestArrowUUIDAndFixedParquetRoundTrip(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "uuid", Type: iceberg.PrimitiveTypes.UUID, Required: true},
+		iceberg.NestedField{ID: 2, Name: "fixed", Type: iceberg.FixedTypeOf(12)},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true)
+	require.NoError(t, err)
+	assert.True(t, arrow.TypeEqual(&arrow.FixedSizeBinaryType{ByteWidth: 16}, arrSchema.Field(0).Type))
+	assert.True(t, arrow.TypeEqual(&arrow.FixedSizeBinaryType{ByteWidth: 12}, arrSchema.Field(1).Type))
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewRecordBuilder(mem, arrSchema)
+	defer bldr.Release()
+
+	id := uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")
+	uuids := [][]byte{id[:], make([]byte, 16)}
+	fixed := [][]byte{[]byte("abcdefghijkl"), {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0xff}}
+	bldr.Field(0).(*array.FixedSizeBinaryBuilder).AppendValues(uuids, nil)
+	bldr.Field(1).(*array.FixedSizeBinaryBuilder).AppendValues(fixed, nil)
+
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	// readBack writes the record to parquet and reads it back as a record
+	// which must be released
+	readBack := func(t *testing.T, props pqarrow.ArrowWriterProperties) arrow.Record {
+		var buf bytes.Buffer
+		tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+		defer tbl.Release()
+		require.NoError(t, pqarrow.WriteTable(tbl, &buf, 1024, nil, props))
+
+		rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		defer rdr.Close()
+
+		pqSchema := rdr.MetaData().Schema
+		for i, width := range []int{16, 12} {
+			col := pqSchema.Column(i)
+			assert.Equal(t, parquet.Types.FixedLenByteArray, col.PhysicalType())
+			assert.Equal(t, width, col.TypeLength())
+			assert.EqualValues(t, i+1, col.SchemaNode().FieldID())
+		}
+
+		fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, mem)
+		require.NoError(t, err)
+
+		result, err := fr.ReadTable(context.Background())
+		require.NoError(t, err)
+		defer result.Release()
+
+		cols := []arrow.Array{result.Column(0).Data().Chunk(0), result.Column(1).Data().Chunk(0)}
+		return array.NewRecord(result.Schema(), cols, result.NumRows())
+	}
+
+	for _, tt := range []struct {
+		name     string
+		props    pqarrow.ArrowWriterProperties
+		fileType iceberg.Type
+	}{
+		{"stored arrow schema", pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()),
+			iceberg.PrimitiveTypes.UUID},
+		{"parquet schema only", pqarrow.DefaultWriterProps(), iceberg.FixedTypeOf(16)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fileRec := readBack(t, tt.props)
+			defer fileRec.Release()
+
+			fileSchema, err := table.ArrowSchemaToIceberg(fileRec.Schema())
+			require.NoError(t, err)
+			assert.Equal(t, tt.fileType, fileSchema.Field(0).Type)
+			assert.Equal(t, iceberg.FixedTypeOf(12), fileSchema.Field(1).Type)
+
+			result, err := table.ToRequestedSchema(compute.WithAllocator(context.Background(), mem), sc, fileRec)
+			require.NoError(t, err)
+			defer result.Release()
+
+			readSchema, err := table.ArrowSchemaToIceberg(result.Schema())
+			require.NoError(t, err)
+			assert.Truef(t, sc.Equals(readSchema), "expected: %s\ngot: %s", sc, readSchema)
+
+			uuidCol := result.Column(0).(*array.FixedSizeBinary)
+			fixedCol := result.Column(1).(*array.FixedSizeBinary)
+			for i := range uuids {
+				assert.Equal(t, uuids[i], uuidCol.Value(i))
+				assert.Equal(t, fixed[i], fixedCol.Value(i))
+			}
+
+			// values compare by their bytes, the same as manifest bounds
+			lower, err := iceberg.LiteralFromBytes(iceberg.PrimitiveTypes.UUID, uuidCol.Value(1))
+			require.NoError(t, err)
+			upper, err := iceberg.LiteralFromBytes(iceberg.PrimitiveTypes.UUID, uuidCol.Value(0))
+			require.NoError(t, err)
+			assert.Equal(t, iceberg.NewLiteral(id), upper)
+			assert.Equal(t, -1, lower.(iceberg.UUIDLiteral).Comparator()(
+				lower.(iceberg.UUIDLiteral).Value(), upper.(iceberg.UUIDLiteral).Value()))
+		})
+	}
+}
+
+func TestSchemaToArrowSchemaV3Types(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "unknown", Type: iceberg.PrimitiveTypes.Unknown},
+		iceberg.NestedField{ID: 2, Name: "variant", Type: iceberg.PrimitiveTypes.Variant},
+		iceberg.NestedField{ID: 3, Name: "geom", Type: iceberg.GeometryTypeOf("EPSG:4326"), Required: true},
+		iceberg.NestedField{ID: 4, Name: "geog", Type: iceberg.GeographyTypeOf("", "")},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, arrow.Null, arrSchema.Field(0).Type)
+	for i := 1; i < 4; i++ {
+		f := arrSchema.Field(i)
+		assert.Equal(t, arrow.BinaryTypes.Binary, f.Type)
+		idx := f.Metadata.FindKey(table.ArrowIcebergTypeKey)
+		require.NotEqual(t, -1, idx)
+		assert.Equal(t, sc.Field(i).Type.String(), f.Metadata.Values()[idx])
+	}
+
+	roundTrip, err := table.ArrowSchemaToIceberg(arrSchema)
+	require.NoError(t, err)
+	assert.Truef(t, sc.Equals(roundTrip), "expected: %s\ngot: %s", sc, roundTrip)
+}
+
+func TestToRequestedSchemaAfterPromotion(t *testing.T) {
+	fileSchema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "price", Type: iceberg.DecimalTypeOf(10, 2)},
+		iceberg.NestedField{ID: 3, Name: "score", Type: iceberg.PrimitiveTypes.Float32},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(fileSchema, nil, true)
+	require.NoError(t, err)
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	bldr := array.NewRecordBuilder(mem, arrSchema)
+	defer bldr.Release()
+
+	bldr.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	bldr.Field(1).(*array.Decimal128Builder).AppendValues(
+		[]decimal128.Num{decimal128.FromI64(12345), decimal128.FromI64(-1), {}},
+		[]bool{true, true, false})
+	bldr.Field(2).(*array.Float32Builder).AppendValues([]float32{0.5, 1.5, 2.5}, nil)
+
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+	defer tbl.Release()
+	require.NoError(t, pqarrow.WriteTable(tbl, &buf, 1024, nil, pqarrow.DefaultWriterProps()))
+
+	rdr, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, mem)
+	require.NoError(t, err)
+
+	result, err := fr.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer result.Release()
+
+	tr := array.NewTableReader(result, -1)
+	defer tr.Release()
+	require.True(t, tr.Next())
+	stored := tr.Record()
+
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	t.Run("promoted", func(t *testing.T) {
+		current := iceberg.NewSchema(1,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+			iceberg.NestedField{ID: 2, Name: "amount", Type: iceberg.DecimalTypeOf(18, 2)},
+			iceberg.NestedField{ID: 3, Name: "score", Type: iceberg.PrimitiveTypes.Float64},
+			iceberg.NestedField{ID: 4, Name: "note", Type: iceberg.PrimitiveTypes.String},
+		)
+
+		out, err := table.ToRequestedSchema(ctx, current, stored)
+		require.NoError(t, err)
+		defer out.Release()
+
+		outSchema, err := table.ArrowSchemaToIceberg(out.Schema())
+		require.NoError(t, err)
+		assert.Truef(t, current.Equals(outSchema), "expected: %s\ngot: %s", current, outSchema)
+
+		assert.Equal(t, []int64{1, 2, 3}, out.Column(0).(*array.Int64).Int64Values())
+		amount := out.Column(1).(*array.Decimal128)
+		assert.Equal(t, decimal128.FromI64(12345), amount.Value(0))
+		assert.Equal(t, decimal128.FromI64(-1), amount.Value(1))
+		assert.True(t, amount.IsNull(2))
+		assert.Equal(t, []float64{0.5, 1.5, 2.5}, out.Column(2).(*array.Float64).Float64Values())
+		assert.Equal(t, 3, out.Column(3).NullN())
+	})
+
+	t.Run("disallowed", func(t *testing.T) {
+		_, err := table.ToRequestedSchema(ctx, iceberg.NewSchema(1,
+			iceberg.NestedField{ID: 2, Name: "price", Type: iceberg.DecimalTypeOf(18, 4)},
+		), stored)
+		assert.ErrorIs(t, err, iceberg.ErrType)
+		assert.ErrorContains(t, err, "cannot read field 'price' of type decimal(10, 2) as decimal(18, 4)")
+
+		_, err = table.ToRequestedSchema(ctx, iceberg.NewSchema(1,
+			iceberg.NestedField{ID: 5, Name: "missing", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		), stored)
+		assert.ErrorIs(t, err, iceberg.ErrType)
+	})
+}
+
+func TestToRequestedSchemaNestedAddition(t *testing.T) {
+	fileSchema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 2, Name: "lat", Type: iceberg.PrimitiveTypes.Float32, Required: true},
+		}}},
+		iceberg.NestedField{ID: 3, Name: "points", Type: &iceberg.ListType{
+			ElementID: 4, ElementRequired: true, Element: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 5, Name: "x", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+			}}}},
+		iceberg.NestedField{ID: 6, Name: "props", Type: &iceberg.MapType{
+			KeyID: 7, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 8, ValueRequired: true, ValueType: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 9, Name: "a", Type: iceberg.PrimitiveTypes.Int32},
+			}}}},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(fileSchema, nil, true)
+	require.NoError(t, err)
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// a record as it was stored before fields were added to the nested
+	// structs
+	stored, _, err := array.RecordFromJSON(mem, arrSchema, strings.NewReader(`[
+		{"location": {"lat": 1.5}, "points": [{"x": 1}, {"x": 2}], "props": [{"key": "k", "value": {"a": 1}}]},
+		{"location": null, "points": [], "props": []},
+		{"location": {"lat": 2.5}, "points": null, "props": [{"key": "j", "value": {"a": null}}]}
+	]`))
+	require.NoError(t, err)
+	defer stored.Release()
+
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	current := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 2, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			{ID: 10, Name: "lon", Type: iceberg.PrimitiveTypes.Float64},
+		}}},
+		iceberg.NestedField{ID: 3, Name: "points", Type: &iceberg.ListType{
+			ElementID: 4, ElementRequired: true, Element: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 5, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+				{ID: 11, Name: "y", Type: iceberg.PrimitiveTypes.Int32, Required: true, InitialDefault: 7},
+			}}}},
+		iceberg.NestedField{ID: 6, Name: "props", Type: &iceberg.MapType{
+			KeyID: 7, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 8, ValueRequired: true, ValueType: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 12, Name: "b", Type: iceberg.PrimitiveTypes.String, InitialDefault: "none"},
+				{ID: 9, Name: "a", Type: iceberg.PrimitiveTypes.Int32},
+			}}}},
+	)
+
+	out, err := table.ToRequestedSchema(ctx, current, stored)
+	require.NoError(t, err)
+	defer out.Release()
+
+	expectedSchema, err := table.SchemaToArrowSchema(current, nil, true)
+	require.NoError(t, err)
+	assert.Truef(t, expectedSchema.Equal(out.Schema()), "expected: %s\ngot: %s", expectedSchema, out.Schema())
+
+	expected := []string{
+		`[{"lat": 1.5, "lon": null}, null, {"lat": 2.5, "lon": null}]`,
+		`[[{"x": 1, "y": 7}, {"x": 2, "y": 7}], [], null]`,
+		`[[{"key": "k", "value": {"b": "none", "a": 1}}], [],
+		  [{"key": "j", "value": {"b": "none", "a": null}}]]`,
+	}
+	for i, exp := range expected {
+		data, err := json.Marshal(out.Column(i))
+		require.NoError(t, err)
+		assert.JSONEq(t, exp, string(data), out.Schema().Field(i).Name)
+	}
+
+	t.Run("missing required", func(t *testing.T) {
+		_, err := table.ToRequestedSchema(ctx, iceberg.NewSchema(1,
+			iceberg.NestedField{ID: 1, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 13, Name: "alt", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			}}}), stored)
+		assert.ErrorIs(t, err, iceberg.ErrType)
+		assert.ErrorContains(t, err, "required field 'alt' (id 13) is missing from file")
+	})
+}
+
+func TestRowIDs(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	positions, _, err := array.FromJSON(mem, arrow.PrimitiveTypes.Int64,
+		strings.NewReader(`[0, 1, 3, 4]`))
+	require.NoError(t, err)
+	defer positions.Release()
+	stored, _, err := array.FromJSON(mem, arrow.PrimitiveTypes.Int64,
+		strings.NewReader(`[null, 7, null, null]`))
+	require.NoError(t, err)
+	defer stored.Release()
+
+	first := int64(100)
+	ids := table.RowIDs(mem, &first, positions.(*array.Int64), stored.(*array.Int64))
+	defer ids.Release()
+	assert.Equal(t, `[100 7 103 104]`, ids.String())
+
+	// without stored row IDs, every row is assigned one by its position
+	ids = table.RowIDs(mem, &first, positions.(*array.Int64), nil)
+	defer ids.Release()
+	assert.Equal(t, `[100 101 103 104]`, ids.String())
+
+	// files without a first row ID have no lineage for unassigned rows
+	ids = table.RowIDs(mem, nil, positions.(*array.Int64), stored.(*array.Int64))
+	defer ids.Release()
+	assert.Equal(t, `[(null) 7 (null) (null)]`, ids.String())
+}
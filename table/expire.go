@@ -0,0 +1,259 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// PropertyMaxSnapshotAgeMs is the default age of the oldest snapshot
+	// kept in the history of each branch when expiring snapshots.
+	PropertyMaxSnapshotAgeMs        = "history.expire.max-snapshot-age-ms"
+	PropertyMaxSnapshotAgeMsDefault = int64(5 * 24 * time.Hour / time.Millisecond)
+	// PropertyMinSnapshotsToKeep is the default number of snapshots kept
+	// in the history of each branch when expiring snapshots, regardless
+	// of their age.
+	PropertyMinSnapshotsToKeep        = "history.expire.min-snapshots-to-keep"
+	PropertyMinSnapshotsToKeepDefault = 1
+	// PropertyMaxRefAgeMs is the default age of the snapshot of a branch
+	// or tag after which the ref itself is dropped when expiring
+	// snapshots. The main branch is never dropped. By default refs are
+	// kept forever.
+	PropertyMaxRefAgeMs = "history.expire.max-ref-age-ms"
+)
+
+// ExpireSnapshots collects the options to remove old snapshots from the
+// table's metadata, which are applied by Commit.
+//
+// The snapshots to keep are computed per branch: a branch keeps the most
+// recent snapshots of its history up to its min-snapshots-to-keep and
+// any newer than its max-snapshot-age-ms. Refs without these settings use
+// the table properties. RetainLast and ExpireOlderThan add a policy which
+// applies to every branch, a snapshot is kept if either the branch's own
+// policy or this one keeps it. Tags keep the snapshot they point to, and
+// branches and tags whose snapshot is older than their max-ref-age-ms
+// are dropped. Snapshots not referenced by any branch or tag are kept
+// while they are newer than ExpireOlderThan.
+//
+// Only the metadata is changed, files which are no longer referenced are
+// not deleted.
+type ExpireSnapshots struct {
+	tbl *Table
+
+	olderThan  *time.Time
+	retainLast int
+	ids        []int64
+}
+
+// ExpireSnapshots begins expiring the snapshots of the table.
+func (t Table) ExpireSnapshots() *ExpireSnapshots {
+	return &ExpireSnapshots{tbl: &t}
+}
+
+// ExpireOlderThan expires snapshots older than ts from every branch,
+// subject to the retention policies of the branches.
+func (e *ExpireSnapshots) ExpireOlderThan(ts time.Time) *ExpireSnapshots {
+	e.olderThan = &ts
+	return e
+}
+
+// RetainLast keeps at least the n most recent snapshots of every branch.
+func (e *ExpireSnapshots) RetainLast(n int) *ExpireSnapshots {
+	e.retainLast = n
+	return e
+}
+
+// ExpireSnapshotID expires the given snapshot, which must not be the
+// snapshot of a branch or tag that is kept.
+func (e *ExpireSnapshots) ExpireSnapshotID(id int64) *ExpireSnapshots {
+	e.ids = append(e.ids, id)
+	return e
+}
+
+// ExpirePlan is the outcome of expiring snapshots.
+type ExpirePlan struct {
+	// ExpiredSnapshots are the ids of the removed snapshots, in
+	// ascending order.
+	ExpiredSnapshots []int64
+	// DroppedRefs are the names of the removed branches and tags, in
+	// ascending order.
+	DroppedRefs []string
+}
+
+func propertyInt64(props iceberg.Properties, key string, def int64) int64 {
+	if v, err := strconv.ParseInt(props[key], 10, 64); err == nil {
+		return v
+	}
+	return def
+}
+
+// retentionPolicy keeps snapshots which are among the newest minSnapshots
+// of a branch or newer than olderThanMs.
+type retentionPolicy struct {
+	minSnapshots int
+	olderThanMs  int64
+}
+
+func (p retentionPolicy) keeps(idx int, snap Snapshot) bool {
+	return idx < p.minSnapshots || snap.TimestampMs >= p.olderThanMs
+}
+
+// Plan computes the snapshots and refs to remove, without changing the
+// table.
+func (e *ExpireSnapshots) Plan() (ExpirePlan, error) {
+	meta := e.tbl.metadata
+	props := meta.Properties()
+	nowMs := e.tbl.Clock().Now().UnixMilli()
+
+	global := retentionPolicy{
+		minSnapshots: int(propertyInt64(props, PropertyMinSnapshotsToKeep, PropertyMinSnapshotsToKeepDefault)),
+		olderThanMs:  nowMs - propertyInt64(props, PropertyMaxSnapshotAgeMs, PropertyMaxSnapshotAgeMsDefault),
+	}
+	defaultMaxRefAgeMs := propertyInt64(props, PropertyMaxRefAgeMs, -1)
+
+	// the explicit options form a policy on top of the per-branch ones
+	var explicit *retentionPolicy
+	if e.olderThan != nil || e.retainLast > 0 {
+		explicit = &retentionPolicy{minSnapshots: max(e.retainLast, 1), olderThanMs: global.olderThanMs}
+		if e.olderThan != nil {
+			explicit.olderThanMs = e.olderThan.UnixMilli()
+		}
+	}
+
+	var plan ExpirePlan
+	retained := make(map[int64]struct{})
+	for name, ref := range meta.SnapshotRefs() {
+		snap := meta.SnapshotByID(ref.SnapshotID)
+		maxRefAgeMs := defaultMaxRefAgeMs
+		if ref.MaxRefAgeMs != nil {
+			maxRefAgeMs = *ref.MaxRefAgeMs
+		}
+
+		if name != MainBranch && (snap == nil || (maxRefAgeMs >= 0 && nowMs-snap.TimestampMs > maxRefAgeMs)) {
+			plan.DroppedRefs = append(plan.DroppedRefs, name)
+			continue
+		}
+		if snap == nil {
+			continue
+		}
+
+		if ref.SnapshotRefType == TagRef {
+			retained[snap.SnapshotID] = struct{}{}
+			continue
+		}
+
+		policy := global
+		if ref.MinSnapshotsToKeep != nil {
+			policy.minSnapshots = *ref.MinSnapshotsToKeep
+		}
+		if ref.MaxSnapshotAgeMs != nil {
+			policy.olderThanMs = nowMs - *ref.MaxSnapshotAgeMs
+		}
+
+		for i, s := range AncestorsOf(meta, snap.SnapshotID) {
+			if i == 0 || policy.keeps(i, s) || (explicit != nil && explicit.keeps(i, s)) {
+				retained[s.SnapshotID] = struct{}{}
+			}
+		}
+	}
+
+	referenced := make(map[int64]struct{})
+	for name, ref := range meta.SnapshotRefs() {
+		if slices.Contains(plan.DroppedRefs, name) {
+			continue
+		}
+		for _, s := range AncestorsOf(meta, ref.SnapshotID) {
+			referenced[s.SnapshotID] = struct{}{}
+		}
+	}
+
+	unreferencedOlderThanMs := global.olderThanMs
+	if explicit != nil {
+		unreferencedOlderThanMs = explicit.olderThanMs
+	}
+	for _, s := range meta.Snapshots() {
+		if _, ok := referenced[s.SnapshotID]; !ok && s.TimestampMs >= unreferencedOlderThanMs {
+			retained[s.SnapshotID] = struct{}{}
+		}
+	}
+
+	for _, id := range e.ids {
+		if meta.SnapshotByID(id) == nil {
+			continue
+		}
+		for name, ref := range meta.SnapshotRefs() {
+			if ref.SnapshotID == id && !slices.Contains(plan.DroppedRefs, name) {
+				return ExpirePlan{}, fmt.Errorf("%w: cannot expire snapshot %d, it is referenced by %s",
+					iceberg.ErrInvalidArgument, id, name)
+			}
+		}
+		delete(retained, id)
+	}
+
+	for _, s := range meta.Snapshots() {
+		if _, ok := retained[s.SnapshotID]; !ok {
+			plan.ExpiredSnapshots = append(plan.ExpiredSnapshots, s.SnapshotID)
+		}
+	}
+
+	slices.Sort(plan.DroppedRefs)
+	slices.Sort(plan.ExpiredSnapshots)
+	return plan, nil
+}
+
+// Commit removes the expired snapshots and dropped refs through the
+// catalog the table was loaded from, and returns the updated table.
+func (e *ExpireSnapshots) Commit(ctx context.Context) (*Table, error) {
+	plan, err := e.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plan.ExpiredSnapshots) == 0 && len(plan.DroppedRefs) == 0 {
+		return e.tbl, nil
+	}
+
+	var updates []Update
+	for _, name := range plan.DroppedRefs {
+		updates = append(updates, NewRemoveSnapshotRefUpdate(name))
+	}
+	if len(plan.ExpiredSnapshots) > 0 {
+		updates = append(updates, NewRemoveSnapshotsUpdate(plan.ExpiredSnapshots))
+	}
+
+	var current *int64
+	if snap := e.tbl.CurrentSnapshot(); snap != nil {
+		current = &snap.SnapshotID
+	}
+
+	txn, err := e.tbl.NewTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.apply(updates, []Requirement{AssertRefSnapshotID(MainBranch, current)}); err != nil {
+		return nil, err
+	}
+	return txn.Commit(ctx)
+}
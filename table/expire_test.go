@@ -0,0 +1,171 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const day = int64(24 * time.Hour / time.Millisecond)
+
+// expireTestMetadata has the history below, where main is at snapshot 4,
+// the audit branch keeps three snapshots, the short branch keeps an hour
+// of history, the old-tag ref expires after ten days and snapshots 9 and
+// 10 aren't referenced by any branch or tag.
+//
+//	1 (80d) - 2 (90d) - 3 (97d, release) - 4 (99d, main) - 7 (99.5d) - 8 (99.9d, short)
+//	          \- 5 (91d) - 6 (92d, audit)
+var expireTestMetadata = fmt.Sprintf(`{
+	"format-version": 2,
+	"table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+	"location": "s3://bucket/test/location",
+	"last-sequence-number": 10,
+	"last-updated-ms": %[1]d,
+	"last-column-id": 1,
+	"current-schema-id": 0,
+	"schemas": [{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "type": "long", "required": true}]}],
+	"default-spec-id": 0,
+	"partition-specs": [{"spec-id": 0, "fields": []}],
+	"last-partition-id": 999,
+	"default-sort-order-id": 0,
+	"sort-orders": [{"order-id": 0, "fields": []}],
+	"current-snapshot-id": 4,
+	"snapshots": [
+		{"snapshot-id": 1, "sequence-number": 1, "timestamp-ms": %[2]d},
+		{"snapshot-id": 2, "parent-snapshot-id": 1, "sequence-number": 2, "timestamp-ms": %[3]d},
+		{"snapshot-id": 3, "parent-snapshot-id": 2, "sequence-number": 3, "timestamp-ms": %[4]d},
+		{"snapshot-id": 4, "parent-snapshot-id": 3, "sequence-number": 4, "timestamp-ms": %[5]d},
+		{"snapshot-id": 5, "parent-snapshot-id": 2, "sequence-number": 5, "timestamp-ms": %[6]d},
+		{"snapshot-id": 6, "parent-snapshot-id": 5, "sequence-number": 6, "timestamp-ms": %[7]d},
+		{"snapshot-id": 7, "parent-snapshot-id": 4, "sequence-number": 7, "timestamp-ms": %[8]d},
+		{"snapshot-id": 8, "parent-snapshot-id": 7, "sequence-number": 8, "timestamp-ms": %[9]d},
+		{"snapshot-id": 9, "sequence-number": 9, "timestamp-ms": %[10]d},
+		{"snapshot-id": 10, "sequence-number": 10, "timestamp-ms": %[11]d}
+	],
+	"refs": {
+		"main": {"snapshot-id": 4, "type": "branch"},
+		"audit": {"snapshot-id": 6, "type": "branch", "min-snapshots-to-keep": 3},
+		"short": {"snapshot-id": 8, "type": "branch", "max-snapshot-age-ms": %[12]d},
+		"release": {"snapshot-id": 3, "type": "tag"},
+		"old-tag": {"snapshot-id": 1, "type": "tag", "max-ref-age-ms": %[13]d}
+	}
+}`, 100*day, 80*day, 90*day, 97*day, 99*day, 91*day, 92*day,
+	99*day+day/2, 100*day-day/10, 98*day, 50*day,
+	int64(time.Hour/time.Millisecond), 10*day)
+
+func expireTestTable(t *testing.T) (*table.Table, *memCatalog) {
+	meta, err := table.ParseMetadataString(expireTestMetadata)
+	require.NoError(t, err)
+
+	cat := &memCatalog{meta: meta, location: "s3://bucket/test/location/v1.metadata.json"}
+	tbl, err := cat.LoadTable(context.Background(), table.Identifier{"db", "tbl"}, nil)
+	require.NoError(t, err)
+	return tbl.WithClock(table.ClockFunc(func() time.Time { return time.UnixMilli(100 * day) })), cat
+}
+
+func TestExpireSnapshotsPlan(t *testing.T) {
+	tbl, _ := expireTestTable(t)
+
+	tests := []struct {
+		name    string
+		expire  func(*table.ExpireSnapshots) *table.ExpireSnapshots
+		expired []int64
+	}{
+		{"ref policies", func(e *table.ExpireSnapshots) *table.ExpireSnapshots { return e },
+			[]int64{1, 7, 10}},
+		{"retain last", func(e *table.ExpireSnapshots) *table.ExpireSnapshots { return e.RetainLast(4) },
+			[]int64{10}},
+		{"older than", func(e *table.ExpireSnapshots) *table.ExpireSnapshots {
+			return e.ExpireOlderThan(time.UnixMilli(99 * day))
+		}, []int64{1, 9, 10}},
+		{"older than keeps branch policies", func(e *table.ExpireSnapshots) *table.ExpireSnapshots {
+			return e.ExpireOlderThan(time.UnixMilli(100 * day))
+		}, []int64{1, 7, 9, 10}},
+		{"explicit id", func(e *table.ExpireSnapshots) *table.ExpireSnapshots { return e.ExpireSnapshotID(9) },
+			[]int64{1, 7, 9, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := tt.expire(tbl.ExpireSnapshots()).Plan()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expired, plan.ExpiredSnapshots)
+			assert.Equal(t, []string{"old-tag"}, plan.DroppedRefs)
+		})
+	}
+
+	_, err := tbl.ExpireSnapshots().ExpireSnapshotID(3).Plan()
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "referenced by release")
+}
+
+func TestExpireSnapshotsTableDefaults(t *testing.T) {
+	tbl, _ := expireTestTable(t)
+
+	meta, err := table.MetadataBuilderFromBase(tbl.Metadata())
+	require.NoError(t, err)
+	_, err = meta.SetProperties(iceberg.Properties{
+		table.PropertyMaxSnapshotAgeMs:   fmt.Sprint(day / 2),
+		table.PropertyMinSnapshotsToKeep: "2",
+		table.PropertyMaxRefAgeMs:        fmt.Sprint(2 * day),
+	})
+	require.NoError(t, err)
+	built, err := meta.Build()
+	require.NoError(t, err)
+
+	plan, err := table.New(tbl.Identifier(), built, tbl.MetadataLocation(), nil).
+		WithClock(tbl.Clock()).ExpireSnapshots().Plan()
+	require.NoError(t, err)
+	// only main and short are young enough to be kept, each with two
+	// snapshots, while the unreferenced snapshot 9 is too old
+	assert.Equal(t, []int64{1, 2, 5, 6, 9, 10}, plan.ExpiredSnapshots)
+	assert.Equal(t, []string{"audit", "old-tag", "release"}, plan.DroppedRefs)
+}
+
+func TestExpireSnapshotsCommit(t *testing.T) {
+	tbl, cat := expireTestTable(t)
+
+	updated, err := tbl.ExpireSnapshots().Commit(context.Background())
+	require.NoError(t, err)
+
+	var ids []int64
+	for _, s := range updated.Metadata().Snapshots() {
+		ids = append(ids, s.SnapshotID)
+	}
+	assert.Equal(t, []int64{2, 3, 4, 5, 6, 8, 9}, ids)
+	assert.NotContains(t, updated.Metadata().SnapshotRefs(), "old-tag")
+	assert.Contains(t, updated.Metadata().SnapshotRefs(), "short")
+	assert.EqualValues(t, 4, updated.CurrentSnapshot().SnapshotID)
+
+	require.Len(t, cat.updates, 2)
+	assert.Equal(t, table.UpdateRemoveSnapshotRef, cat.updates[0].Action())
+	assert.Equal(t, table.UpdateRemoveSnapshots, cat.updates[1].Action())
+
+	// nothing is left to expire
+	again, err := updated.ExpireSnapshots().Commit(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, updated.MetadataLocation(), again.MetadataLocation())
+}
@@ -0,0 +1,289 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+)
+
+// ErrCommitConflict is returned when committing to a Hadoop table if
+// another writer committed the same metadata version first.
+var ErrCommitConflict = errors.New("concurrent commit conflict")
+
+// CommitConflictError is returned when a new metadata version of a Hadoop
+// table already exists, because another writer committed concurrently. It
+// wraps ErrCommitConflict, and the commit can be retried after refreshing
+// the table.
+type CommitConflictError struct {
+	// Location is the metadata file which already existed.
+	Location string
+}
+
+func (e *CommitConflictError) Error() string {
+	return fmt.Sprintf("%s: metadata file %s already exists", ErrCommitConflict, e.Location)
+}
+
+func (e *CommitConflictError) Unwrap() error { return ErrCommitConflict }
+
+// Retryable always returns true, a conflict means the table changed
+// concurrently and the changes can be applied to the refreshed table.
+func (e *CommitConflictError) Retryable() bool { return true }
+
+const (
+	// HadoopVersionHintFile is the file in the metadata directory of a
+	// Hadoop table holding the version of its current metadata file.
+	HadoopVersionHintFile = "version-hint.text"
+
+	hadoopMetadataSuffix = ".metadata.json"
+)
+
+// hadoopMetadataVersion returns the version N of a metadata file named
+// vN.metadata.json, or false if the name doesn't have that form.
+func hadoopMetadataVersion(name string) (int, bool) {
+	if !strings.HasPrefix(name, "v") || !strings.HasSuffix(name, hadoopMetadataSuffix) {
+		return 0, false
+	}
+
+	v, err := strconv.Atoi(strings.TrimSuffix(name[1:], hadoopMetadataSuffix))
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+func hadoopMetadataFile(metadataDir string, version int) string {
+	return metadataDir + "/v" + strconv.Itoa(version) + hadoopMetadataSuffix
+}
+
+func fileExists(fsys io.IO, name string) bool {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// readHadoopVersionHint returns the version in the version hint file of
+// the metadata directory, or false if there is no hint.
+func readHadoopVersionHint(fsys io.IO, metadataDir string) (int, bool, error) {
+	f, err := fsys.Open(metadataDir + "/" + HadoopVersionHintFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(f); err != nil {
+		return 0, false, err
+	}
+
+	v, err := strconv.Atoi(strings.TrimSpace(buf.String()))
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid version hint in %s: %w", metadataDir, err)
+	}
+	return v, true, nil
+}
+
+// latestHadoopVersion lists the metadata directory for the highest
+// version of the metadata files, which requires the IO to support
+// reading directories.
+func latestHadoopVersion(fsys io.IO, metadataDir string) (int, error) {
+	f, err := fsys.Open(metadataDir)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	dir, ok := f.(io.ReadDirFile)
+	if !ok {
+		return 0, fmt.Errorf("cannot list %s to find the latest metadata file without a version hint: %w",
+			metadataDir, errors.ErrUnsupported)
+	}
+
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		return 0, err
+	}
+
+	latest := -1
+	for _, e := range entries {
+		if v, ok := hadoopMetadataVersion(e.Name()); ok && !e.IsDir() {
+			latest = max(latest, v)
+		}
+	}
+
+	if latest < 0 {
+		return 0, fmt.Errorf("no metadata files in %s: %w", metadataDir, fs.ErrNotExist)
+	}
+	return latest, nil
+}
+
+// currentHadoopVersion returns the version of the current metadata file,
+// from the version hint if there is one. As the hint is written after the
+// metadata file it may be stale, so following versions are checked.
+func currentHadoopVersion(fsys io.IO, metadataDir string) (int, error) {
+	version, ok, err := readHadoopVersionHint(fsys, metadataDir)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return latestHadoopVersion(fsys, metadataDir)
+	}
+
+	for fileExists(fsys, hadoopMetadataFile(metadataDir, version+1)) {
+		version++
+	}
+	return version, nil
+}
+
+// ReadHadoopTable reads a table stored without a catalog in the layout of
+// Hadoop tables, where the metadata directory under the table location
+// holds the metadata files vN.metadata.json and a version hint file with
+// the current version N. Without a version hint the metadata directory is
+// listed for the highest version, which requires the IO to implement
+// [io.ReadDirFile] for directories.
+//
+// The table's identifier is its location. If the IO implements
+// [io.WriteFileIO] the table can be committed to, see [HadoopTables].
+func ReadHadoopTable(ctx context.Context, tableLocation string, fsys io.IO) (*Table, error) {
+	return HadoopTables{FS: fsys}.LoadTable(ctx, Identifier{strings.TrimRight(tableLocation, "/")}, nil)
+}
+
+// HadoopTables loads and commits to tables in the layout of Hadoop tables,
+// where a table is identified by its location. It implements CatalogIO so
+// that the tables it loads can be refreshed and committed to.
+//
+// A commit writes the next metadata version with
+// [io.WriteFileIO.WriteFileIfAbsent], so that of concurrent writers only
+// one succeeds and the others get a *CommitConflictError, and then
+// replaces the version hint.
+type HadoopTables struct {
+	FS io.IO
+}
+
+func hadoopLocation(ident Identifier) (string, error) {
+	if len(ident) != 1 || ident[0] == "" {
+		return "", fmt.Errorf("%w: hadoop tables are identified by their location, got %v",
+			iceberg.ErrInvalidArgument, ident)
+	}
+	return strings.TrimRight(ident[0], "/"), nil
+}
+
+// load reads the current metadata of the table at loc, returning it
+// along with its version.
+func (h HadoopTables) load(ctx context.Context, loc string) (*Table, int, error) {
+	metadataDir := loc + "/metadata"
+	version, err := currentHadoopVersion(h.FS, metadataDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	tbl, err := NewFromLocation(Identifier{loc}, hadoopMetadataFile(metadataDir, version), h.FS)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tbl, version, nil
+}
+
+// LoadTable reads the current metadata of the table at the location in
+// the identifier.
+func (h HadoopTables) LoadTable(ctx context.Context, ident Identifier, props iceberg.Properties) (*Table, error) {
+	loc, err := hadoopLocation(ident)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, _, err := h.load(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	return tbl.WithCatalog(h, Identifier{loc}, props).PinFromProperties(props)
+}
+
+// CommitTable validates the requirements against the current metadata of
+// the table and writes the updated metadata as the next version.
+func (h HadoopTables) CommitTable(ctx context.Context, ident Identifier, reqs []Requirement, updates []Update) (Metadata, string, error) {
+	wfs, ok := h.FS.(io.WriteFileIO)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: cannot commit to hadoop table %v, the file system doesn't support atomic writes",
+			errors.ErrUnsupported, ident)
+	}
+
+	loc, err := hadoopLocation(ident)
+	if err != nil {
+		return nil, "", err
+	}
+
+	current, version, err := h.load(ctx, loc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := ValidateRequirements(current.Metadata(), reqs); err != nil {
+		return nil, "", err
+	}
+
+	meta, err := ApplyUpdates(current.Metadata(), updates)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, "", err
+	}
+
+	metadataDir := loc + "/metadata"
+	next := hadoopMetadataFile(metadataDir, version+1)
+
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if err := wfs.WriteFileIfAbsent(next, data); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return nil, "", &CommitConflictError{Location: next}
+		}
+		return nil, "", err
+	}
+
+	// the new version is committed, a stale hint is corrected by
+	// readers checking for later versions
+	_ = wfs.WriteFile(metadataDir+"/"+HadoopVersionHintFile, []byte(strconv.Itoa(version+1)))
+
+	return meta, next, nil
+}
@@ -0,0 +1,226 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeHadoopTable writes the metadata files with the given versions, and
+// the version hint if it isn't empty, returning the table location.
+func writeHadoopTable(t *testing.T, hint string, versions ...string) string {
+	loc := t.TempDir()
+	metadataDir := filepath.Join(loc, "metadata")
+	require.NoError(t, os.Mkdir(metadataDir, 0o755))
+
+	for _, v := range versions {
+		require.NoError(t, os.WriteFile(filepath.Join(metadataDir, "v"+v+".metadata.json"),
+			[]byte(ExampleTableMetadataV2), 0o644))
+	}
+	// files which aren't metadata versions are ignored when listing
+	require.NoError(t, os.WriteFile(filepath.Join(metadataDir, "snap-1.avro"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(metadataDir, "vx.metadata.json"), nil, 0o644))
+
+	if hint != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(metadataDir, table.HadoopVersionHintFile),
+			[]byte(hint), 0o644))
+	}
+	return loc
+}
+
+func TestReadHadoopTable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("version hint", func(t *testing.T) {
+		loc := writeHadoopTable(t, "2\n", "1", "2")
+
+		tbl, err := table.ReadHadoopTable(ctx, loc+"/", io.LocalFS{})
+		require.NoError(t, err)
+		assert.Equal(t, loc+"/metadata/v2.metadata.json", tbl.MetadataLocation())
+		assert.Equal(t, table.Identifier{loc}, tbl.Identifier())
+		assert.Equal(t, "s3://bucket/test/location", tbl.Location())
+	})
+
+	t.Run("stale version hint", func(t *testing.T) {
+		loc := writeHadoopTable(t, "1", "1", "2", "3")
+
+		tbl, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+		require.NoError(t, err)
+		assert.Equal(t, loc+"/metadata/v3.metadata.json", tbl.MetadataLocation())
+	})
+
+	t.Run("no version hint", func(t *testing.T) {
+		loc := writeHadoopTable(t, "", "1", "2", "10")
+
+		tbl, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+		require.NoError(t, err)
+		assert.Equal(t, loc+"/metadata/v10.metadata.json", tbl.MetadataLocation())
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		loc := writeHadoopTable(t, "")
+
+		_, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("invalid version hint", func(t *testing.T) {
+		loc := writeHadoopTable(t, "latest", "1")
+
+		_, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+		assert.ErrorContains(t, err, "invalid version hint")
+	})
+
+	t.Run("missing hinted version", func(t *testing.T) {
+		loc := writeHadoopTable(t, "4", "1")
+
+		_, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("listing unsupported", func(t *testing.T) {
+		loc := writeHadoopTable(t, "", "1")
+
+		_, err := table.ReadHadoopTable(ctx, loc, noListFS{})
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+	})
+}
+
+func TestHadoopTableCommit(t *testing.T) {
+	ctx := context.Background()
+	loc := writeHadoopTable(t, "1", "1")
+
+	tbl, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+	require.NoError(t, err)
+
+	updated, _, err := tbl.UpdateProperties().Set("owner", "me").Commit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, loc+"/metadata/v2.metadata.json", updated.MetadataLocation())
+	assert.Equal(t, "me", updated.Properties()["owner"])
+
+	hint, err := os.ReadFile(filepath.Join(loc, "metadata", table.HadoopVersionHintFile))
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(hint))
+
+	reread, err := table.ReadHadoopTable(ctx, loc, io.LocalFS{})
+	require.NoError(t, err)
+	assert.Equal(t, updated.MetadataLocation(), reread.MetadataLocation())
+	assert.Equal(t, "me", reread.Properties()["owner"])
+
+	_, _, err = table.HadoopTables{FS: conflictFS{}}.CommitTable(ctx, updated.Identifier(), nil,
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "you"})})
+	var conflict *table.CommitConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, table.ErrCommitConflict)
+	assert.True(t, conflict.Retryable())
+	assert.Equal(t, loc+"/metadata/v3.metadata.json", conflict.Location)
+
+	// commits apply to the latest version, written by the other writer
+	latest, _, err := updated.UpdateProperties().Set("owner", "you").Commit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, loc+"/metadata/v4.metadata.json", latest.MetadataLocation())
+
+	// requirements are checked against the latest version
+	_, _, err = table.HadoopTables{FS: io.LocalFS{}}.CommitTable(ctx, updated.Identifier(),
+		[]table.Requirement{table.AssertCurrentSchemaID(42)}, nil)
+	assert.ErrorIs(t, err, table.ErrRequirementFailed)
+
+	_, _, err = table.HadoopTables{FS: noListFS{}}.CommitTable(ctx, updated.Identifier(), nil, nil)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestHadoopTableFileURI(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.ToSlash(t.TempDir())
+	if !strings.HasPrefix(dir, "/") {
+		// a path on a Windows drive, C:/dir becomes file:///C:/dir
+		dir = "/" + dir
+	}
+	loc := "file://" + dir + "/db/tbl"
+
+	fsys, err := io.LoadFS(nil, loc)
+	require.NoError(t, err)
+	require.IsType(t, io.LocalFS{}, fsys)
+
+	sc := iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, loc, nil)
+	require.NoError(t, err)
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	// the metadata directory doesn't exist yet and is created by the write
+	wfs := fsys.(io.WriteFileIO)
+	require.NoError(t, wfs.WriteFileIfAbsent(loc+"/metadata/v1.metadata.json", data))
+	assert.ErrorIs(t, wfs.WriteFileIfAbsent(loc+"/metadata/v1.metadata.json", data), fs.ErrExist)
+
+	// without a version hint the metadata directory is listed
+	tbl, err := table.ReadHadoopTable(ctx, loc, fsys)
+	require.NoError(t, err)
+	assert.Equal(t, loc+"/metadata/v1.metadata.json", tbl.MetadataLocation())
+	assert.True(t, tbl.Schema().Equals(sc))
+
+	updated, _, err := tbl.UpdateProperties().Set("owner", "me").Commit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, loc+"/metadata/v2.metadata.json", updated.MetadataLocation())
+
+	reread, err := table.ReadHadoopTable(ctx, loc, fsys)
+	require.NoError(t, err)
+	assert.Equal(t, updated.MetadataLocation(), reread.MetadataLocation())
+	assert.Equal(t, "me", reread.Properties()["owner"])
+
+	require.NoError(t, fsys.Remove(loc+"/metadata/v1.metadata.json"))
+	_, err = fsys.Open(loc + "/metadata/v1.metadata.json")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+// conflictFS is a local file system where another writer always creates
+// the file first.
+type conflictFS struct{ io.LocalFS }
+
+func (conflictFS) WriteFileIfAbsent(name string, data []byte) error {
+	if err := os.WriteFile(name, []byte(ExampleTableMetadataV2), 0o644); err != nil {
+		return err
+	}
+	return io.LocalFS{}.WriteFileIfAbsent(name, data)
+}
+
+// noListFS is a local file system whose directories can't be listed.
+type noListFS struct{}
+
+func (noListFS) Open(name string) (io.File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return struct{ io.File }{f}, nil
+}
+
+func (noListFS) Remove(name string) error { return os.Remove(name) }
@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// ParseIdentifier splits a dotted identifier such as "db.schema.table" into
+// its components. Components may be wrapped in double quotes in order to
+// contain dots, so `"my.db"."t"` is parsed as ["my.db", "t"]. Within a quoted
+// component a double quote can be escaped by doubling it.
+//
+// An error wrapping ErrInvalidIdentifier is returned for empty identifiers,
+// empty components (such as a leading or trailing dot) and unterminated or
+// misplaced quotes.
+func ParseIdentifier(s string) (Identifier, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: identifier cannot be empty", ErrInvalidIdentifier)
+	}
+
+	var (
+		out  Identifier
+		part strings.Builder
+	)
+
+	for i := 0; i < len(s); {
+		part.Reset()
+		if s[i] == '"' {
+			i++
+			closed := false
+			for i < len(s) {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						part.WriteByte('"')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				part.WriteByte(s[i])
+				i++
+			}
+
+			switch {
+			case !closed:
+				return nil, fmt.Errorf("%w: unterminated quote in '%s'", ErrInvalidIdentifier, s)
+			case i < len(s) && s[i] != '.':
+				return nil, fmt.Errorf("%w: unexpected character after closing quote at position %d in '%s'",
+					ErrInvalidIdentifier, i, s)
+			}
+		} else {
+			for i < len(s) && s[i] != '.' {
+				if s[i] == '"' {
+					return nil, fmt.Errorf("%w: unexpected quote at position %d in '%s'",
+						ErrInvalidIdentifier, i, s)
+				}
+				part.WriteByte(s[i])
+				i++
+			}
+		}
+
+		if part.Len() == 0 {
+			return nil, fmt.Errorf("%w: empty component in '%s'", ErrInvalidIdentifier, s)
+		}
+		out = append(out, part.String())
+
+		if i < len(s) {
+			// skip the separator, a trailing dot leaves an empty component
+			i++
+			if i == len(s) {
+				return nil, fmt.Errorf("%w: trailing dot in '%s'", ErrInvalidIdentifier, s)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// IdentifierString returns the dotted string form of the identifier, quoting
+// any component that contains a dot or a double quote so that the result can
+// be parsed again by ParseIdentifier. Like ParseIdentifier, it returns an
+// error wrapping ErrInvalidIdentifier for an empty identifier or one with an
+// empty component, which has no dotted form.
+func IdentifierString(ident Identifier) (string, error) {
+	if len(ident) == 0 {
+		return "", fmt.Errorf("%w: identifier cannot be empty", ErrInvalidIdentifier)
+	}
+
+	parts := make([]string, len(ident))
+	for i, p := range ident {
+		if p == "" {
+			return "", fmt.Errorf("%w: empty component at position %d in %q",
+				ErrInvalidIdentifier, i, []string(ident))
+		}
+		if strings.ContainsAny(p, `."`) {
+			p = `"` + strings.ReplaceAll(p, `"`, `""`) + `"`
+		}
+		parts[i] = p
+	}
+	return strings.Join(parts, "."), nil
+}
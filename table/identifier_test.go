@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected table.Identifier
+	}{
+		{"tbl", table.Identifier{"tbl"}},
+		{"db.tbl", table.Identifier{"db", "tbl"}},
+		{"db.schema.tbl", table.Identifier{"db", "schema", "tbl"}},
+		{`"my.db"."t"`, table.Identifier{"my.db", "t"}},
+		{`db."my.tbl"`, table.Identifier{"db", "my.tbl"}},
+		{`"a""b".c`, table.Identifier{`a"b`, "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ident, err := table.ParseIdentifier(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ident)
+
+			str, err := table.IdentifierString(ident)
+			require.NoError(t, err)
+			roundTrip, err := table.ParseIdentifier(str)
+			require.NoError(t, err)
+			assert.Equal(t, ident, roundTrip)
+		})
+	}
+}
+
+func TestParseIdentifierErrors(t *testing.T) {
+	for _, input := range []string{
+		"", ".", "db.", ".tbl", "db..tbl", `"db`, `"db"x.tbl`, `d"b.tbl`, `"".tbl`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, err := table.ParseIdentifier(input)
+			assert.ErrorIs(t, err, table.ErrInvalidIdentifier)
+		})
+	}
+}
+
+func TestIdentifierString(t *testing.T) {
+	tests := []struct {
+		ident    table.Identifier
+		expected string
+	}{
+		{table.Identifier{"db", "tbl"}, "db.tbl"},
+		{table.Identifier{"my.db", "t"}, `"my.db".t`},
+		{table.Identifier{"db", `a"b`}, `db."a""b"`},
+		{table.Identifier{`"`, "."}, `""""."."`},
+	}
+
+	for _, tt := range tests {
+		str, err := table.IdentifierString(tt.ident)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, str)
+
+		roundTrip, err := table.ParseIdentifier(str)
+		require.NoError(t, err)
+		assert.Equal(t, tt.ident, roundTrip)
+	}
+
+	// empty components can't be parsed, so they have no string form
+	for _, ident := range []table.Identifier{nil, {""}, {"db", ""}, {"", "tbl"}} {
+		_, err := table.IdentifierString(ident)
+		assert.ErrorIs(t, err, table.ErrInvalidIdentifier, ident)
+	}
+}
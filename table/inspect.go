@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	"golang.org/x/exp/slices"
+)
+
+// Inspector provides the metadata tables of a table, which are computed
+// from its metadata and manifests without reading any data files.
+type Inspector struct {
+	tbl Table
+}
+
+// Inspect returns an Inspector for the metadata tables of t.
+func (t Table) Inspect() Inspector { return Inspector{tbl: t} }
+
+// EntryRow is a row of the entries metadata table, describing a single
+// manifest entry of a snapshot.
+type EntryRow struct {
+	// Status is whether the file was existing, added or deleted
+	// in the snapshot that wrote the manifest.
+	Status             iceberg.ManifestEntryStatus
+	SnapshotID         int64
+	SequenceNumber     int64
+	FileSequenceNumber *int64
+	// Content is the content of the manifest the entry was read from,
+	// which tells entries of delete manifests apart from data manifests.
+	Content  iceberg.ManifestContent
+	DataFile iceberg.DataFile
+}
+
+type entriesOptions struct {
+	snapshotID *int64
+	statuses   []iceberg.ManifestEntryStatus
+}
+
+type EntriesOption func(*entriesOptions)
+
+// WithEntriesSnapshotID reads the entries of the manifests of the given
+// snapshot rather than those of the table's snapshot.
+func WithEntriesSnapshotID(id int64) EntriesOption {
+	return func(o *entriesOptions) { o.snapshotID = &id }
+}
+
+// WithEntryStatus only returns the entries with one of the given statuses.
+func WithEntryStatus(statuses ...iceberg.ManifestEntryStatus) EntriesOption {
+	return func(o *entriesOptions) { o.statuses = statuses }
+}
+
+// Entries returns the entries of all data and delete manifests of the
+// table's [Table.Snapshot], including the entries of deleted files. A table
+// without snapshots has no entries.
+func (i Inspector) Entries(ctx context.Context, opts ...EntriesOption) ([]EntryRow, error) {
+	var o entriesOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	snap := i.tbl.Snapshot()
+	if o.snapshotID != nil {
+		if snap = i.tbl.SnapshotByID(*o.snapshotID); snap == nil {
+			return nil, fmt.Errorf("%w: snapshot %d not found",
+				iceberg.ErrInvalidArgument, *o.snapshotID)
+		}
+	}
+
+	if snap == nil {
+		return nil, nil
+	}
+
+	manifests, err := snap.Manifests(i.tbl.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []EntryRow
+	for _, m := range manifests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		entries, err := m.FetchEntries(i.tbl.fs, false)
+		if err != nil {
+			return nil, fmt.Errorf("could not read manifest %s: %w", m.FilePath(), err)
+		}
+
+		for _, e := range entries {
+			if len(o.statuses) > 0 && !slices.Contains(o.statuses, e.Status()) {
+				continue
+			}
+
+			rows = append(rows, EntryRow{
+				Status:             e.Status(),
+				SnapshotID:         e.SnapshotID(),
+				SequenceNumber:     e.SequenceNum(),
+				FileSequenceNumber: e.FileSequenceNum(),
+				Content:            m.ManifestContent(),
+				DataFile:           e.DataFile(),
+			})
+		}
+	}
+
+	return rows, nil
+}
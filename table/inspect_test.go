@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/internal"
+	"github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAvroFile(t *testing.T, path, schemaKey string, records ...any) int64 {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	enc, err := ocf.NewEncoder(internal.AvroSchemaCache.Get(schemaKey).String(), f,
+		ocf.WithMetadata(map[string][]byte{"format-version": []byte("2")}))
+	require.NoError(t, err)
+	for _, r := range records {
+		require.NoError(t, enc.Encode(r))
+	}
+	require.NoError(t, enc.Close())
+
+	info, err := f.Stat()
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func entryRecord(status int32, snapshotID, seq int64, content int32, path string) map[string]any {
+	return map[string]any{
+		"status":               status,
+		"snapshot_id":          snapshotID,
+		"sequence_number":      seq,
+		"file_sequence_number": seq,
+		"data_file": map[string]any{
+			"content":            content,
+			"file_path":          path,
+			"file_format":        "PARQUET",
+			"partition":          map[string]any{"VendorID": nil, "tpep_pickup_datetime": nil},
+			"record_count":       int64(10),
+			"file_size_in_bytes": int64(1024),
+			"column_sizes":       nil,
+			"value_counts":       nil,
+			"null_value_counts":  nil,
+			"nan_value_counts":   nil,
+			"lower_bounds":       nil,
+			"upper_bounds":       nil,
+			"key_metadata":       nil,
+			"split_offsets":      nil,
+			"equality_ids":       nil,
+			"sort_order_id":      nil,
+		},
+	}
+}
+
+func manifestRecord(path string, length int64, content int32, seq, snapshotID int64) map[string]any {
+	return map[string]any{
+		"manifest_path":        path,
+		"manifest_length":      length,
+		"partition_spec_id":    int32(0),
+		"content":              content,
+		"sequence_number":      seq,
+		"min_sequence_number":  seq,
+		"added_snapshot_id":    snapshotID,
+		"added_files_count":    int32(1),
+		"existing_files_count": int32(1),
+		"deleted_files_count":  int32(1),
+		"added_rows_count":     int64(10),
+		"existing_rows_count":  int64(10),
+		"deleted_rows_count":   int64(10),
+		"partitions":           nil,
+		"key_metadata":         nil,
+	}
+}
+
+func TestInspectEntries(t *testing.T) {
+	dir := t.TempDir()
+
+	dataManifest := filepath.Join(dir, "data-m0.avro")
+	dataLen := writeAvroFile(t, dataManifest, internal.ManifestEntryV2Key,
+		entryRecord(0, 1, 1, 0, "s3://bucket/data/existing.parquet"),
+		entryRecord(1, 2, 2, 0, "s3://bucket/data/added.parquet"),
+		entryRecord(2, 2, 1, 0, "s3://bucket/data/deleted.parquet"))
+
+	deleteManifest := filepath.Join(dir, "delete-m0.avro")
+	deleteLen := writeAvroFile(t, deleteManifest, internal.ManifestEntryV2Key,
+		entryRecord(1, 2, 2, 1, "s3://bucket/data/pos-deletes.parquet"))
+
+	manifestList := filepath.Join(dir, "snap-2.avro")
+	writeAvroFile(t, manifestList, internal.ManifestListV2Key,
+		manifestRecord(dataManifest, dataLen, 0, 2, 2),
+		manifestRecord(deleteManifest, deleteLen, 1, 2, 2))
+
+	meta, err := table.ParseMetadataString(fmt.Sprintf(`{
+		"format-version": 2,
+		"table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+		"location": %q,
+		"last-sequence-number": 2,
+		"last-updated-ms": 1602638573590,
+		"last-column-id": 1,
+		"current-schema-id": 0,
+		"schemas": [{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "type": "long", "required": true}]}],
+		"default-spec-id": 0,
+		"partition-specs": [{"spec-id": 0, "fields": []}],
+		"last-partition-id": 999,
+		"default-sort-order-id": 0,
+		"sort-orders": [{"order-id": 0, "fields": []}],
+		"current-snapshot-id": 2,
+		"snapshots": [
+			{"snapshot-id": 1, "sequence-number": 1, "timestamp-ms": 1515100955770, "summary": {"operation": "append"}},
+			{"snapshot-id": 2, "parent-snapshot-id": 1, "sequence-number": 2, "timestamp-ms": 1555100955770,
+			 "manifest-list": %q, "summary": {"operation": "overwrite"}}
+		]
+	}`, dir, manifestList))
+	require.NoError(t, err)
+
+	tbl := table.New(table.Identifier{"db", "tbl"}, meta, filepath.Join(dir, "v1.metadata.json"), io.LocalFS{})
+	ctx := context.Background()
+
+	rows, err := tbl.Inspect().Entries(ctx)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	paths := make([]string, len(rows))
+	for i, r := range rows {
+		paths[i] = r.DataFile.FilePath()
+	}
+	assert.Equal(t, []string{
+		"s3://bucket/data/existing.parquet", "s3://bucket/data/added.parquet",
+		"s3://bucket/data/deleted.parquet", "s3://bucket/data/pos-deletes.parquet",
+	}, paths)
+
+	assert.Equal(t, iceberg.EntryStatusDELETED, rows[2].Status)
+	assert.EqualValues(t, 2, rows[2].SnapshotID)
+	assert.EqualValues(t, 1, rows[2].SequenceNumber)
+	require.NotNil(t, rows[2].FileSequenceNumber)
+	assert.EqualValues(t, 1, *rows[2].FileSequenceNumber)
+	assert.Equal(t, iceberg.ManifestContentData, rows[2].Content)
+
+	assert.Equal(t, iceberg.ManifestContentDeletes, rows[3].Content)
+	assert.Equal(t, iceberg.EntryContentPosDeletes, rows[3].DataFile.ContentType())
+
+	added, err := tbl.Inspect().Entries(ctx, table.WithEntryStatus(iceberg.EntryStatusADDED))
+	require.NoError(t, err)
+	require.Len(t, added, 2)
+	assert.Equal(t, "s3://bucket/data/added.parquet", added[0].DataFile.FilePath())
+	assert.Equal(t, "s3://bucket/data/pos-deletes.parquet", added[1].DataFile.FilePath())
+
+	// the first snapshot has no manifest list
+	rows, err = tbl.Inspect().Entries(ctx, table.WithEntriesSnapshotID(1))
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	_, err = tbl.Inspect().Entries(ctx, table.WithEntriesSnapshotID(3))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -0,0 +1,180 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/internal"
+)
+
+const (
+	// PropertyWriteDataPath is the location new data files are written
+	// under, which defaults to the data directory of the table location.
+	PropertyWriteDataPath = "write.data.path"
+	// PropertyObjectStoreEnabled enables the object storage layout, which
+	// adds a hash to the path of each data file so that files are spread
+	// across prefixes of the object store.
+	PropertyObjectStoreEnabled = "write.object-storage.enabled"
+	// PropertyObjectStorePartitionedPaths controls whether the partition
+	// path is kept in data file paths with the object storage layout,
+	// which is the default.
+	PropertyObjectStorePartitionedPaths = "write.object-storage.partitioned-paths"
+
+	// deprecated properties for the data location, which are still
+	// honored for tables written by older versions of other engines
+	propertyObjectStorePath   = "write.object-storage.path"
+	propertyFolderStoragePath = "write.folder-storage.path"
+)
+
+const (
+	// the object storage hash is the last 20 bits of the murmur3 hash of
+	// the file name, split into three directories of 4 bits and one of 8
+	objectStoreHashBits   = 20
+	objectStoreDirLength  = 4
+	objectStoreDirDepth   = 3
+	objectStoreHashPrefix = 32 - objectStoreHashBits
+)
+
+// LocationProvider generates the locations of new data files of a table.
+type LocationProvider interface {
+	// NewDataLocation returns the location for a new data file with the
+	// given name, in the partition with the given path as returned by
+	// PartitionSpec.PartitionToPath, which is empty for unpartitioned
+	// tables.
+	NewDataLocation(partitionPath, fileName string) string
+}
+
+// NewLocationProvider returns the location provider for a table with the
+// given location and properties. By default data files are written to
+// "<data path>/<partition path>/<file>", where the data path is
+// write.data.path or the data directory of the table location.
+//
+// If write.object-storage.enabled is set, a hash of the partition path and
+// file name is added as "<data path>/<hash>/<partition path>/<file>", the
+// same layout as the Java implementation so that files written by any
+// engine are spread the same way. If the data path isn't within the table
+// location, the table's parent directory and name are added after the hash.
+// With write.object-storage.partitioned-paths set to false the partition
+// path is left out, and the file is named "<hash>-<file>".
+func NewLocationProvider(tableLocation string, props iceberg.Properties) LocationProvider {
+	tableLocation = strings.TrimRight(tableLocation, "/")
+	dataPath := dataLocation(tableLocation, props)
+
+	if !propertyBool(props, PropertyObjectStoreEnabled, false) {
+		return simpleLocationProvider{dataPath: dataPath}
+	}
+
+	p := objectStoreLocationProvider{
+		dataPath:              dataPath,
+		includePartitionPaths: propertyBool(props, PropertyObjectStorePartitionedPaths, true),
+	}
+	if !strings.HasPrefix(dataPath, tableLocation) {
+		p.context = pathContext(tableLocation)
+	}
+	return p
+}
+
+// pathContext returns the last two directories of the table location,
+// which are usually the database and table names.
+func pathContext(tableLocation string) string {
+	loc := tableLocation
+	if u, err := url.Parse(tableLocation); err == nil {
+		loc = u.Path
+	}
+
+	dirs := strings.Split(strings.Trim(loc, "/"), "/")
+	if len(dirs) > 2 {
+		dirs = dirs[len(dirs)-2:]
+	}
+	return strings.Join(dirs, "/")
+}
+
+// LocationProvider returns the provider for the locations of new data
+// files of the table, see NewLocationProvider.
+func (t Table) LocationProvider() LocationProvider {
+	return NewLocationProvider(t.Location(), t.Properties())
+}
+
+func dataLocation(tableLocation string, props iceberg.Properties) string {
+	for _, key := range []string{PropertyWriteDataPath, propertyObjectStorePath, propertyFolderStoragePath} {
+		if loc, ok := props[key]; ok && loc != "" {
+			return strings.TrimRight(loc, "/")
+		}
+	}
+	return tableLocation + "/data"
+}
+
+func propertyBool(props iceberg.Properties, key string, def bool) bool {
+	if v, err := strconv.ParseBool(props[key]); err == nil {
+		return v
+	}
+	return def
+}
+
+type simpleLocationProvider struct {
+	dataPath string
+}
+
+func (p simpleLocationProvider) NewDataLocation(partitionPath, fileName string) string {
+	if partitionPath == "" {
+		return p.dataPath + "/" + fileName
+	}
+	return p.dataPath + "/" + partitionPath + "/" + fileName
+}
+
+type objectStoreLocationProvider struct {
+	dataPath              string
+	context               string
+	includePartitionPaths bool
+}
+
+func (p objectStoreLocationProvider) NewDataLocation(partitionPath, fileName string) string {
+	if p.includePartitionPaths && partitionPath != "" {
+		fileName = partitionPath + "/" + fileName
+	}
+
+	hash := objectStoreHash(fileName)
+	switch {
+	case p.context != "":
+		return p.dataPath + "/" + hash + "/" + p.context + "/" + fileName
+	case p.includePartitionPaths:
+		return p.dataPath + "/" + hash + "/" + fileName
+	default:
+		return p.dataPath + "/" + hash + "-" + fileName
+	}
+}
+
+// objectStoreHash returns the hash directories for a file name, such as
+// "0101/0110/1001/10110010".
+func objectStoreHash(fileName string) string {
+	// setting the top bit keeps the leading zeros of the hash
+	bits := strconv.FormatUint(uint64(internal.Murmur3Hash32([]byte(fileName))|1<<31), 2)
+	bits = bits[objectStoreHashPrefix:]
+
+	var b strings.Builder
+	for i := 0; i < objectStoreDirDepth*objectStoreDirLength; i += objectStoreDirLength {
+		b.WriteString(bits[i : i+objectStoreDirLength])
+		b.WriteByte('/')
+	}
+	b.WriteString(bits[objectStoreDirDepth*objectStoreDirLength:])
+	return b.String()
+}
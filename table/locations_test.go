@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimpleLocationProvider(t *testing.T) {
+	provider := table.NewLocationProvider("s3://bucket/db/tbl/", nil)
+	assert.Equal(t, "s3://bucket/db/tbl/data/file.parquet",
+		provider.NewDataLocation("", "file.parquet"))
+	assert.Equal(t, "s3://bucket/db/tbl/data/region=eu/file.parquet",
+		provider.NewDataLocation("region=eu", "file.parquet"))
+
+	provider = table.NewLocationProvider("s3://bucket/db/tbl", iceberg.Properties{
+		table.PropertyWriteDataPath: "s3://other-bucket/data/"})
+	assert.Equal(t, "s3://other-bucket/data/region=eu/file.parquet",
+		provider.NewDataLocation("region=eu", "file.parquet"))
+
+	provider = table.NewLocationProvider("s3://bucket/db/tbl", iceberg.Properties{
+		"write.folder-storage.path": "s3://legacy/data"})
+	assert.Equal(t, "s3://legacy/data/file.parquet", provider.NewDataLocation("", "file.parquet"))
+}
+
+func TestObjectStoreLocationProvider(t *testing.T) {
+	enabled := iceberg.Properties{table.PropertyObjectStoreEnabled: "true"}
+	provider := table.NewLocationProvider("table_location", enabled)
+
+	// the hashes match those of the Java implementation for the same names
+	tests := []struct {
+		name, hash string
+	}{
+		{"a", "0101/0110/1001/10110010"},
+		{"b", "1110/0111/1110/00000011"},
+		{"c", "0010/1101/0110/01011111"},
+		{"d", "1001/0001/0100/01110011"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, "table_location/data/"+tt.hash+"/"+tt.name,
+			provider.NewDataLocation("", tt.name))
+	}
+
+	// the partition path is hashed together with the file name
+	assert.Equal(t, "table_location/data/0001/0010/1001/00000011/string_field=example_string/test.parquet",
+		provider.NewDataLocation("string_field=example_string", "test.parquet"))
+
+	provider = table.NewLocationProvider("table_location", iceberg.Properties{
+		table.PropertyObjectStoreEnabled:          "true",
+		table.PropertyObjectStorePartitionedPaths: "false",
+	})
+	assert.Equal(t, "table_location/data/0110/1010/0011/11101000-test.parquet",
+		provider.NewDataLocation("string_field=example_string", "test.parquet"))
+
+	// a data path outside of the table location gets the table's context
+	provider = table.NewLocationProvider("s3://bucket/db/tbl", iceberg.Properties{
+		table.PropertyObjectStoreEnabled: "true",
+		table.PropertyWriteDataPath:      "s3://data-bucket/",
+	})
+	assert.Equal(t, "s3://data-bucket/0110/1010/0011/11101000/db/tbl/test.parquet",
+		provider.NewDataLocation("", "test.parquet"))
+}
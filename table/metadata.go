@@ -18,6 +18,7 @@
 package table
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +27,8 @@ import (
 	"github.com/apache/iceberg-go"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // Metadata for an iceberg table as specified in the Iceberg spec
@@ -49,6 +52,11 @@ type Metadata interface {
 	// This is used to ensure fields are always assigned an unused ID when
 	// evolving schemas.
 	LastColumnID() int
+	// HighestAssignedFieldID returns the highest field ID which has been
+	// assigned in any version of the table's schema, or the last-column-id
+	// if that is higher. New fields must be assigned IDs above it, so
+	// that IDs are never reused across schema versions.
+	HighestAssignedFieldID() int
 	// Schemas returns the list of schemas, stored as objects with their
 	// schema-id.
 	Schemas() []*iceberg.Schema
@@ -78,6 +86,17 @@ type Metadata interface {
 	SnapshotByName(name string) *Snapshot
 	// CurrentSnapshot returns the table's current snapshot.
 	CurrentSnapshot() *Snapshot
+	// SnapshotRefs returns a copy of the map of named snapshot refs
+	// (branches and tags) of the table.
+	SnapshotRefs() map[string]SnapshotRef
+	// SnapshotLogs returns the log of changes to the current snapshot of the
+	// table, ordered from oldest to newest. Each entry records the timestamp
+	// at which the current snapshot was changed and the snapshot-id it was
+	// changed to.
+	SnapshotLogs() []SnapshotLogEntry
+	// MetadataLogs returns the list of previous metadata files for the table,
+	// ordered from oldest to newest.
+	MetadataLogs() []MetadataLogEntry
 	// SortOrder returns the table's current sort order, ie: the one with the
 	// ID that matches the default-sort-order-id.
 	SortOrder() SortOrder
@@ -88,6 +107,37 @@ type Metadata interface {
 	// to be used for arbitrary metadata. For example, commit.retry.num-retries
 	// is used to control the number of commit retries.
 	Properties() iceberg.Properties
+	// Statistics returns the table statistics files, such as Puffin files
+	// with column statistics, each for a snapshot of the table.
+	Statistics() []StatisticsFile
+	// PartitionStatistics returns the partition statistics files, each for
+	// a snapshot of the table.
+	PartitionStatistics() []PartitionStatisticsFile
+}
+
+const (
+	// PropertyFormatVersion is the property used to request the format
+	// version of a new table. It is not stored in the table properties.
+	PropertyFormatVersion = "format-version"
+	// DefaultFormatVersion is the format version of new tables if one
+	// isn't requested.
+	DefaultFormatVersion = 2
+)
+
+// ReservedProperties are table properties which are derived from the
+// table metadata, or used to request changes to it, and so can't be set
+// or removed directly. For example, the format version of a table is
+// changed with an upgrade-format-version update.
+var ReservedProperties = []string{
+	PropertyFormatVersion,
+	"uuid",
+	"snapshot-count",
+	"current-snapshot-id",
+	"current-snapshot-summary",
+	"current-snapshot-timestamp-ms",
+	"current-schema",
+	"default-partition-spec",
+	"default-sort-order",
 }
 
 var (
@@ -127,6 +177,8 @@ func ParseMetadataBytes(b []byte) (Metadata, error) {
 		ret = &MetadataV1{}
 	case 2:
 		ret = &MetadataV2{}
+	case 3:
+		ret = &MetadataV3{}
 	default:
 		return nil, ErrInvalidMetadataFormatVersion
 	}
@@ -136,24 +188,113 @@ func ParseMetadataBytes(b []byte) (Metadata, error) {
 
 // https://iceberg.apache.org/spec/#iceberg-table-spec
 type commonMetadata struct {
-	FormatVersion      int                     `json:"format-version"`
-	UUID               uuid.UUID               `json:"table-uuid"`
-	Loc                string                  `json:"location"`
-	LastUpdatedMS      int64                   `json:"last-updated-ms"`
-	LastColumnId       int                     `json:"last-column-id"`
-	SchemaList         []*iceberg.Schema       `json:"schemas"`
-	CurrentSchemaID    int                     `json:"current-schema-id"`
-	Specs              []iceberg.PartitionSpec `json:"partition-specs"`
-	DefaultSpecID      int                     `json:"default-spec-id"`
-	LastPartitionID    *int                    `json:"last-partition-id,omitempty"`
-	Props              iceberg.Properties      `json:"properties"`
-	SnapshotList       []Snapshot              `json:"snapshots,omitempty"`
-	CurrentSnapshotID  *int64                  `json:"current-snapshot-id,omitempty"`
-	SnapshotLog        []SnapshotLogEntry      `json:"snapshot-log"`
-	MetadataLog        []MetadataLogEntry      `json:"metadata-log"`
-	SortOrderList      []SortOrder             `json:"sort-orders"`
-	DefaultSortOrderID int                     `json:"default-sort-order-id"`
-	Refs               map[string]SnapshotRef  `json:"refs"`
+	FormatVersion      int                       `json:"format-version"`
+	UUID               uuid.UUID                 `json:"table-uuid"`
+	Loc                string                    `json:"location"`
+	LastUpdatedMS      int64                     `json:"last-updated-ms"`
+	LastColumnId       int                       `json:"last-column-id"`
+	SchemaList         []*iceberg.Schema         `json:"schemas"`
+	CurrentSchemaID    int                       `json:"current-schema-id"`
+	Specs              []iceberg.PartitionSpec   `json:"partition-specs"`
+	DefaultSpecID      int                       `json:"default-spec-id"`
+	LastPartitionID    *int                      `json:"last-partition-id,omitempty"`
+	Props              iceberg.Properties        `json:"properties"`
+	SnapshotList       []Snapshot                `json:"snapshots,omitempty"`
+	CurrentSnapshotID  *int64                    `json:"current-snapshot-id,omitempty"`
+	SnapshotLog        []SnapshotLogEntry        `json:"snapshot-log"`
+	MetadataLog        []MetadataLogEntry        `json:"metadata-log"`
+	SortOrderList      []SortOrder               `json:"sort-orders"`
+	DefaultSortOrderID int                       `json:"default-sort-order-id"`
+	Refs               map[string]SnapshotRef    `json:"refs"`
+	StatisticsList     []StatisticsFile          `json:"statistics,omitempty"`
+	PartitionStatsList []PartitionStatisticsFile `json:"partition-statistics,omitempty"`
+}
+
+// metadataJSON is the layout metadata is written in, which follows the
+// key order of the Java implementation's TableMetadataParser.
+type metadataJSON struct {
+	FormatVersion      int                       `json:"format-version"`
+	UUID               uuid.UUID                 `json:"table-uuid"`
+	Loc                string                    `json:"location"`
+	LastSequenceNumber *int                      `json:"last-sequence-number,omitempty"`
+	LastUpdatedMS      int64                     `json:"last-updated-ms"`
+	LastColumnId       int                       `json:"last-column-id"`
+	NextRowID          *int64                    `json:"next-row-id,omitempty"`
+	Schema             *iceberg.Schema           `json:"schema,omitempty"`
+	CurrentSchemaID    int                       `json:"current-schema-id"`
+	SchemaList         []*iceberg.Schema         `json:"schemas"`
+	Partition          *[]iceberg.PartitionField `json:"partition-spec,omitempty"`
+	DefaultSpecID      int                       `json:"default-spec-id"`
+	Specs              []iceberg.PartitionSpec   `json:"partition-specs"`
+	LastPartitionID    *int                      `json:"last-partition-id,omitempty"`
+	DefaultSortOrderID int                       `json:"default-sort-order-id"`
+	SortOrderList      []SortOrder               `json:"sort-orders"`
+	Props              iceberg.Properties        `json:"properties"`
+	CurrentSnapshotID  int64                     `json:"current-snapshot-id"`
+	Refs               map[string]SnapshotRef    `json:"refs"`
+	SnapshotList       []Snapshot                `json:"snapshots"`
+	StatisticsList     []StatisticsFile          `json:"statistics,omitempty"`
+	PartitionStatsList []PartitionStatisticsFile `json:"partition-statistics,omitempty"`
+	SnapshotLog        []SnapshotLogEntry        `json:"snapshot-log"`
+	MetadataLog        []MetadataLogEntry        `json:"metadata-log"`
+}
+
+// toJSON returns the metadata in the layout it's written in. Like the
+// Java implementation, a missing current snapshot is written as -1 and
+// missing lists and maps as empty ones, except for the statistics which
+// are left out. Schemas, partition specs, sort orders and snapshots are
+// written ordered by their IDs, so that the output doesn't depend on the
+// order they were added in.
+func (c *commonMetadata) toJSON() metadataJSON {
+	out := metadataJSON{
+		FormatVersion:      c.FormatVersion,
+		UUID:               c.UUID,
+		Loc:                c.Loc,
+		LastUpdatedMS:      c.LastUpdatedMS,
+		LastColumnId:       c.LastColumnId,
+		CurrentSchemaID:    c.CurrentSchemaID,
+		SchemaList:         sortedByID(c.SchemaList, func(s *iceberg.Schema) int64 { return int64(s.ID) }),
+		DefaultSpecID:      c.DefaultSpecID,
+		Specs:              sortedByID(c.Specs, func(s iceberg.PartitionSpec) int64 { return int64(s.ID()) }),
+		LastPartitionID:    c.LastPartitionID,
+		DefaultSortOrderID: c.DefaultSortOrderID,
+		SortOrderList:      sortedByID(c.SortOrderList, func(s SortOrder) int64 { return int64(s.OrderID) }),
+		Props:              c.Props,
+		CurrentSnapshotID:  -1,
+		Refs:               c.Refs,
+		SnapshotList:       sortedByID(c.SnapshotList, func(s Snapshot) int64 { return s.SnapshotID }),
+		StatisticsList:     c.StatisticsList,
+		PartitionStatsList: c.PartitionStatsList,
+		SnapshotLog:        c.SnapshotLog,
+		MetadataLog:        c.MetadataLog,
+	}
+
+	if c.CurrentSnapshotID != nil {
+		out.CurrentSnapshotID = *c.CurrentSnapshotID
+	}
+	if out.Props == nil {
+		out.Props = iceberg.Properties{}
+	}
+	if out.Refs == nil {
+		out.Refs = map[string]SnapshotRef{}
+	}
+	if out.SnapshotList == nil {
+		out.SnapshotList = []Snapshot{}
+	}
+	if out.SnapshotLog == nil {
+		out.SnapshotLog = []SnapshotLogEntry{}
+	}
+	if out.MetadataLog == nil {
+		out.MetadataLog = []MetadataLogEntry{}
+	}
+	return out
+}
+
+// sortedByID returns a copy of s sorted by the IDs of its elements.
+func sortedByID[T any](s []T, id func(T) int64) []T {
+	out := slices.Clone(s)
+	slices.SortStableFunc(out, func(a, b T) int { return cmp.Compare(id(a), id(b)) })
+	return out
 }
 
 func (c *commonMetadata) TableUUID() uuid.UUID       { return c.UUID }
@@ -161,6 +302,17 @@ func (c *commonMetadata) Location() string           { return c.Loc }
 func (c *commonMetadata) LastUpdatedMillis() int64   { return c.LastUpdatedMS }
 func (c *commonMetadata) LastColumnID() int          { return c.LastColumnId }
 func (c *commonMetadata) Schemas() []*iceberg.Schema { return c.SchemaList }
+
+func (c *commonMetadata) HighestAssignedFieldID() int {
+	id := c.LastColumnId
+	for _, s := range c.SchemaList {
+		if h := s.HighestFieldID(); h > id {
+			id = h
+		}
+	}
+	return id
+}
+
 func (c *commonMetadata) CurrentSchema() *iceberg.Schema {
 	for _, s := range c.SchemaList {
 		if s.ID == c.CurrentSchemaID {
@@ -212,6 +364,10 @@ func (c *commonMetadata) CurrentSnapshot() *Snapshot {
 	return c.SnapshotByID(*c.CurrentSnapshotID)
 }
 
+func (c *commonMetadata) SnapshotRefs() map[string]SnapshotRef { return maps.Clone(c.Refs) }
+func (c *commonMetadata) SnapshotLogs() []SnapshotLogEntry     { return c.SnapshotLog }
+func (c *commonMetadata) MetadataLogs() []MetadataLogEntry     { return c.MetadataLog }
+
 func (c *commonMetadata) SortOrders() []SortOrder { return c.SortOrderList }
 func (c *commonMetadata) SortOrder() SortOrder {
 	for _, s := range c.SortOrderList {
@@ -226,6 +382,11 @@ func (c *commonMetadata) Properties() iceberg.Properties {
 	return c.Props
 }
 
+func (c *commonMetadata) Statistics() []StatisticsFile { return c.StatisticsList }
+func (c *commonMetadata) PartitionStatistics() []PartitionStatisticsFile {
+	return c.PartitionStatsList
+}
+
 // preValidate updates values in the metadata struct with defaults based on
 // combinations of struct members. Such as initializing slices as empty slices
 // if they were null in the metadata, or normalizing inconsistencies between
@@ -237,6 +398,10 @@ func (c *commonMetadata) preValidate() {
 		c.CurrentSnapshotID = nil
 	}
 
+	if c.Refs == nil {
+		c.Refs = make(map[string]SnapshotRef)
+	}
+
 	if c.CurrentSnapshotID != nil {
 		if _, ok := c.Refs[MainBranch]; !ok {
 			c.Refs[MainBranch] = SnapshotRef{
@@ -250,10 +415,6 @@ func (c *commonMetadata) preValidate() {
 		c.MetadataLog = []MetadataLogEntry{}
 	}
 
-	if c.Refs == nil {
-		c.Refs = make(map[string]SnapshotRef)
-	}
-
 	if c.SnapshotLog == nil {
 		c.SnapshotLog = []SnapshotLogEntry{}
 	}
@@ -372,6 +533,22 @@ func (m *MetadataV1) UnmarshalJSON(b []byte) error {
 	return m.validate()
 }
 
+// MarshalJSON writes the metadata with the current schema and the fields
+// of the default partition spec in the schema and partition-spec fields,
+// which are read by older readers.
+func (m *MetadataV1) MarshalJSON() ([]byte, error) {
+	out := m.toJSON()
+	out.Schema = m.CurrentSchema()
+
+	spec := m.PartitionSpec()
+	fields := make([]iceberg.PartitionField, spec.NumFields())
+	for i := range fields {
+		fields[i] = spec.Field(i)
+	}
+	out.Partition = &fields
+	return json.Marshal(out)
+}
+
 func (m *MetadataV1) ToV2() MetadataV2 {
 	commonOut := m.commonMetadata
 	commonOut.FormatVersion = 2
@@ -388,6 +565,12 @@ type MetadataV2 struct {
 	commonMetadata
 }
 
+func (m *MetadataV2) MarshalJSON() ([]byte, error) {
+	out := m.toJSON()
+	out.LastSequenceNumber = &m.LastSequenceNumber
+	return json.Marshal(out)
+}
+
 func (m *MetadataV2) UnmarshalJSON(b []byte) error {
 	type Alias MetadataV2
 	aux := (*Alias)(m)
@@ -399,3 +582,30 @@ func (m *MetadataV2) UnmarshalJSON(b []byte) error {
 	m.preValidate()
 	return m.validate()
 }
+
+// MetadataV3 is the metadata of a format version 3 table, which adds
+// row lineage to version 2.
+type MetadataV3 struct {
+	LastSequenceNumber int   `json:"last-sequence-number"`
+	NextRowID          int64 `json:"next-row-id"`
+
+	commonMetadata
+}
+
+func (m *MetadataV3) MarshalJSON() ([]byte, error) {
+	out := m.toJSON()
+	out.LastSequenceNumber, out.NextRowID = &m.LastSequenceNumber, &m.NextRowID
+	return json.Marshal(out)
+}
+
+func (m *MetadataV3) UnmarshalJSON(b []byte) error {
+	type Alias MetadataV3
+	aux := (*Alias)(m)
+
+	if err := json.Unmarshal(b, aux); err != nil {
+		return err
+	}
+
+	m.preValidate()
+	return m.validate()
+}
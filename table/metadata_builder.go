@@ -0,0 +1,871 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// ErrInvalidUpdate is returned when an update can't be applied to the
+// table metadata, such as when it references a schema which doesn't exist.
+var ErrInvalidUpdate = errors.New("invalid metadata update")
+
+// supportedFormatVersion is the highest table format version which can be
+// produced by a MetadataBuilder.
+const supportedFormatVersion = 3
+
+// MetadataBuilder is used to construct a new version of table metadata by
+// applying changes to a base version. Each change is recorded as an Update
+// so that the same set of changes can be sent to a catalog or replayed
+// against another version of the metadata.
+type MetadataBuilder struct {
+	base    Metadata
+	updates []Update
+
+	c                  commonMetadata
+	lastSequenceNumber int
+	nextRowID          int64
+	// lastUpdatedMS is the timestamp to use for the resulting metadata,
+	// zero means the current time will be used when building.
+	lastUpdatedMS int64
+
+	// track what has been added by this builder so that -1 can be used
+	// to refer to the last added schema, spec or sort order.
+	lastAddedSchemaID *int
+	lastAddedSpecID   *int
+	lastAddedOrderID  *int
+	addedSnapshots    map[int64]struct{}
+
+	clock Clock
+}
+
+// MetadataBuilderFromBase returns a builder initialized with a copy of the
+// given metadata, which is left unmodified.
+func MetadataBuilderFromBase(base Metadata) (*MetadataBuilder, error) {
+	b := &MetadataBuilder{base: base, addedSnapshots: make(map[int64]struct{}),
+		clock: SystemClock}
+	switch m := base.(type) {
+	case *MetadataV1:
+		b.c = m.commonMetadata
+	case *MetadataV2:
+		b.c = m.commonMetadata
+		b.lastSequenceNumber = m.LastSequenceNumber
+	case *MetadataV3:
+		b.c = m.commonMetadata
+		b.lastSequenceNumber = m.LastSequenceNumber
+		b.nextRowID = m.NextRowID
+	default:
+		return nil, fmt.Errorf("%w: unsupported metadata type %T",
+			ErrInvalidMetadata, base)
+	}
+
+	// copy the slices and maps so that changes don't leak into the base
+	b.c.SchemaList = slices.Clone(b.c.SchemaList)
+	b.c.Specs = slices.Clone(b.c.Specs)
+	b.c.Props = maps.Clone(b.c.Props)
+	b.c.SnapshotList = slices.Clone(b.c.SnapshotList)
+	b.c.SnapshotLog = slices.Clone(b.c.SnapshotLog)
+	b.c.MetadataLog = slices.Clone(b.c.MetadataLog)
+	b.c.SortOrderList = slices.Clone(b.c.SortOrderList)
+	b.c.Refs = maps.Clone(b.c.Refs)
+	b.c.StatisticsList = slices.Clone(b.c.StatisticsList)
+	b.c.PartitionStatsList = slices.Clone(b.c.PartitionStatsList)
+	if b.c.LastPartitionID != nil {
+		id := *b.c.LastPartitionID
+		b.c.LastPartitionID = &id
+	}
+	if b.c.CurrentSnapshotID != nil {
+		id := *b.c.CurrentSnapshotID
+		b.c.CurrentSnapshotID = &id
+	}
+
+	return b, nil
+}
+
+// NewMetadataBuilder returns a builder for the metadata of a new table
+// with the given format version and a newly generated UUID. The table has
+// no schemas, specs or sort orders until they are added, and Build will
+// fail unless at least a schema, a partition spec and a sort order have
+// been added and made current.
+//
+// This is useful for assembling metadata for tests or custom catalogs
+// without a live catalog; see NewMetadata for the common case of a table
+// with a single schema, spec and sort order.
+func NewMetadataBuilder(formatVersion int) (*MetadataBuilder, error) {
+	if formatVersion < 1 || formatVersion > supportedFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d, must be between 1 and %d",
+			ErrInvalidMetadataFormatVersion, formatVersion, supportedFormatVersion)
+	}
+
+	b := &MetadataBuilder{
+		c: commonMetadata{
+			FormatVersion:      formatVersion,
+			CurrentSchemaID:    -1,
+			DefaultSpecID:      -1,
+			DefaultSortOrderID: -1,
+			Props:              iceberg.Properties{},
+			Refs:               map[string]SnapshotRef{},
+			SnapshotLog:        []SnapshotLogEntry{},
+			MetadataLog:        []MetadataLogEntry{},
+		},
+		addedSnapshots: make(map[int64]struct{}),
+		clock:          SystemClock,
+	}
+
+	id := uuid.New()
+	b.c.UUID = id
+	b.updates = append(b.updates, NewAssignUUIDUpdate(id),
+		NewUpgradeFormatVersionUpdate(formatVersion))
+	return b, nil
+}
+
+// NewMetadata returns the metadata for a new table with the given schema,
+// partition spec and sort order. The format version of the table is taken
+// from the PropertyFormatVersion property, defaulting to
+// DefaultFormatVersion, and an error is returned if the schema uses types
+// or features which the format version doesn't support.
+func NewMetadata(sc *iceberg.Schema, spec *iceberg.PartitionSpec, sortOrder SortOrder, location string, props iceberg.Properties) (Metadata, error) {
+	formatVersion := DefaultFormatVersion
+	props = maps.Clone(props)
+	if v, ok := props[PropertyFormatVersion]; ok {
+		var err error
+		if formatVersion, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%w: invalid format version %q",
+				ErrInvalidMetadataFormatVersion, v)
+		}
+		delete(props, PropertyFormatVersion)
+	}
+
+	b, err := NewMetadataBuilder(formatVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkSchemaFormatVersion(sc, formatVersion); err != nil {
+		return nil, err
+	}
+
+	if spec == nil {
+		spec = iceberg.UnpartitionedSpec
+	}
+
+	if _, err = b.AddSchema(sc, sc.HighestFieldID()); err != nil {
+		return nil, err
+	}
+	if _, err = b.SetCurrentSchemaID(-1); err != nil {
+		return nil, err
+	}
+	if _, err = b.AddPartitionSpec(spec); err != nil {
+		return nil, err
+	}
+	if _, err = b.SetDefaultSpecID(-1); err != nil {
+		return nil, err
+	}
+	if _, err = b.AddSortOrder(&sortOrder); err != nil {
+		return nil, err
+	}
+	if _, err = b.SetDefaultSortOrderID(-1); err != nil {
+		return nil, err
+	}
+	if _, err = b.SetLocation(location); err != nil {
+		return nil, err
+	}
+	if _, err = b.SetProperties(props); err != nil {
+		return nil, err
+	}
+
+	return b.Build()
+}
+
+// checkSchemaFormatVersion returns an error if the schema has fields
+// which require a newer format version than the one given.
+func checkSchemaFormatVersion(sc *iceberg.Schema, formatVersion int) error {
+	if formatVersion >= 3 {
+		return nil
+	}
+
+	fields, err := iceberg.IndexByID(sc)
+	if err != nil {
+		return err
+	}
+
+	ids := maps.Keys(fields)
+	slices.Sort(ids)
+	for _, id := range ids {
+		field := fields[id]
+		switch field.Type.(type) {
+		case iceberg.TimestampNsType, iceberg.TimestampTzNsType, iceberg.UnknownType,
+			iceberg.VariantType, iceberg.GeometryType, iceberg.GeographyType:
+			return fmt.Errorf("%w: field '%s' of type %s requires format version 3, not %d",
+				ErrInvalidMetadataFormatVersion, field.Name, field.Type, formatVersion)
+		}
+
+		if field.InitialDefault != nil {
+			return fmt.Errorf("%w: default value of field '%s' requires format version 3, not %d",
+				ErrInvalidMetadataFormatVersion, field.Name, formatVersion)
+		}
+	}
+	return nil
+}
+
+// Updates returns the list of changes which have been applied to this
+// builder, in the order they were applied.
+func (b *MetadataBuilder) Updates() []Update { return slices.Clone(b.updates) }
+
+// HasChanges returns true if any changes have been applied to the builder.
+func (b *MetadataBuilder) HasChanges() bool { return len(b.updates) > 0 }
+
+// AddSchema adds a new schema to the table, reusing the ID of an existing
+// schema if one with the same fields already exists. The last-column-id of
+// the table is set to newLastColumnID, which can't be lower than the
+// current value or than the highest field ID of the schema.
+func (b *MetadataBuilder) AddSchema(schema *iceberg.Schema, newLastColumnID int) (*MetadataBuilder, error) {
+	if newLastColumnID < b.c.LastColumnId {
+		return nil, fmt.Errorf("%w: invalid last column id %d, must be >= %d",
+			ErrInvalidUpdate, newLastColumnID, b.c.LastColumnId)
+	}
+
+	if highest := schema.HighestFieldID(); newLastColumnID < highest {
+		return nil, fmt.Errorf("%w: invalid last column id %d, lower than the highest field id %d of the schema",
+			ErrInvalidUpdate, newLastColumnID, highest)
+	}
+
+	for _, s := range b.c.SchemaList {
+		if s.Equals(schema) {
+			id := s.ID
+			b.lastAddedSchemaID = &id
+			return b, nil
+		}
+	}
+
+	newID := 0
+	for _, s := range b.c.SchemaList {
+		if s.ID >= newID {
+			newID = s.ID + 1
+		}
+	}
+
+	newSchema := schema
+	if schema.ID != newID {
+		newSchema = iceberg.NewSchemaWithIdentifiers(newID,
+			slices.Clone(schema.IdentifierFieldIDs), schema.Fields()...)
+	}
+
+	b.c.SchemaList = append(b.c.SchemaList, newSchema)
+	b.c.LastColumnId = newLastColumnID
+	b.lastAddedSchemaID = &newID
+	b.updates = append(b.updates, NewAddSchemaUpdate(newSchema, newLastColumnID))
+	return b, nil
+}
+
+// SetCurrentSchemaID sets the current schema of the table, -1 can be
+// used to refer to the last schema added by this builder.
+func (b *MetadataBuilder) SetCurrentSchemaID(id int) (*MetadataBuilder, error) {
+	if id == -1 {
+		if b.lastAddedSchemaID == nil {
+			return nil, fmt.Errorf("%w: cannot set current schema to last added schema, no schema has been added",
+				ErrInvalidUpdate)
+		}
+		id = *b.lastAddedSchemaID
+	}
+
+	if id == b.c.CurrentSchemaID {
+		return b, nil
+	}
+
+	if !slices.ContainsFunc(b.c.SchemaList, func(s *iceberg.Schema) bool { return s.ID == id }) {
+		return nil, fmt.Errorf("%w: cannot set current schema to unknown schema id %d",
+			ErrInvalidUpdate, id)
+	}
+
+	b.c.CurrentSchemaID = id
+	if b.lastAddedSchemaID != nil && *b.lastAddedSchemaID == id && b.addedByBuilder(id, UpdateAddSchema) {
+		id = -1
+	}
+	b.updates = append(b.updates, NewSetCurrentSchemaUpdate(id))
+	return b, nil
+}
+
+// AddPartitionSpec adds a new partition spec to the table, reusing the ID of
+// an existing spec if one with equivalent fields already exists. The
+// last-partition-id of the table is updated to account for the fields of
+// the new spec.
+func (b *MetadataBuilder) AddPartitionSpec(spec *iceberg.PartitionSpec) (*MetadataBuilder, error) {
+	for _, s := range b.c.Specs {
+		if s.CompatibleWith(spec) {
+			id := s.ID()
+			b.lastAddedSpecID = &id
+			return b, nil
+		}
+	}
+
+	newID := 0
+	for _, s := range b.c.Specs {
+		if s.ID() >= newID {
+			newID = s.ID() + 1
+		}
+	}
+
+	fields := make([]iceberg.PartitionField, spec.NumFields())
+	for i := range fields {
+		fields[i] = spec.Field(i)
+	}
+	newSpec := iceberg.NewPartitionSpecID(newID, fields...)
+
+	b.c.Specs = append(b.c.Specs, newSpec)
+	if last := newSpec.LastAssignedFieldID(); b.c.LastPartitionID == nil || last > *b.c.LastPartitionID {
+		b.c.LastPartitionID = &last
+	}
+	b.lastAddedSpecID = &newID
+	b.updates = append(b.updates, NewAddPartitionSpecUpdate(&newSpec))
+	return b, nil
+}
+
+// SetDefaultSpecID sets the default partition spec of the table, -1 can
+// be used to refer to the last spec added by this builder.
+func (b *MetadataBuilder) SetDefaultSpecID(id int) (*MetadataBuilder, error) {
+	if id == -1 {
+		if b.lastAddedSpecID == nil {
+			return nil, fmt.Errorf("%w: cannot set default spec to last added spec, no spec has been added",
+				ErrInvalidUpdate)
+		}
+		id = *b.lastAddedSpecID
+	}
+
+	if id == b.c.DefaultSpecID {
+		return b, nil
+	}
+
+	if !slices.ContainsFunc(b.c.Specs, func(s iceberg.PartitionSpec) bool { return s.ID() == id }) {
+		return nil, fmt.Errorf("%w: cannot set default spec to unknown spec id %d",
+			ErrInvalidUpdate, id)
+	}
+
+	b.c.DefaultSpecID = id
+	if b.lastAddedSpecID != nil && *b.lastAddedSpecID == id && b.addedByBuilder(id, UpdateAddPartitionSpec) {
+		id = -1
+	}
+	b.updates = append(b.updates, NewSetDefaultSpecUpdate(id))
+	return b, nil
+}
+
+// AddSortOrder adds a new sort order to the table, reusing the ID of an
+// existing order if one with the same fields already exists. An order
+// without any fields is always given the unsorted order ID.
+func (b *MetadataBuilder) AddSortOrder(order *SortOrder) (*MetadataBuilder, error) {
+	for _, o := range b.c.SortOrderList {
+		if slices.Equal(o.Fields, order.Fields) {
+			id := o.OrderID
+			b.lastAddedOrderID = &id
+			return b, nil
+		}
+	}
+
+	newID := UnsortedSortOrderID
+	if len(order.Fields) > 0 {
+		newID = InitialSortOrderID
+		for _, o := range b.c.SortOrderList {
+			if o.OrderID >= newID {
+				newID = o.OrderID + 1
+			}
+		}
+	}
+
+	newOrder := SortOrder{OrderID: newID, Fields: slices.Clone(order.Fields)}
+	if newOrder.Fields == nil {
+		newOrder.Fields = []SortField{}
+	}
+	b.c.SortOrderList = append(b.c.SortOrderList, newOrder)
+	b.lastAddedOrderID = &newID
+	b.updates = append(b.updates, NewAddSortOrderUpdate(&newOrder))
+	return b, nil
+}
+
+// SetDefaultSortOrderID sets the default sort order of the table, -1 can
+// be used to refer to the last order added by this builder.
+func (b *MetadataBuilder) SetDefaultSortOrderID(id int) (*MetadataBuilder, error) {
+	if id == -1 {
+		if b.lastAddedOrderID == nil {
+			return nil, fmt.Errorf("%w: cannot set default sort order to last added order, no order has been added",
+				ErrInvalidUpdate)
+		}
+		id = *b.lastAddedOrderID
+	}
+
+	if id == b.c.DefaultSortOrderID {
+		return b, nil
+	}
+
+	if !slices.ContainsFunc(b.c.SortOrderList, func(o SortOrder) bool { return o.OrderID == id }) {
+		return nil, fmt.Errorf("%w: cannot set default sort order to unknown order id %d",
+			ErrInvalidUpdate, id)
+	}
+
+	b.c.DefaultSortOrderID = id
+	if b.lastAddedOrderID != nil && *b.lastAddedOrderID == id && b.addedByBuilder(id, UpdateAddSortOrder) {
+		id = -1
+	}
+	b.updates = append(b.updates, NewSetDefaultSortOrderUpdate(id))
+	return b, nil
+}
+
+// addedByBuilder returns true if the schema, spec or sort order with the
+// given ID was added by an update recorded in this builder, rather than
+// already existing in the base metadata.
+func (b *MetadataBuilder) addedByBuilder(id int, action string) bool {
+	for _, u := range b.updates {
+		switch u := u.(type) {
+		case *addSchemaUpdate:
+			if action == UpdateAddSchema && u.Schema.ID == id {
+				return true
+			}
+		case *addPartitionSpecUpdate:
+			if action == UpdateAddPartitionSpec && u.Spec.ID() == id {
+				return true
+			}
+		case *addSortOrderUpdate:
+			if action == UpdateAddSortOrder && u.SortOrder.OrderID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddSnapshot adds a snapshot to the table. For format version 2 and
+// above, the snapshot must have a sequence number higher than the table's
+// last sequence number unless it has no parent. The last-updated-ms of the
+// resulting metadata will be the timestamp of the snapshot.
+func (b *MetadataBuilder) AddSnapshot(snapshot *Snapshot) (*MetadataBuilder, error) {
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: cannot add nil snapshot", ErrInvalidUpdate)
+	}
+
+	if b.snapshot(snapshot.SnapshotID) != nil {
+		return nil, fmt.Errorf("%w: snapshot with id %d already exists",
+			ErrInvalidUpdate, snapshot.SnapshotID)
+	}
+
+	if snapshot.SchemaID != nil && !slices.ContainsFunc(b.c.SchemaList, func(s *iceberg.Schema) bool {
+		return s.ID == *snapshot.SchemaID
+	}) {
+		return nil, fmt.Errorf("%w: snapshot %d references unknown schema %d",
+			ErrInvalidUpdate, snapshot.SnapshotID, *snapshot.SchemaID)
+	}
+
+	if b.c.FormatVersion >= 2 {
+		if snapshot.ParentSnapshotID != nil && int(snapshot.SequenceNumber) <= b.lastSequenceNumber {
+			return nil, fmt.Errorf("%w: cannot add snapshot with sequence number %d older than last sequence number %d",
+				ErrInvalidUpdate, snapshot.SequenceNumber, b.lastSequenceNumber)
+		}
+		if int(snapshot.SequenceNumber) > b.lastSequenceNumber {
+			b.lastSequenceNumber = int(snapshot.SequenceNumber)
+		}
+	}
+
+	b.c.SnapshotList = append(b.c.SnapshotList, *snapshot)
+	b.addedSnapshots[snapshot.SnapshotID] = struct{}{}
+	b.lastUpdatedMS = snapshot.TimestampMs
+	b.updates = append(b.updates, NewAddSnapshotUpdate(snapshot))
+	return b, nil
+}
+
+func (b *MetadataBuilder) snapshot(id int64) *Snapshot {
+	for i := range b.c.SnapshotList {
+		if b.c.SnapshotList[i].SnapshotID == id {
+			return &b.c.SnapshotList[i]
+		}
+	}
+	return nil
+}
+
+type setSnapshotRefOption func(*SnapshotRef)
+
+// WithMaxRefAgeMs sets the max-ref-age-ms of a snapshot ref.
+func WithMaxRefAgeMs(ms int64) setSnapshotRefOption {
+	return func(r *SnapshotRef) { r.MaxRefAgeMs = &ms }
+}
+
+// WithMaxSnapshotAgeMs sets the max-snapshot-age-ms of a branch.
+func WithMaxSnapshotAgeMs(ms int64) setSnapshotRefOption {
+	return func(r *SnapshotRef) { r.MaxSnapshotAgeMs = &ms }
+}
+
+// WithMinSnapshotsToKeep sets the min-snapshots-to-keep of a branch.
+func WithMinSnapshotsToKeep(n int) setSnapshotRefOption {
+	return func(r *SnapshotRef) { r.MinSnapshotsToKeep = &n }
+}
+
+// SetSnapshotRef creates or updates the named ref to point at the given
+// snapshot, which must exist. Setting the main branch also changes the
+// current snapshot of the table and adds an entry to the snapshot log.
+func (b *MetadataBuilder) SetSnapshotRef(name string, snapshotID int64, refType RefType, opts ...setSnapshotRefOption) (*MetadataBuilder, error) {
+	switch refType {
+	case BranchRef, TagRef:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRefType, refType)
+	}
+
+	ref := SnapshotRef{SnapshotID: snapshotID, SnapshotRefType: refType}
+	for _, opt := range opts {
+		opt(&ref)
+	}
+
+	if existing, ok := b.c.Refs[name]; ok && refsEqual(existing, ref) {
+		return b, nil
+	}
+
+	snapshot := b.snapshot(snapshotID)
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: cannot set %s to unknown snapshot %d",
+			ErrInvalidUpdate, name, snapshotID)
+	}
+
+	if b.c.Refs == nil {
+		b.c.Refs = make(map[string]SnapshotRef)
+	}
+	b.c.Refs[name] = ref
+
+	if name == MainBranch {
+		b.c.CurrentSnapshotID = &snapshotID
+
+		ts := b.clock.Now().UnixMilli()
+		if _, ok := b.addedSnapshots[snapshotID]; ok {
+			ts = snapshot.TimestampMs
+		}
+		b.c.SnapshotLog = append(b.c.SnapshotLog, SnapshotLogEntry{
+			SnapshotID:  snapshotID,
+			TimestampMs: ts,
+		})
+	}
+
+	b.updates = append(b.updates, NewSetSnapshotRefUpdate(name, ref))
+	return b, nil
+}
+
+// SetCurrentSnapshot makes the snapshot, which must exist, the current
+// snapshot of the table by setting the main branch to it.
+func (b *MetadataBuilder) SetCurrentSnapshot(snapshotID int64) (*MetadataBuilder, error) {
+	return b.SetSnapshotRef(MainBranch, snapshotID, BranchRef)
+}
+
+func refsEqual(a, b SnapshotRef) bool {
+	return a.SnapshotID == b.SnapshotID && a.SnapshotRefType == b.SnapshotRefType &&
+		ptrEqual(a.MinSnapshotsToKeep, b.MinSnapshotsToKeep) &&
+		ptrEqual(a.MaxSnapshotAgeMs, b.MaxSnapshotAgeMs) &&
+		ptrEqual(a.MaxRefAgeMs, b.MaxRefAgeMs)
+}
+
+func ptrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// RemoveSnapshots removes the snapshots with the given IDs from the table,
+// along with any refs which point to them. Entries of the snapshot log from
+// before the most recently removed snapshot are dropped, as that part of
+// the history can no longer be reconstructed.
+func (b *MetadataBuilder) RemoveSnapshots(ids []int64) (*MetadataBuilder, error) {
+	if len(ids) == 0 {
+		return b, nil
+	}
+
+	removed := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		removed[id] = struct{}{}
+	}
+
+	b.c.SnapshotList = slices.DeleteFunc(b.c.SnapshotList, func(s Snapshot) bool {
+		_, ok := removed[s.SnapshotID]
+		return ok
+	})
+
+	for name, ref := range b.c.Refs {
+		if _, ok := removed[ref.SnapshotID]; ok {
+			delete(b.c.Refs, name)
+			if name == MainBranch {
+				b.c.CurrentSnapshotID = nil
+			}
+		}
+	}
+
+	var newLog []SnapshotLogEntry
+	for _, entry := range b.c.SnapshotLog {
+		if _, ok := removed[entry.SnapshotID]; ok {
+			newLog = newLog[:0]
+			continue
+		}
+		newLog = append(newLog, entry)
+	}
+	b.c.SnapshotLog = append([]SnapshotLogEntry{}, newLog...)
+
+	// statistics of removed snapshots can't be used anymore
+	b.c.StatisticsList = slices.DeleteFunc(b.c.StatisticsList, func(s StatisticsFile) bool {
+		_, ok := removed[s.SnapshotID]
+		return ok
+	})
+	b.c.PartitionStatsList = slices.DeleteFunc(b.c.PartitionStatsList, func(s PartitionStatisticsFile) bool {
+		_, ok := removed[s.SnapshotID]
+		return ok
+	})
+
+	b.updates = append(b.updates, NewRemoveSnapshotsUpdate(ids))
+	return b, nil
+}
+
+// RemoveSnapshotRef removes the named ref from the table. Removing the
+// main branch leaves the table without a current snapshot.
+func (b *MetadataBuilder) RemoveSnapshotRef(name string) (*MetadataBuilder, error) {
+	if _, ok := b.c.Refs[name]; !ok {
+		return b, nil
+	}
+
+	delete(b.c.Refs, name)
+	if name == MainBranch {
+		b.c.CurrentSnapshotID = nil
+	}
+
+	b.updates = append(b.updates, NewRemoveSnapshotRefUpdate(name))
+	return b, nil
+}
+
+// SetProperties adds or replaces the given table properties.
+func (b *MetadataBuilder) SetProperties(props iceberg.Properties) (*MetadataBuilder, error) {
+	if len(props) == 0 {
+		return b, nil
+	}
+
+	if b.c.Props == nil {
+		b.c.Props = make(iceberg.Properties, len(props))
+	}
+	maps.Copy(b.c.Props, props)
+
+	b.updates = append(b.updates, NewSetPropertiesUpdate(props))
+	return b, nil
+}
+
+// RemoveProperties removes the given keys from the table properties. Keys
+// which don't exist are ignored.
+func (b *MetadataBuilder) RemoveProperties(keys []string) (*MetadataBuilder, error) {
+	if len(keys) == 0 {
+		return b, nil
+	}
+
+	for _, k := range keys {
+		delete(b.c.Props, k)
+	}
+
+	b.updates = append(b.updates, NewRemovePropertiesUpdate(keys))
+	return b, nil
+}
+
+// SetStatistics adds the statistics file for its snapshot, replacing any
+// existing statistics file for the same snapshot.
+func (b *MetadataBuilder) SetStatistics(stats StatisticsFile) (*MetadataBuilder, error) {
+	b.c.StatisticsList = slices.DeleteFunc(b.c.StatisticsList, func(s StatisticsFile) bool {
+		return s.SnapshotID == stats.SnapshotID
+	})
+	b.c.StatisticsList = append(b.c.StatisticsList, stats)
+
+	b.updates = append(b.updates, NewSetStatisticsUpdate(stats))
+	return b, nil
+}
+
+// RemoveStatistics removes the statistics file for the snapshot, if any.
+func (b *MetadataBuilder) RemoveStatistics(snapshotID int64) (*MetadataBuilder, error) {
+	n := len(b.c.StatisticsList)
+	b.c.StatisticsList = slices.DeleteFunc(b.c.StatisticsList, func(s StatisticsFile) bool {
+		return s.SnapshotID == snapshotID
+	})
+	if len(b.c.StatisticsList) == n {
+		return b, nil
+	}
+
+	b.updates = append(b.updates, NewRemoveStatisticsUpdate(snapshotID))
+	return b, nil
+}
+
+// SetPartitionStatistics adds the partition statistics file for its
+// snapshot, replacing any existing one for the same snapshot.
+func (b *MetadataBuilder) SetPartitionStatistics(stats PartitionStatisticsFile) (*MetadataBuilder, error) {
+	b.c.PartitionStatsList = slices.DeleteFunc(b.c.PartitionStatsList, func(s PartitionStatisticsFile) bool {
+		return s.SnapshotID == stats.SnapshotID
+	})
+	b.c.PartitionStatsList = append(b.c.PartitionStatsList, stats)
+
+	b.updates = append(b.updates, NewSetPartitionStatisticsUpdate(stats))
+	return b, nil
+}
+
+// RemovePartitionStatistics removes the partition statistics file for the
+// snapshot, if any.
+func (b *MetadataBuilder) RemovePartitionStatistics(snapshotID int64) (*MetadataBuilder, error) {
+	n := len(b.c.PartitionStatsList)
+	b.c.PartitionStatsList = slices.DeleteFunc(b.c.PartitionStatsList, func(s PartitionStatisticsFile) bool {
+		return s.SnapshotID == snapshotID
+	})
+	if len(b.c.PartitionStatsList) == n {
+		return b, nil
+	}
+
+	b.updates = append(b.updates, NewRemovePartitionStatisticsUpdate(snapshotID))
+	return b, nil
+}
+
+// SetLocation changes the base location of the table.
+func (b *MetadataBuilder) SetLocation(loc string) (*MetadataBuilder, error) {
+	if loc == b.c.Loc {
+		return b, nil
+	}
+
+	b.c.Loc = loc
+	b.updates = append(b.updates, NewSetLocationUpdate(loc))
+	return b, nil
+}
+
+// SetUUID assigns the UUID of the table.
+func (b *MetadataBuilder) SetUUID(id uuid.UUID) (*MetadataBuilder, error) {
+	if id == uuid.Nil {
+		return nil, fmt.Errorf("%w: cannot assign a nil table uuid", ErrInvalidUpdate)
+	}
+
+	if id == b.c.UUID {
+		return b, nil
+	}
+
+	b.c.UUID = id
+	b.updates = append(b.updates, NewAssignUUIDUpdate(id))
+	return b, nil
+}
+
+// SetFormatVersion upgrades the format version of the table. Downgrading
+// is not allowed.
+func (b *MetadataBuilder) SetFormatVersion(formatVersion int) (*MetadataBuilder, error) {
+	switch {
+	case formatVersion < b.c.FormatVersion:
+		return nil, fmt.Errorf("%w: cannot downgrade format version from %d to %d",
+			ErrInvalidUpdate, b.c.FormatVersion, formatVersion)
+	case formatVersion > supportedFormatVersion:
+		return nil, fmt.Errorf("%w: unsupported format version %d",
+			ErrInvalidUpdate, formatVersion)
+	case formatVersion == b.c.FormatVersion:
+		return b, nil
+	}
+
+	b.c.FormatVersion = formatVersion
+	if b.c.UUID == uuid.Nil {
+		b.c.UUID = uuid.New()
+	}
+	b.updates = append(b.updates, NewUpgradeFormatVersionUpdate(formatVersion))
+	return b, nil
+}
+
+// SetClock sets the clock used for the timestamps of the resulting metadata,
+// which defaults to SystemClock.
+func (b *MetadataBuilder) SetClock(c Clock) *MetadataBuilder {
+	b.clock = c
+	return b
+}
+
+// Build returns the resulting metadata after validating it. If changes
+// have been applied, the last-updated-ms of the result will be set to
+// the timestamp of the last added snapshot, or the current time if no
+// snapshot was added.
+func (b *MetadataBuilder) Build() (Metadata, error) {
+	common := b.c
+	if b.HasChanges() {
+		common.LastUpdatedMS = b.lastUpdatedMS
+		if common.LastUpdatedMS == 0 {
+			common.LastUpdatedMS = b.clock.Now().UnixMilli()
+		}
+	}
+
+	if err := common.validate(); err != nil {
+		return nil, err
+	}
+
+	switch common.FormatVersion {
+	case 1:
+		meta := &MetadataV1{commonMetadata: common}
+		// the schema can't be copied by value, so round-trip it
+		// through JSON to populate the v1 schema field.
+		data, err := json.Marshal(common.CurrentSchema())
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &meta.Schema); err != nil {
+			return nil, err
+		}
+
+		spec := common.PartitionSpec()
+		meta.Partition = make([]iceberg.PartitionField, spec.NumFields())
+		for i := range meta.Partition {
+			meta.Partition[i] = spec.Field(i)
+		}
+		return meta, nil
+	case 2:
+		return &MetadataV2{
+			LastSequenceNumber: b.lastSequenceNumber,
+			commonMetadata:     common,
+		}, nil
+	case 3:
+		return &MetadataV3{
+			LastSequenceNumber: b.lastSequenceNumber,
+			NextRowID:          b.nextRowID,
+			commonMetadata:     common,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrInvalidMetadataFormatVersion, common.FormatVersion)
+}
+
+// ApplyUpdates applies the updates, in order, to a copy of the base metadata
+// and returns the resulting metadata. The base metadata is not modified.
+//
+// This is the shared logic used for committing changes to a table, so that
+// the result of applying a set of updates is the same regardless of the
+// catalog being used.
+func ApplyUpdates(base Metadata, updates []Update) (Metadata, error) {
+	return applyUpdates(base, updates, SystemClock)
+}
+
+func applyUpdates(base Metadata, updates []Update, clock Clock) (Metadata, error) {
+	b, err := MetadataBuilderFromBase(base)
+	if err != nil {
+		return nil, err
+	}
+	b.SetClock(clock)
+
+	for _, u := range updates {
+		if err := u.Apply(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Build()
+}
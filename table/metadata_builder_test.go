@@ -0,0 +1,523 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exampleMetadataV2(t *testing.T) table.Metadata {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+	return meta
+}
+
+func TestApplyUpdatesSchema(t *testing.T) {
+	base := exampleMetadataV2(t)
+
+	newSchema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 4, Name: "w", Type: iceberg.PrimitiveTypes.String},
+	)
+
+	meta, err := table.ApplyUpdates(base, []table.Update{
+		table.NewAddSchemaUpdate(newSchema, 4),
+		table.NewSetCurrentSchemaUpdate(-1),
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, meta.Schemas(), 3)
+	assert.Equal(t, 2, meta.CurrentSchema().ID)
+	assert.True(t, newSchema.Equals(meta.CurrentSchema()))
+	assert.Equal(t, 4, meta.LastColumnID())
+
+	// the base metadata is left untouched
+	assert.Len(t, base.Schemas(), 2)
+	assert.Equal(t, 1, base.CurrentSchema().ID)
+
+	// adding an existing schema reuses its id
+	meta, err = table.ApplyUpdates(base, []table.Update{
+		table.NewAddSchemaUpdate(base.Schemas()[0], 3),
+		table.NewSetCurrentSchemaUpdate(-1),
+	})
+	require.NoError(t, err)
+	assert.Len(t, meta.Schemas(), 2)
+	assert.Equal(t, 0, meta.CurrentSchema().ID)
+
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewAddSchemaUpdate(newSchema, 2)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	// the last column id must cover ids nested in the new schema
+	nested := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 4, Name: "m", Type: &iceberg.MapType{
+			KeyID: 5, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 6, ValueType: iceberg.PrimitiveTypes.String}},
+	)
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewAddSchemaUpdate(nested, 4)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewSetCurrentSchemaUpdate(-1)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewSetCurrentSchemaUpdate(5)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+}
+
+func TestApplyUpdatesPartitionSpec(t *testing.T) {
+	base := exampleMetadataV2(t)
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "x", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "y_bucket", Transform: iceberg.BucketTransform{NumBuckets: 16}},
+	)
+
+	meta, err := table.ApplyUpdates(base, []table.Update{
+		table.NewAddPartitionSpecUpdate(&spec),
+		table.NewSetDefaultSpecUpdate(-1),
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, meta.PartitionSpecs(), 2)
+	assert.Equal(t, 1, meta.DefaultPartitionSpec())
+	newSpec := meta.PartitionSpec()
+	assert.Equal(t, 1, newSpec.ID())
+	assert.True(t, newSpec.CompatibleWith(&spec))
+	assert.Equal(t, 1001, *meta.LastPartitionSpecID())
+
+	existing := base.PartitionSpec()
+	meta, err = table.ApplyUpdates(base, []table.Update{
+		table.NewAddPartitionSpecUpdate(&existing),
+	})
+	require.NoError(t, err)
+	assert.Len(t, meta.PartitionSpecs(), 1)
+
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewSetDefaultSpecUpdate(3)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+}
+
+func TestApplyUpdatesSortOrder(t *testing.T) {
+	base := exampleMetadataV2(t)
+
+	order := table.SortOrder{Fields: []table.SortField{
+		{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortDESC, NullOrder: table.NullsLast},
+	}}
+
+	meta, err := table.ApplyUpdates(base, []table.Update{
+		table.NewAddSortOrderUpdate(&order),
+		table.NewSetDefaultSortOrderUpdate(-1),
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, meta.SortOrders(), 2)
+	assert.Equal(t, 4, meta.SortOrder().OrderID)
+	assert.Equal(t, order.Fields, meta.SortOrder().Fields)
+
+	meta, err = table.ApplyUpdates(base, []table.Update{
+		table.NewAddSortOrderUpdate(&table.SortOrder{}),
+		table.NewSetDefaultSortOrderUpdate(-1),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, table.UnsortedSortOrderID, meta.SortOrder().OrderID)
+}
+
+func TestApplyUpdatesSnapshots(t *testing.T) {
+	base := exampleMetadataV2(t)
+
+	parent := int64(3055729675574597004)
+	snap := table.Snapshot{
+		SnapshotID:       1234,
+		ParentSnapshotID: &parent,
+		SequenceNumber:   35,
+		TimestampMs:      1602638580000,
+		ManifestList:     "s3://a/b/3.avro",
+		Summary:          &table.Summary{Operation: table.OpAppend},
+	}
+
+	meta, err := table.ApplyUpdates(base, []table.Update{
+		table.NewAddSnapshotUpdate(&snap),
+		table.NewSetSnapshotRefUpdate(table.MainBranch, table.SnapshotRef{
+			SnapshotID: 1234, SnapshotRefType: table.BranchRef}),
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, meta.Snapshots(), 3)
+	assert.EqualValues(t, 1234, meta.CurrentSnapshot().SnapshotID)
+	assert.EqualValues(t, 35, meta.(*table.MetadataV2).LastSequenceNumber)
+	assert.EqualValues(t, 1602638580000, meta.LastUpdatedMillis())
+	assert.Equal(t, table.SnapshotLogEntry{SnapshotID: 1234, TimestampMs: 1602638580000},
+		meta.SnapshotLogs()[len(meta.SnapshotLogs())-1])
+
+	// sequence numbers must increase
+	stale := snap
+	stale.SnapshotID, stale.SequenceNumber = 4321, 34
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewAddSnapshotUpdate(&stale)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	// can't add the same snapshot twice
+	_, err = table.ApplyUpdates(meta, []table.Update{table.NewAddSnapshotUpdate(&snap)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	// refs must point to existing snapshots
+	_, err = table.ApplyUpdates(base, []table.Update{
+		table.NewSetSnapshotRefUpdate("branch", table.SnapshotRef{SnapshotID: 1, SnapshotRefType: table.BranchRef})})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	maxAge := int64(1000)
+	meta, err = table.ApplyUpdates(base, []table.Update{
+		table.NewSetSnapshotRefUpdate("tag", table.SnapshotRef{
+			SnapshotID: 3051729675574597004, SnapshotRefType: table.TagRef, MaxRefAgeMs: &maxAge}),
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3051729675574597004, meta.SnapshotByName("tag").SnapshotID)
+	assert.EqualValues(t, 3055729675574597004, meta.CurrentSnapshot().SnapshotID)
+
+	meta, err = table.ApplyUpdates(meta, []table.Update{table.NewRemoveSnapshotRefUpdate("tag")})
+	require.NoError(t, err)
+	assert.Nil(t, meta.SnapshotByName("tag"))
+
+	meta, err = table.ApplyUpdates(base, []table.Update{
+		table.NewRemoveSnapshotsUpdate([]int64{3051729675574597004}),
+	})
+	require.NoError(t, err)
+	assert.Len(t, meta.Snapshots(), 1)
+	assert.Nil(t, meta.SnapshotByName("test"))
+	assert.EqualValues(t, 3055729675574597004, meta.CurrentSnapshot().SnapshotID)
+	assert.Equal(t, []table.SnapshotLogEntry{
+		{SnapshotID: 3055729675574597004, TimestampMs: 1555100955770},
+	}, meta.SnapshotLogs())
+
+	meta, err = table.ApplyUpdates(base, []table.Update{
+		table.NewRemoveSnapshotRefUpdate(table.MainBranch),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, meta.CurrentSnapshot())
+}
+
+func TestApplyUpdatesStatistics(t *testing.T) {
+	base := exampleMetadataV2(t)
+
+	stats := func(snapshotID int64, path string) table.StatisticsFile {
+		return table.StatisticsFile{
+			SnapshotID: snapshotID, StatisticsPath: path,
+			FileSizeInBytes: 413, FileFooterSizeInBytes: 42,
+			BlobMetadata: []table.BlobMetadata{{
+				Type: "apache-datasketches-theta-v1", SnapshotID: snapshotID,
+				SequenceNumber: 1, Fields: []int{1},
+				Properties: map[string]string{"ndv": "3"},
+			}},
+		}
+	}
+	partStats := table.PartitionStatisticsFile{SnapshotID: 3051729675574597004,
+		StatisticsPath: "s3://a/b/partition-stats.parquet", FileSizeInBytes: 43}
+
+	meta, err := table.ApplyUpdates(base, []table.Update{
+		table.NewSetStatisticsUpdate(stats(3055729675574597004, "s3://a/b/1.puffin")),
+		table.NewSetStatisticsUpdate(stats(3051729675574597004, "s3://a/b/2.puffin")),
+		// replaces the statistics of the same snapshot
+		table.NewSetStatisticsUpdate(stats(3055729675574597004, "s3://a/b/3.puffin")),
+		table.NewSetPartitionStatisticsUpdate(partStats),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []table.StatisticsFile{
+		stats(3051729675574597004, "s3://a/b/2.puffin"),
+		stats(3055729675574597004, "s3://a/b/3.puffin"),
+	}, meta.Statistics())
+	assert.Equal(t, []table.PartitionStatisticsFile{partStats}, meta.PartitionStatistics())
+	assert.Empty(t, base.Statistics())
+
+	// the statistics are persisted in the metadata
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	parsed, err := table.ParseMetadataBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, meta.Statistics(), parsed.Statistics())
+	assert.Equal(t, meta.PartitionStatistics(), parsed.PartitionStatistics())
+
+	removed, err := table.ApplyUpdates(meta, []table.Update{
+		table.NewRemoveStatisticsUpdate(3055729675574597004),
+		table.NewRemovePartitionStatisticsUpdate(3051729675574597004),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []table.StatisticsFile{stats(3051729675574597004, "s3://a/b/2.puffin")},
+		removed.Statistics())
+	assert.Empty(t, removed.PartitionStatistics())
+
+	// the statistics of removed snapshots are dropped with them
+	expired, err := table.ApplyUpdates(meta, []table.Update{
+		table.NewRemoveSnapshotsUpdate([]int64{3051729675574597004}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []table.StatisticsFile{stats(3055729675574597004, "s3://a/b/3.puffin")},
+		expired.Statistics())
+	assert.Empty(t, expired.PartitionStatistics())
+}
+
+func TestApplyUpdatesTableProperties(t *testing.T) {
+	base := exampleMetadataV2(t)
+	newUUID := uuid.New()
+
+	meta, err := table.ApplyUpdates(base, []table.Update{
+		table.NewSetLocationUpdate("s3://bucket/new/location"),
+		table.NewAssignUUIDUpdate(newUUID),
+		table.NewSetPropertiesUpdate(iceberg.Properties{"a": "b"}),
+		table.NewRemovePropertiesUpdate([]string{"read.split.target.size"}),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://bucket/new/location", meta.Location())
+	assert.Equal(t, newUUID, meta.TableUUID())
+	assert.Equal(t, iceberg.Properties{"a": "b"}, meta.Properties())
+
+	_, err = table.ApplyUpdates(base, []table.Update{table.NewAssignUUIDUpdate(uuid.Nil)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+}
+
+func TestApplyUpdatesFormatVersion(t *testing.T) {
+	base, err := table.ParseMetadataString(ExampleTableMetadataV1)
+	require.NoError(t, err)
+
+	meta, err := table.ApplyUpdates(base, []table.Update{table.NewUpgradeFormatVersionUpdate(2)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, meta.Version())
+	assert.IsType(t, &table.MetadataV2{}, meta)
+	assert.Equal(t, base.TableUUID(), meta.TableUUID())
+
+	_, err = table.ApplyUpdates(meta, []table.Update{table.NewUpgradeFormatVersionUpdate(1)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	_, err = table.ApplyUpdates(meta, []table.Update{table.NewUpgradeFormatVersionUpdate(4)})
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+}
+
+func TestMetadataBuilderUpdates(t *testing.T) {
+	base := exampleMetadataV2(t)
+
+	b, err := table.MetadataBuilderFromBase(base)
+	require.NoError(t, err)
+
+	_, err = b.AddSchema(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true}), 3)
+	require.NoError(t, err)
+	_, err = b.SetCurrentSchemaID(0)
+	require.NoError(t, err)
+	_, err = b.SetProperties(iceberg.Properties{"a": "b"})
+	require.NoError(t, err)
+
+	// adding an existing schema and setting it as current only records
+	// the change of the current schema
+	updates := b.Updates()
+	require.Len(t, updates, 2)
+	assert.Equal(t, table.UpdateSetCurrentSchema, updates[0].Action())
+	assert.Equal(t, table.UpdateSetProperties, updates[1].Action())
+
+	direct, err := b.Build()
+	require.NoError(t, err)
+
+	replayed, err := table.ApplyUpdates(base, updates)
+	require.NoError(t, err)
+	assert.Equal(t, direct.CurrentSchema().ID, replayed.CurrentSchema().ID)
+	assert.Equal(t, direct.Properties(), replayed.Properties())
+}
+
+func TestNewMetadataUnsorted(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+
+	for _, order := range []table.SortOrder{table.UnsortedSortOrder, {}, {OrderID: 5}} {
+		meta, err := table.NewMetadata(sc, nil, order, "s3://bucket/table", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, table.UnsortedSortOrderID, meta.SortOrder().OrderID)
+		assert.True(t, meta.SortOrder().IsUnsorted())
+		assert.Equal(t, []table.SortOrder{table.UnsortedSortOrder}, meta.SortOrders())
+
+		data, err := json.Marshal(meta)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"default-sort-order-id":0,"sort-orders":[{"order-id":0,"fields":[]}]`)
+	}
+}
+
+func TestNewMetadataFormatVersion(t *testing.T) {
+	sc := iceberg.NewSchema(7,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4}})
+
+	for _, version := range []int{1, 2, 3} {
+		t.Run(strconv.Itoa(version), func(t *testing.T) {
+			meta, err := table.NewMetadata(sc, &spec, table.UnsortedSortOrder, "s3://bucket/table",
+				iceberg.Properties{table.PropertyFormatVersion: strconv.Itoa(version), "owner": "me"})
+			require.NoError(t, err)
+
+			assert.Equal(t, version, meta.Version())
+			assert.Equal(t, iceberg.Properties{"owner": "me"}, meta.Properties())
+			assert.Equal(t, 0, meta.CurrentSchema().ID)
+			assert.Equal(t, 2, meta.LastColumnID())
+			assert.Equal(t, 1000, *meta.LastPartitionSpecID())
+			assert.NotEqual(t, uuid.Nil, meta.TableUUID())
+
+			data, err := json.Marshal(meta)
+			require.NoError(t, err)
+			// v1 metadata also writes the current schema and spec in the
+			// single schema and partition-spec fields
+			assert.Equal(t, version == 1, strings.Contains(string(data), `"schema":`))
+
+			parsed, err := table.ParseMetadataBytes(data)
+			require.NoError(t, err)
+			assert.Equal(t, version, parsed.Version())
+			assert.True(t, sc.Equals(parsed.CurrentSchema()))
+		})
+	}
+
+	t.Run("default", func(t *testing.T) {
+		meta, err := table.NewMetadata(sc, nil, table.UnsortedSortOrder, "s3://bucket/table", nil)
+		require.NoError(t, err)
+		assert.Equal(t, table.DefaultFormatVersion, meta.Version())
+		spec := meta.PartitionSpec()
+		assert.True(t, spec.IsUnpartitioned())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, v := range []string{"0", "4", "two"} {
+			_, err := table.NewMetadata(sc, nil, table.UnsortedSortOrder, "s3://bucket/table",
+				iceberg.Properties{table.PropertyFormatVersion: v})
+			assert.ErrorIs(t, err, table.ErrInvalidMetadataFormatVersion, v)
+		}
+	})
+
+	t.Run("v3 types", func(t *testing.T) {
+		v3Schema := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampNs})
+
+		_, err := table.NewMetadata(v3Schema, nil, table.UnsortedSortOrder, "s3://bucket/table", nil)
+		assert.ErrorIs(t, err, table.ErrInvalidMetadataFormatVersion)
+		assert.ErrorContains(t, err, "field 'ts' of type timestamp_ns requires format version 3, not 2")
+
+		_, err = table.NewMetadata(v3Schema, nil, table.UnsortedSortOrder, "s3://bucket/table",
+			iceberg.Properties{table.PropertyFormatVersion: "3"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestNewMetadataBuilderFixture(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+	)
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Name: "ts_day", Transform: iceberg.DayTransform{}})
+	order := table.SortOrder{OrderID: 1, Fields: []table.SortField{{
+		SourceID: 1, Transform: iceberg.IdentityTransform{},
+		Direction: table.SortASC, NullOrder: table.NullsFirst}}}
+
+	newBuilder := func(t *testing.T) *table.MetadataBuilder {
+		b, err := table.NewMetadataBuilder(2)
+		require.NoError(t, err)
+		_, err = b.AddSchema(sc, sc.HighestFieldID())
+		require.NoError(t, err)
+		_, err = b.SetCurrentSchemaID(-1)
+		require.NoError(t, err)
+		_, err = b.AddPartitionSpec(&spec)
+		require.NoError(t, err)
+		_, err = b.SetDefaultSpecID(-1)
+		require.NoError(t, err)
+		_, err = b.AddSortOrder(&order)
+		require.NoError(t, err)
+		_, err = b.SetDefaultSortOrderID(-1)
+		require.NoError(t, err)
+		_, err = b.SetLocation("s3://bucket/fixture")
+		require.NoError(t, err)
+		_, err = b.SetProperties(iceberg.Properties{"owner": "tests"})
+		require.NoError(t, err)
+		return b
+	}
+
+	schemaID := 0
+	snapshot := table.Snapshot{
+		SnapshotID:     42,
+		SequenceNumber: 1,
+		TimestampMs:    1700000000000,
+		ManifestList:   "s3://bucket/fixture/metadata/snap-42.avro",
+		Summary:        &table.Summary{Operation: table.OpAppend, Properties: map[string]string{}},
+		SchemaID:       &schemaID,
+	}
+
+	b := newBuilder(t)
+	_, err := b.AddSnapshot(&snapshot)
+	require.NoError(t, err)
+	_, err = b.SetCurrentSnapshot(snapshot.SnapshotID)
+	require.NoError(t, err)
+
+	meta, err := b.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, meta.Version())
+	assert.Equal(t, "s3://bucket/fixture", meta.Location())
+	assert.Equal(t, iceberg.Properties{"owner": "tests"}, meta.Properties())
+	assert.Equal(t, 2, meta.LastColumnID())
+	assert.True(t, sc.Equals(meta.CurrentSchema()))
+	assert.Equal(t, 0, meta.DefaultPartitionSpec())
+	assert.Equal(t, 1, meta.SortOrder().OrderID)
+	assert.Equal(t, snapshot.TimestampMs, meta.LastUpdatedMillis())
+	require.NotNil(t, meta.CurrentSnapshot())
+	assert.Equal(t, snapshot.SnapshotID, meta.CurrentSnapshot().SnapshotID)
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	parsed, err := table.ParseMetadataBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, meta, parsed)
+
+	t.Run("unknown schema", func(t *testing.T) {
+		badSchemaID := 5
+		bad := snapshot
+		bad.SchemaID = &badSchemaID
+		_, err := newBuilder(t).AddSnapshot(&bad)
+		assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+		assert.ErrorContains(t, err, "snapshot 42 references unknown schema 5")
+	})
+
+	t.Run("unknown snapshot", func(t *testing.T) {
+		_, err := newBuilder(t).SetCurrentSnapshot(7)
+		assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+	})
+
+	t.Run("missing schema", func(t *testing.T) {
+		b, err := table.NewMetadataBuilder(2)
+		require.NoError(t, err)
+		_, err = b.Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := table.NewMetadataBuilder(4)
+		assert.ErrorIs(t, err, table.ErrInvalidMetadataFormatVersion)
+	})
+}
@@ -18,7 +18,9 @@
 package table_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/apache/iceberg-go"
@@ -26,6 +28,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 )
 
 const ExampleTableMetadataV2 = `{
@@ -188,6 +191,86 @@ func TestMetadataV2Parsing(t *testing.T) {
 	assert.EqualValues(t, "134217728", meta.Properties()["read.split.target.size"])
 }
 
+func TestMetadataV3RowLineageParsing(t *testing.T) {
+	// metadata written by a v3 writer tracks row lineage, with the next
+	// row ID of the table and the row IDs assigned by each snapshot
+	const metadataV3 = `{
+    "format-version": 3,
+    "table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+    "location": "s3://bucket/test/location",
+    "last-sequence-number": 2,
+    "last-updated-ms": 1602638573590,
+    "last-column-id": 1,
+    "current-schema-id": 0,
+    "schemas": [{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "required": true, "type": "long"}]}],
+    "default-spec-id": 0,
+    "partition-specs": [{"spec-id": 0, "fields": []}],
+    "last-partition-id": 999,
+    "default-sort-order-id": 0,
+    "sort-orders": [{"order-id": 0, "fields": []}],
+    "current-snapshot-id": 3055729675574597004,
+    "next-row-id": 150,
+    "snapshots": [
+        {
+            "snapshot-id": 3051729675574597004,
+            "timestamp-ms": 1515100955770,
+            "sequence-number": 1,
+            "summary": {"operation": "append"},
+            "manifest-list": "s3://a/b/1.avro",
+            "first-row-id": 0,
+            "added-rows": 100
+        },
+        {
+            "snapshot-id": 3055729675574597004,
+            "parent-snapshot-id": 3051729675574597004,
+            "timestamp-ms": 1555100955770,
+            "sequence-number": 2,
+            "summary": {"operation": "append"},
+            "manifest-list": "s3://a/b/2.avro",
+            "first-row-id": 100,
+            "added-rows": 50
+        }
+    ]
+}`
+
+	meta, err := table.ParseMetadataBytes([]byte(metadataV3))
+	require.NoError(t, err)
+	require.IsType(t, (*table.MetadataV3)(nil), meta)
+	assert.Equal(t, 3, meta.Version())
+	assert.EqualValues(t, 150, meta.(*table.MetadataV3).NextRowID)
+
+	snap := meta.CurrentSnapshot()
+	require.NotNil(t, snap)
+	require.NotNil(t, snap.FirstRowID)
+	require.NotNil(t, snap.AddedRows)
+	assert.EqualValues(t, 100, *snap.FirstRowID)
+	assert.EqualValues(t, 50, *snap.AddedRows)
+	assert.EqualValues(t, 0, *meta.SnapshotByID(3051729675574597004).FirstRowID)
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	roundTrip, err := table.ParseMetadataBytes(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 150, roundTrip.(*table.MetadataV3).NextRowID)
+	assert.Equal(t, *snap, *roundTrip.CurrentSnapshot())
+}
+
+func TestHighestAssignedFieldID(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, meta.HighestAssignedFieldID())
+
+	// ids of fields which were dropped from the current schema are
+	// still considered, even if the last-column-id is lower
+	meta, err = table.ParseMetadataString(strings.Replace(ExampleTableMetadataV2,
+		`{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "required": true, "type": "long"}]}`,
+		`{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "required": true, "type": "long"},
+			{"id": 4, "name": "l", "required": false, "type": {"type": "list", "element-id": 7, "element": "int", "element-required": false}}]}`, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 3, meta.LastColumnID())
+	assert.Equal(t, 7, meta.HighestAssignedFieldID())
+}
+
 func TestParsingCorrectTypes(t *testing.T) {
 	var meta table.MetadataV2
 	require.NoError(t, json.Unmarshal([]byte(ExampleTableMetadataV2), &meta))
@@ -204,10 +287,170 @@ func TestSerializeMetadataV1(t *testing.T) {
 	data, err := json.Marshal(&meta)
 	require.NoError(t, err)
 
-	assert.JSONEq(t, `{"location": "s3://bucket/test/location", "table-uuid": "d20125c8-7284-442c-9aea-15fee620737c", "last-updated-ms": 1602638573874, "last-column-id": 3, "schemas": [{"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}, {"id": 2, "name": "y", "type": "long", "required": true, "doc": "comment"}, {"id": 3, "name": "z", "type": "long", "required": true}], "schema-id": 0, "identifier-field-ids": []}], "current-schema-id": 0, "partition-specs": [{"spec-id": 0, "fields": [{"source-id": 1, "field-id": 1000, "transform": "identity", "name": "x"}]}], "default-spec-id": 0, "last-partition-id": 1000, "properties": {}, "snapshots": [{"snapshot-id": 1925, "sequence-number": 0, "timestamp-ms": 1602638573822}], "snapshot-log": [], "metadata-log": [], "sort-orders": [{"order-id": 0, "fields": []}], "default-sort-order-id": 0, "refs": {}, "format-version": 1, "schema": {"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}, {"id": 2, "name": "y", "type": "long", "required": true, "doc": "comment"}, {"id": 3, "name": "z", "type": "long", "required": true}], "schema-id": 0, "identifier-field-ids": []}, "partition-spec": [{"name": "x", "transform": "identity", "source-id": 1, "field-id": 1000}]}`,
+	assert.JSONEq(t, `{"location": "s3://bucket/test/location", "table-uuid": "d20125c8-7284-442c-9aea-15fee620737c", "last-updated-ms": 1602638573874, "last-column-id": 3, "schemas": [{"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}, {"id": 2, "name": "y", "type": "long", "required": true, "doc": "comment"}, {"id": 3, "name": "z", "type": "long", "required": true}], "schema-id": 0}], "current-schema-id": 0, "partition-specs": [{"spec-id": 0, "fields": [{"source-id": 1, "field-id": 1000, "transform": "identity", "name": "x"}]}], "default-spec-id": 0, "last-partition-id": 1000, "properties": {}, "snapshots": [{"snapshot-id": 1925, "sequence-number": 0, "timestamp-ms": 1602638573822}], "snapshot-log": [], "metadata-log": [], "sort-orders": [{"order-id": 0, "fields": []}], "default-sort-order-id": 0, "refs": {}, "current-snapshot-id": -1, "format-version": 1, "schema": {"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}, {"id": 2, "name": "y", "type": "long", "required": true, "doc": "comment"}, {"id": 3, "name": "z", "type": "long", "required": true}], "schema-id": 0}, "partition-spec": [{"name": "x", "transform": "identity", "source-id": 1, "field-id": 1000}]}`,
 		string(data))
 }
 
+// expectedTableMetadataV2 is ExampleTableMetadataV2 as it's written by
+// this package, with the keys in the order used by the Java
+// TableMetadataParser. It's written by hand, not generated by Java.
+const expectedTableMetadataV2 = `{
+  "format-version" : 2,
+  "table-uuid" : "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+  "location" : "s3://bucket/test/location",
+  "last-sequence-number" : 34,
+  "last-updated-ms" : 1602638573590,
+  "last-column-id" : 3,
+  "current-schema-id" : 1,
+  "schemas" : [ {
+    "type" : "struct",
+    "schema-id" : 0,
+    "fields" : [ {
+      "id" : 1,
+      "name" : "x",
+      "required" : true,
+      "type" : "long"
+    } ]
+  }, {
+    "type" : "struct",
+    "schema-id" : 1,
+    "identifier-field-ids" : [ 1, 2 ],
+    "fields" : [ {
+      "id" : 1,
+      "name" : "x",
+      "required" : true,
+      "type" : "long"
+    }, {
+      "id" : 2,
+      "name" : "y",
+      "required" : true,
+      "type" : "long",
+      "doc" : "comment"
+    }, {
+      "id" : 3,
+      "name" : "z",
+      "required" : true,
+      "type" : "long"
+    } ]
+  } ],
+  "default-spec-id" : 0,
+  "partition-specs" : [ {
+    "spec-id" : 0,
+    "fields" : [ {
+      "name" : "x",
+      "transform" : "identity",
+      "source-id" : 1,
+      "field-id" : 1000
+    } ]
+  } ],
+  "last-partition-id" : 1000,
+  "default-sort-order-id" : 3,
+  "sort-orders" : [ {
+    "order-id" : 3,
+    "fields" : [ {
+      "transform" : "identity",
+      "source-id" : 2,
+      "direction" : "asc",
+      "null-order" : "nulls-first"
+    }, {
+      "transform" : "bucket[4]",
+      "source-id" : 3,
+      "direction" : "desc",
+      "null-order" : "nulls-last"
+    } ]
+  } ],
+  "properties" : {
+    "read.split.target.size" : "134217728"
+  },
+  "current-snapshot-id" : 3055729675574597004,
+  "refs" : {
+    "main" : {
+      "snapshot-id" : 3055729675574597004,
+      "type" : "branch"
+    },
+    "test" : {
+      "snapshot-id" : 3051729675574597004,
+      "type" : "tag",
+      "max-ref-age-ms" : 10000000
+    }
+  },
+  "snapshots" : [ {
+    "snapshot-id" : 3051729675574597004,
+    "sequence-number" : 0,
+    "timestamp-ms" : 1515100955770,
+    "summary" : {
+      "operation" : "append"
+    },
+    "manifest-list" : "s3://a/b/1.avro"
+  }, {
+    "snapshot-id" : 3055729675574597004,
+    "parent-snapshot-id" : 3051729675574597004,
+    "sequence-number" : 1,
+    "timestamp-ms" : 1555100955770,
+    "summary" : {
+      "operation" : "append"
+    },
+    "manifest-list" : "s3://a/b/2.avro",
+    "schema-id" : 1
+  } ],
+  "snapshot-log" : [ {
+    "timestamp-ms" : 1515100955770,
+    "snapshot-id" : 3051729675574597004
+  }, {
+    "timestamp-ms" : 1555100955770,
+    "snapshot-id" : 3055729675574597004
+  } ],
+  "metadata-log" : [ {
+    "timestamp-ms" : 1515100,
+    "metadata-file" : "s3://bucket/.../v1.json"
+  } ]
+}`
+
+func TestSerializeMetadataFieldOrder(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	var expected bytes.Buffer
+	require.NoError(t, json.Compact(&expected, []byte(expectedTableMetadataV2)))
+	assert.Equal(t, expected.String(), string(data))
+
+	// writing the metadata read back from the output gives the same bytes
+	roundTrip, err := table.ParseMetadataBytes(data)
+	require.NoError(t, err)
+	again, err := json.Marshal(roundTrip)
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(again))
+
+	// schemas, specs, sort orders and snapshots are written ordered by ID
+	// rather than in the order they're listed in
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(ExampleTableMetadataV2), &raw))
+	for _, key := range []string{"schemas", "snapshots"} {
+		var list []json.RawMessage
+		require.NoError(t, json.Unmarshal(raw[key], &list))
+		slices.Reverse(list)
+		raw[key], err = json.Marshal(list)
+		require.NoError(t, err)
+	}
+	reversed, err := json.Marshal(raw)
+	require.NoError(t, err)
+	meta, err = table.ParseMetadataBytes(reversed)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3055729675574597004, meta.Snapshots()[0].SnapshotID)
+	data, err = json.Marshal(meta)
+	require.NoError(t, err)
+	assert.Equal(t, expected.String(), string(data))
+
+	sum := table.Summary{Operation: table.OpAppend, Properties: map[string]string{
+		"total-records": "3", "added-data-files": "1", "added-records": "3"}}
+	data, err = json.Marshal(&sum)
+	require.NoError(t, err)
+	assert.Equal(t, `{"operation":"append","added-data-files":"1","added-records":"3","total-records":"3"}`, string(data))
+}
+
 func TestSerializeMetadataV2(t *testing.T) {
 	var meta table.MetadataV2
 	require.NoError(t, json.Unmarshal([]byte(ExampleTableMetadataV2), &meta))
@@ -215,7 +458,7 @@ func TestSerializeMetadataV2(t *testing.T) {
 	data, err := json.Marshal(&meta)
 	require.NoError(t, err)
 
-	assert.JSONEq(t, `{"location": "s3://bucket/test/location", "table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1", "last-updated-ms": 1602638573590, "last-column-id": 3, "schemas": [{"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}], "schema-id": 0, "identifier-field-ids": []}, {"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}, {"id": 2, "name": "y", "type": "long", "required": true, "doc": "comment"}, {"id": 3, "name": "z", "type": "long", "required": true}], "schema-id": 1, "identifier-field-ids": [1, 2]}], "current-schema-id": 1, "partition-specs": [{"spec-id": 0, "fields": [{"source-id": 1, "field-id": 1000, "transform": "identity", "name": "x"}]}], "default-spec-id": 0, "last-partition-id": 1000, "properties": {"read.split.target.size": "134217728"}, "current-snapshot-id": 3055729675574597004, "snapshots": [{"snapshot-id": 3051729675574597004, "sequence-number": 0, "timestamp-ms": 1515100955770, "manifest-list": "s3://a/b/1.avro", "summary": {"operation": "append"}}, {"snapshot-id": 3055729675574597004, "parent-snapshot-id": 3051729675574597004, "sequence-number": 1, "timestamp-ms": 1555100955770, "manifest-list": "s3://a/b/2.avro", "summary": {"operation": "append"}, "schema-id": 1}], "snapshot-log": [{"snapshot-id": 3051729675574597004, "timestamp-ms": 1515100955770}, {"snapshot-id": 3055729675574597004, "timestamp-ms": 1555100955770}], "metadata-log": [{"metadata-file": "s3://bucket/.../v1.json", "timestamp-ms": 1515100}], "sort-orders": [{"order-id": 3, "fields": [{"source-id": 2, "transform": "identity", "direction": "asc", "null-order": "nulls-first"}, {"source-id": 3, "transform": "bucket[4]", "direction": "desc", "null-order": "nulls-last"}]}], "default-sort-order-id": 3, "refs": {"test": {"snapshot-id": 3051729675574597004, "type": "tag", "max-ref-age-ms": 10000000}, "main": {"snapshot-id": 3055729675574597004, "type": "branch"}}, "format-version": 2, "last-sequence-number": 34}`,
+	assert.JSONEq(t, `{"location": "s3://bucket/test/location", "table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1", "last-updated-ms": 1602638573590, "last-column-id": 3, "schemas": [{"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}], "schema-id": 0}, {"type": "struct", "fields": [{"id": 1, "name": "x", "type": "long", "required": true}, {"id": 2, "name": "y", "type": "long", "required": true, "doc": "comment"}, {"id": 3, "name": "z", "type": "long", "required": true}], "schema-id": 1, "identifier-field-ids": [1, 2]}], "current-schema-id": 1, "partition-specs": [{"spec-id": 0, "fields": [{"source-id": 1, "field-id": 1000, "transform": "identity", "name": "x"}]}], "default-spec-id": 0, "last-partition-id": 1000, "properties": {"read.split.target.size": "134217728"}, "current-snapshot-id": 3055729675574597004, "snapshots": [{"snapshot-id": 3051729675574597004, "sequence-number": 0, "timestamp-ms": 1515100955770, "manifest-list": "s3://a/b/1.avro", "summary": {"operation": "append"}}, {"snapshot-id": 3055729675574597004, "parent-snapshot-id": 3051729675574597004, "sequence-number": 1, "timestamp-ms": 1555100955770, "manifest-list": "s3://a/b/2.avro", "summary": {"operation": "append"}, "schema-id": 1}], "snapshot-log": [{"snapshot-id": 3051729675574597004, "timestamp-ms": 1515100955770}, {"snapshot-id": 3055729675574597004, "timestamp-ms": 1555100955770}], "metadata-log": [{"metadata-file": "s3://bucket/.../v1.json", "timestamp-ms": 1515100}], "sort-orders": [{"order-id": 3, "fields": [{"source-id": 2, "transform": "identity", "direction": "asc", "null-order": "nulls-first"}, {"source-id": 3, "transform": "bucket[4]", "direction": "desc", "null-order": "nulls-last"}]}], "default-sort-order-id": 3, "refs": {"test": {"snapshot-id": 3051729675574597004, "type": "tag", "max-ref-age-ms": 10000000}, "main": {"snapshot-id": 3055729675574597004, "type": "branch"}}, "format-version": 2, "last-sequence-number": 34}`,
 		string(data))
 }
 
@@ -470,9 +713,8 @@ func TestV1WriteMetadataToV2(t *testing.T) {
 			map[string]any{"id": float64(2), "name": "y", "required": true, "type": "long", "doc": "comment"},
 			map[string]any{"id": float64(3), "name": "z", "required": true, "type": "long"},
 		},
-		"identifier-field-ids": []any{},
-		"schema-id":            float64(0),
-		"type":                 "struct",
+		"schema-id": float64(0),
+		"type":      "struct",
 	}}, rawData["schemas"])
 	assert.Equal(t, []any{map[string]any{
 		"spec-id": float64(0),
@@ -0,0 +1,137 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/iceberg-go"
+)
+
+const reportTypeScan = "scan-report"
+
+// CounterResult is a single counter metric of a report.
+type CounterResult struct {
+	Unit  string `json:"unit"`
+	Value int64  `json:"value"`
+}
+
+// TimerResult is a single timer metric of a report, with the total
+// duration of all of the timed events expressed in the given unit.
+type TimerResult struct {
+	TimeUnit      string `json:"time-unit"`
+	Count         int64  `json:"count"`
+	TotalDuration int64  `json:"total-duration"`
+}
+
+// ScanMetrics are the metrics collected while planning a table scan.
+type ScanMetrics struct {
+	TotalPlanningDuration  time.Duration
+	ResultDataFiles        int64
+	ResultDeleteFiles      int64
+	TotalDataManifests     int64
+	TotalDeleteManifests   int64
+	ScannedDataManifests   int64
+	SkippedDataManifests   int64
+	ScannedDeleteManifests int64
+	SkippedDeleteManifests int64
+	SkippedDataFiles       int64
+	SkippedDeleteFiles     int64
+	TotalFileSizeInBytes   int64
+}
+
+// MarshalJSON writes the metrics in the form expected by the REST catalog,
+// a map from the metric name to either a counter or a timer result.
+func (m ScanMetrics) MarshalJSON() ([]byte, error) {
+	count := func(v int64) CounterResult { return CounterResult{Unit: "count", Value: v} }
+
+	return json.Marshal(map[string]any{
+		"total-planning-duration": TimerResult{TimeUnit: "nanoseconds", Count: 1,
+			TotalDuration: m.TotalPlanningDuration.Nanoseconds()},
+		"result-data-files":        count(m.ResultDataFiles),
+		"result-delete-files":      count(m.ResultDeleteFiles),
+		"total-data-manifests":     count(m.TotalDataManifests),
+		"total-delete-manifests":   count(m.TotalDeleteManifests),
+		"scanned-data-manifests":   count(m.ScannedDataManifests),
+		"skipped-data-manifests":   count(m.SkippedDataManifests),
+		"scanned-delete-manifests": count(m.ScannedDeleteManifests),
+		"skipped-delete-manifests": count(m.SkippedDeleteManifests),
+		"skipped-data-files":       count(m.SkippedDataFiles),
+		"skipped-delete-files":     count(m.SkippedDeleteFiles),
+		"total-file-size-in-bytes": CounterResult{Unit: "bytes", Value: m.TotalFileSizeInBytes},
+	})
+}
+
+// ScanReport describes a single table scan, it is produced by scan
+// planning and can be sent to a catalog which collects metrics.
+type ScanReport struct {
+	TableName           string
+	SnapshotID          int64
+	Filter              iceberg.BooleanExpression
+	SchemaID            int
+	ProjectedFieldIDs   []int
+	ProjectedFieldNames []string
+	Metrics             ScanMetrics
+	Metadata            map[string]string
+}
+
+// MarshalJSON writes the report as a REST catalog ReportMetricsRequest,
+// with the filter written as a REST Expression object. A nil filter is
+// written as true.
+func (r ScanReport) MarshalJSON() ([]byte, error) {
+	filter := iceberg.BooleanExpression(iceberg.AlwaysTrue{})
+	if r.Filter != nil {
+		filter = r.Filter
+	}
+
+	filterJSON, err := iceberg.MarshalExpressionJSON(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldIDs, fieldNames := r.ProjectedFieldIDs, r.ProjectedFieldNames
+	if fieldIDs == nil {
+		fieldIDs = []int{}
+	}
+	if fieldNames == nil {
+		fieldNames = []string{}
+	}
+
+	return json.Marshal(struct {
+		ReportType          string            `json:"report-type"`
+		TableName           string            `json:"table-name"`
+		SnapshotID          int64             `json:"snapshot-id"`
+		Filter              json.RawMessage   `json:"filter"`
+		SchemaID            int               `json:"schema-id"`
+		ProjectedFieldIDs   []int             `json:"projected-field-ids"`
+		ProjectedFieldNames []string          `json:"projected-field-names"`
+		Metrics             ScanMetrics       `json:"metrics"`
+		Metadata            map[string]string `json:"metadata,omitempty"`
+	}{
+		ReportType:          reportTypeScan,
+		TableName:           r.TableName,
+		SnapshotID:          r.SnapshotID,
+		Filter:              filterJSON,
+		SchemaID:            r.SchemaID,
+		ProjectedFieldIDs:   fieldIDs,
+		ProjectedFieldNames: fieldNames,
+		Metrics:             r.Metrics,
+		Metadata:            r.Metadata,
+	})
+}
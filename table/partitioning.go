@@ -0,0 +1,202 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v16/arrow"
+	"github.com/apache/arrow/go/v16/arrow/array"
+	"github.com/apache/arrow/go/v16/arrow/compute"
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+)
+
+// PartitionedRecord holds the rows of a record which belong to a single
+// partition, so that they can be written to their own data file.
+type PartitionedRecord struct {
+	// Partition is the partition tuple of the rows, keyed by partition
+	// field name like DataFile.Partition.
+	Partition map[string]any
+	Record    arrow.Record
+}
+
+// PartitionRecord computes the partition tuple of each row of rec by
+// applying the transforms of the spec to the source columns, and splits
+// the record into one record per distinct partition tuple, in the order
+// in which each partition first appears. Null source values produce null
+// partition values. Source columns are matched by the field ids stored in
+// the arrow field metadata, and must be top-level columns.
+//
+// For an unpartitioned spec the record is returned as is with an empty
+// partition tuple. The returned records must be released by the caller.
+func PartitionRecord(ctx context.Context, spec iceberg.PartitionSpec, schema *iceberg.Schema, rec arrow.Record) ([]PartitionedRecord, error) {
+	if spec.IsUnpartitioned() {
+		rec.Retain()
+		return []PartitionedRecord{{Partition: map[string]any{}, Record: rec}}, nil
+	}
+
+	colsByID := make(map[int]int, rec.NumCols())
+	for i, f := range rec.Schema().Fields() {
+		if id, err := arrowFieldID(f); err == nil {
+			colsByID[id] = i
+		}
+	}
+
+	type source struct {
+		field iceberg.PartitionField
+		typ   iceberg.Type
+		col   arrow.Array
+	}
+
+	sources := make([]source, spec.NumFields())
+	for i := range sources {
+		field := spec.Field(i)
+		typ, ok := schema.FindTypeByID(field.SourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot find source column %d for partition field '%s'",
+				iceberg.ErrInvalidSchema, field.SourceID, field.Name)
+		}
+
+		idx, ok := colsByID[field.SourceID]
+		if !ok {
+			return nil, fmt.Errorf("%w: record has no top-level column for source id %d of partition field '%s'",
+				iceberg.ErrInvalidSchema, field.SourceID, field.Name)
+		}
+		sources[i] = source{field: field, typ: typ, col: rec.Column(idx)}
+	}
+
+	var (
+		parts  []PartitionedRecord
+		rows   [][]int64
+		groups = make(map[string]int)
+		key    strings.Builder
+	)
+
+	for row := 0; row < int(rec.NumRows()); row++ {
+		key.Reset()
+		values := make(map[string]any, len(sources))
+		for _, src := range sources {
+			val, err := arrowValue(src.col, row, src.typ)
+			if err != nil {
+				return nil, err
+			}
+
+			if val, err = src.field.Transform.Apply(val); err != nil {
+				return nil, fmt.Errorf("partition field '%s': %w", src.field.Name, err)
+			}
+			values[src.field.Name] = val
+			fmt.Fprintf(&key, "%T:%#v;", val, val)
+		}
+
+		g, ok := groups[key.String()]
+		if !ok {
+			g = len(parts)
+			groups[key.String()] = g
+			parts = append(parts, PartitionedRecord{Partition: values})
+			rows = append(rows, nil)
+		}
+		rows[g] = append(rows[g], int64(row))
+	}
+
+	for i := range parts {
+		r, err := takeRows(ctx, rec, rows[i])
+		if err != nil {
+			for _, p := range parts[:i] {
+				p.Record.Release()
+			}
+			return nil, err
+		}
+		parts[i].Record = r
+	}
+	return parts, nil
+}
+
+// takeRows returns a record with the rows of rec at the given indices.
+func takeRows(ctx context.Context, rec arrow.Record, rows []int64) (arrow.Record, error) {
+	mem := compute.GetAllocator(ctx)
+	bldr := array.NewInt64Builder(mem)
+	defer bldr.Release()
+	bldr.AppendValues(rows, nil)
+	indices := bldr.NewArray()
+	defer indices.Release()
+
+	cols := make([]arrow.Array, 0, rec.NumCols())
+	defer func() { releaseAll(cols) }()
+	for _, col := range rec.Columns() {
+		taken, err := compute.TakeArray(ctx, col, indices)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, taken)
+	}
+	return array.NewRecord(rec.Schema(), cols, int64(len(rows))), nil
+}
+
+// arrowValue returns the value at index i of arr, which holds values of
+// the iceberg type typ, as the Go type used for partition values.
+func arrowValue(arr arrow.Array, i int, typ iceberg.Type) (any, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i), nil
+	case *array.Int32:
+		return a.Value(i), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Float32:
+		return a.Value(i), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.Date32:
+		return iceberg.Date(a.Value(i)), nil
+	case *array.Time64:
+		return iceberg.Time(a.Value(i)), nil
+	case *array.Timestamp:
+		if a.DataType().(*arrow.TimestampType).Unit == arrow.Nanosecond {
+			return iceberg.TimestampNano(a.Value(i)), nil
+		}
+		return iceberg.Timestamp(a.Value(i)), nil
+	case *array.String:
+		// values share the record's memory, so they're copied to outlive it
+		return strings.Clone(a.Value(i)), nil
+	case *array.LargeString:
+		return strings.Clone(a.Value(i)), nil
+	case *array.Binary:
+		return slices.Clone(a.Value(i)), nil
+	case *array.LargeBinary:
+		return slices.Clone(a.Value(i)), nil
+	case *array.FixedSizeBinary:
+		if _, ok := typ.(iceberg.UUIDType); ok {
+			return uuid.FromBytes(a.Value(i))
+		}
+		return slices.Clone(a.Value(i)), nil
+	case *array.Decimal128:
+		return iceberg.Decimal{Val: a.Value(i),
+			Scale: int(a.DataType().(*arrow.Decimal128Type).Scale)}, nil
+	}
+
+	return nil, fmt.Errorf("%w: reading partition source values of arrow type %s",
+		iceberg.ErrNotImplemented, arr.DataType())
+}
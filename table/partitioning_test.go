@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v16/arrow/array"
+	"github.com/apache/arrow/go/v16/arrow/compute"
+	"github.com/apache/arrow/go/v16/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionRecord(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "region", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+	)
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "region", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1001, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 2}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "ts_day", Transform: iceberg.DayTransform{}},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true)
+	require.NoError(t, err)
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rec, _, err := array.RecordFromJSON(mem, arrSchema, strings.NewReader(`[
+		{"id": 1, "region": "eu", "ts": "2017-11-16T22:31:08"},
+		{"id": 2, "region": "us", "ts": "2017-11-16T10:00:00"},
+		{"id": 3, "region": null, "ts": "2017-11-17T01:00:00"},
+		{"id": 4, "region": "eu", "ts": "2017-11-16T01:00:00"},
+		{"id": 5, "region": "us", "ts": null},
+		{"id": 6, "region": null, "ts": "2017-11-17T23:59:59"},
+		{"id": 7, "region": "eu", "ts": "2017-11-16T00:00:00"}
+	]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	ctx := compute.WithAllocator(context.Background(), mem)
+	parts, err := table.PartitionRecord(ctx, spec, sc, rec)
+	require.NoError(t, err)
+	defer func() {
+		for _, p := range parts {
+			p.Record.Release()
+		}
+	}()
+
+	seen := map[string]bool{}
+	total := 0
+	for _, p := range parts {
+		assert.NoError(t, spec.ValidatePartitionData(sc, p.Partition))
+		total += int(p.Record.NumRows())
+
+		ids := p.Record.Column(0).(*array.Int64)
+		regions := p.Record.Column(1).(*array.String)
+		timestamps := p.Record.Column(2).(*array.Timestamp)
+		for i := 0; i < ids.Len(); i++ {
+			var region any
+			if regions.IsValid(i) {
+				region = regions.Value(i)
+			}
+			assert.Equal(t, p.Partition["region"], region)
+
+			bucket, err := iceberg.BucketTransform{NumBuckets: 2}.Apply(ids.Value(i))
+			require.NoError(t, err)
+			assert.Equal(t, p.Partition["id_bucket"], bucket)
+
+			var day any
+			if timestamps.IsValid(i) {
+				day, err = iceberg.DayTransform{}.Apply(iceberg.Timestamp(timestamps.Value(i)))
+				require.NoError(t, err)
+			}
+			assert.Equal(t, p.Partition["ts_day"], day)
+		}
+
+		key := fmt.Sprintf("%v/%v/%v", p.Partition["region"], p.Partition["id_bucket"], p.Partition["ts_day"])
+		assert.False(t, seen[key], "duplicate partition %s", key)
+		seen[key] = true
+	}
+	assert.Equal(t, int(rec.NumRows()), total)
+	assert.Less(t, len(parts), int(rec.NumRows()))
+
+	// the first partition holds the first row and those that share its tuple
+	assert.Equal(t, "eu", parts[0].Partition["region"])
+	assert.Equal(t, iceberg.Date(17486), parts[0].Partition["ts_day"])
+
+	t.Run("unpartitioned", func(t *testing.T) {
+		parts, err := table.PartitionRecord(ctx, *iceberg.UnpartitionedSpec, sc, rec)
+		require.NoError(t, err)
+		require.Len(t, parts, 1)
+		defer parts[0].Record.Release()
+		assert.Empty(t, parts[0].Partition)
+		assert.EqualValues(t, rec.NumRows(), parts[0].Record.NumRows())
+	})
+
+	t.Run("missing source column", func(t *testing.T) {
+		_, err := table.PartitionRecord(ctx, iceberg.NewPartitionSpec(
+			iceberg.PartitionField{SourceID: 4, FieldID: 1000, Name: "missing", Transform: iceberg.IdentityTransform{}},
+		), sc, rec)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+}
@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// PropertiesUpdateSummary describes the result of a properties update,
+// with the keys which were set, removed, and those which were to be
+// removed but didn't exist.
+type PropertiesUpdateSummary struct {
+	Removed []string `json:"removed"`
+	Updated []string `json:"updated"`
+	Missing []string `json:"missing"`
+}
+
+// UpdateProperties collects changes to the properties of a table, which
+// are committed together by Commit. If a key is both set and removed, the
+// last change to it wins.
+type UpdateProperties struct {
+	tbl      *Table
+	updates  iceberg.Properties
+	removals map[string]struct{}
+	err      error
+}
+
+// UpdateProperties begins a change to the properties of the table.
+func (t Table) UpdateProperties() *UpdateProperties {
+	return &UpdateProperties{
+		tbl:      &t,
+		updates:  iceberg.Properties{},
+		removals: map[string]struct{}{},
+	}
+}
+
+func (u *UpdateProperties) checkReserved(key string) bool {
+	if u.err == nil && slices.Contains(ReservedProperties, key) {
+		u.err = fmt.Errorf("%w: table property %s is reserved and can't be changed directly",
+			iceberg.ErrInvalidArgument, key)
+	}
+	return u.err == nil
+}
+
+// Set adds or replaces the value of the property.
+func (u *UpdateProperties) Set(key, value string) *UpdateProperties {
+	if u.checkReserved(key) {
+		u.updates[key] = value
+		delete(u.removals, key)
+	}
+	return u
+}
+
+// Remove removes the property from the table.
+func (u *UpdateProperties) Remove(key string) *UpdateProperties {
+	if u.checkReserved(key) {
+		u.removals[key] = struct{}{}
+		delete(u.updates, key)
+	}
+	return u
+}
+
+// Commit commits the changes with set-properties and remove-properties
+// updates through the catalog the table was loaded from, and returns the
+// updated table along with a summary of the changes. If a reserved
+// property was set or removed, nothing is committed and an error wrapping
+// iceberg.ErrInvalidArgument is returned.
+func (u *UpdateProperties) Commit(ctx context.Context) (*Table, PropertiesUpdateSummary, error) {
+	if u.err != nil {
+		return nil, PropertiesUpdateSummary{}, u.err
+	}
+
+	summary := PropertiesUpdateSummary{Updated: maps.Keys(u.updates)}
+	removals := maps.Keys(u.removals)
+	slices.Sort(summary.Updated)
+	slices.Sort(removals)
+
+	current := u.tbl.Properties()
+	for _, k := range removals {
+		if _, ok := current[k]; ok {
+			summary.Removed = append(summary.Removed, k)
+		} else {
+			summary.Missing = append(summary.Missing, k)
+		}
+	}
+
+	txn, err := u.tbl.NewTransaction()
+	if err != nil {
+		return nil, PropertiesUpdateSummary{}, err
+	}
+	if err := txn.SetProperties(u.updates); err != nil {
+		return nil, PropertiesUpdateSummary{}, err
+	}
+	if err := txn.RemoveProperties(removals...); err != nil {
+		return nil, PropertiesUpdateSummary{}, err
+	}
+
+	tbl, err := txn.Commit(ctx)
+	if err != nil {
+		return nil, PropertiesUpdateSummary{}, err
+	}
+	return tbl, summary, nil
+}
@@ -0,0 +1,399 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReqAssertCreate                  = "assert-create"
+	ReqAssertTableUUID               = "assert-table-uuid"
+	ReqAssertRefSnapshotID           = "assert-ref-snapshot-id"
+	ReqAssertLastAssignedFieldID     = "assert-last-assigned-field-id"
+	ReqAssertCurrentSchemaID         = "assert-current-schema-id"
+	ReqAssertLastAssignedPartitionID = "assert-last-assigned-partition-id"
+	ReqAssertDefaultSpecID           = "assert-default-spec-id"
+	ReqAssertDefaultSortOrderID      = "assert-default-sort-order-id"
+)
+
+var (
+	ErrRequirementFailed  = errors.New("requirement failed")
+	ErrInvalidRequirement = errors.New("invalid requirement")
+)
+
+// RequirementError is returned when a requirement doesn't hold for the
+// current metadata of a table. It wraps ErrRequirementFailed and reports
+// which requirement failed along with the expected and actual values.
+type RequirementError struct {
+	// Requirement is the type of the failed requirement, such as
+	// "assert-current-schema-id".
+	Requirement string
+	// Expected and Actual are the values which were compared, Actual
+	// is nil if the value didn't exist in the current metadata.
+	Expected, Actual any
+	// Detail optionally describes what was being compared, such as the
+	// name of a ref.
+	Detail string
+}
+
+func (e *RequirementError) Error() string {
+	detail := ""
+	if e.Detail != "" {
+		detail = " " + e.Detail
+	}
+	return fmt.Sprintf("%s: %s%s: expected %v, found %v",
+		ErrRequirementFailed, e.Requirement, detail, e.Expected, e.Actual)
+}
+
+func (e *RequirementError) Unwrap() error { return ErrRequirementFailed }
+
+// Retryable returns true if the failure was caused by a concurrent change
+// to the table, in which case the changes can be retried against the
+// refreshed metadata. Failures of assert-create and assert-table-uuid mean
+// that the table was created or replaced, and aren't retryable.
+func (e *RequirementError) Retryable() bool {
+	switch e.Requirement {
+	case ReqAssertCreate, ReqAssertTableUUID:
+		return false
+	}
+	return true
+}
+
+// Requirement is a condition that must hold for the current metadata of a
+// table in order for a set of updates to be committed. Catalogs check the
+// requirements against the table's latest metadata before applying updates
+// so that concurrent changes are detected.
+type Requirement interface {
+	// Type returns the name of the requirement.
+	Type() string
+	// Validate checks the requirement against the given metadata, returning
+	// a *RequirementError if it doesn't hold. The metadata is nil if the
+	// table doesn't exist.
+	Validate(Metadata) error
+}
+
+// ValidateRequirements checks each of the requirements against the given
+// metadata, which should be freshly loaded from the catalog, returning the
+// first failure. The metadata is nil if the table doesn't exist.
+func ValidateRequirements(meta Metadata, reqs []Requirement) error {
+	for _, r := range reqs {
+		if err := r.Validate(meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Requirements is a list of requirements which can be marshaled to and
+// unmarshaled from JSON, such as the requirements in the request body of
+// a REST catalog commit. Each requirement is tagged by its type.
+type Requirements []Requirement
+
+func (r *Requirements) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	out := make(Requirements, len(raw))
+	for i, data := range raw {
+		req, err := unmarshalRequirement(data)
+		if err != nil {
+			return err
+		}
+		out[i] = req
+	}
+
+	*r = out
+	return nil
+}
+
+func unmarshalRequirement(b []byte) (Requirement, error) {
+	var base baseRequirement
+	if err := json.Unmarshal(b, &base); err != nil {
+		return nil, err
+	}
+
+	var req Requirement
+	switch base.TypeName {
+	case ReqAssertCreate:
+		req = &assertCreate{}
+	case ReqAssertTableUUID:
+		req = &assertTableUUID{}
+	case ReqAssertRefSnapshotID:
+		req = &assertRefSnapshotID{}
+	case ReqAssertLastAssignedFieldID:
+		req = &assertLastAssignedFieldID{}
+	case ReqAssertCurrentSchemaID:
+		req = &assertCurrentSchemaID{}
+	case ReqAssertLastAssignedPartitionID:
+		req = &assertLastAssignedPartitionID{}
+	case ReqAssertDefaultSpecID:
+		req = &assertDefaultSpecID{}
+	case ReqAssertDefaultSortOrderID:
+		req = &assertDefaultSortOrderID{}
+	case "":
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidRequirement)
+	default:
+		return nil, fmt.Errorf("%w: unknown type '%s'", ErrInvalidRequirement, base.TypeName)
+	}
+
+	if err := json.Unmarshal(b, req); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidRequirement, base.TypeName, err)
+	}
+	return req, nil
+}
+
+type baseRequirement struct {
+	TypeName string `json:"type"`
+}
+
+func (r *baseRequirement) Type() string { return r.TypeName }
+
+// missingMetadata returns the error for a requirement checked against a
+// table which doesn't exist.
+func (r *baseRequirement) missingMetadata(expected any) error {
+	return &RequirementError{Requirement: r.TypeName, Expected: expected,
+		Detail: "(table does not exist)"}
+}
+
+type assertCreate struct {
+	baseRequirement
+}
+
+// AssertCreate creates a requirement that the table does not already exist.
+func AssertCreate() Requirement {
+	return &assertCreate{baseRequirement: baseRequirement{TypeName: ReqAssertCreate}}
+}
+
+func (a *assertCreate) Validate(meta Metadata) error {
+	if meta != nil {
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: "no table", Actual: meta.TableUUID(),
+			Detail: "(table already exists)"}
+	}
+	return nil
+}
+
+type assertTableUUID struct {
+	baseRequirement
+	UUID uuid.UUID `json:"uuid"`
+}
+
+// AssertTableUUID creates a requirement that the table UUID matches the
+// given UUID, ensuring that the table wasn't dropped and recreated.
+func AssertTableUUID(uuid uuid.UUID) Requirement {
+	return &assertTableUUID{
+		baseRequirement: baseRequirement{TypeName: ReqAssertTableUUID},
+		UUID:            uuid,
+	}
+}
+
+func (a *assertTableUUID) Validate(meta Metadata) error {
+	if meta == nil {
+		return a.missingMetadata(a.UUID)
+	}
+
+	if meta.TableUUID() != a.UUID {
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: a.UUID, Actual: meta.TableUUID()}
+	}
+	return nil
+}
+
+type assertRefSnapshotID struct {
+	baseRequirement
+	Ref        string `json:"ref"`
+	SnapshotID *int64 `json:"snapshot-id"`
+}
+
+// AssertRefSnapshotID creates a requirement that the named ref points at
+// the given snapshot. If the snapshot ID is nil, the ref must not exist.
+func AssertRefSnapshotID(ref string, id *int64) Requirement {
+	return &assertRefSnapshotID{
+		baseRequirement: baseRequirement{TypeName: ReqAssertRefSnapshotID},
+		Ref:             ref,
+		SnapshotID:      id,
+	}
+}
+
+func (a *assertRefSnapshotID) Validate(meta Metadata) error {
+	var expected any = "no ref"
+	if a.SnapshotID != nil {
+		expected = *a.SnapshotID
+	}
+
+	if meta == nil {
+		return a.missingMetadata(expected)
+	}
+
+	ref, ok := meta.SnapshotRefs()[a.Ref]
+	switch {
+	case !ok && a.SnapshotID == nil:
+		return nil
+	case ok && a.SnapshotID != nil && ref.SnapshotID == *a.SnapshotID:
+		return nil
+	}
+
+	var actual any
+	if ok {
+		actual = ref.SnapshotID
+	}
+	return &RequirementError{Requirement: a.TypeName,
+		Expected: expected, Actual: actual, Detail: "for ref " + a.Ref}
+}
+
+type assertLastAssignedFieldID struct {
+	baseRequirement
+	LastAssignedFieldID int `json:"last-assigned-field-id"`
+}
+
+// AssertLastAssignedFieldID creates a requirement that the table's
+// last-column-id matches the given ID.
+func AssertLastAssignedFieldID(id int) Requirement {
+	return &assertLastAssignedFieldID{
+		baseRequirement:     baseRequirement{TypeName: ReqAssertLastAssignedFieldID},
+		LastAssignedFieldID: id,
+	}
+}
+
+func (a *assertLastAssignedFieldID) Validate(meta Metadata) error {
+	if meta == nil {
+		return a.missingMetadata(a.LastAssignedFieldID)
+	}
+
+	if meta.LastColumnID() != a.LastAssignedFieldID {
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: a.LastAssignedFieldID, Actual: meta.LastColumnID()}
+	}
+	return nil
+}
+
+type assertCurrentSchemaID struct {
+	baseRequirement
+	CurrentSchemaID int `json:"current-schema-id"`
+}
+
+// AssertCurrentSchemaID creates a requirement that the table's current
+// schema ID matches the given ID.
+func AssertCurrentSchemaID(id int) Requirement {
+	return &assertCurrentSchemaID{
+		baseRequirement: baseRequirement{TypeName: ReqAssertCurrentSchemaID},
+		CurrentSchemaID: id,
+	}
+}
+
+func (a *assertCurrentSchemaID) Validate(meta Metadata) error {
+	if meta == nil {
+		return a.missingMetadata(a.CurrentSchemaID)
+	}
+
+	if current := meta.CurrentSchema().ID; current != a.CurrentSchemaID {
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: a.CurrentSchemaID, Actual: current}
+	}
+	return nil
+}
+
+type assertLastAssignedPartitionID struct {
+	baseRequirement
+	LastAssignedPartitionID int `json:"last-assigned-partition-id"`
+}
+
+// AssertLastAssignedPartitionID creates a requirement that the table's
+// last-partition-id matches the given ID.
+func AssertLastAssignedPartitionID(id int) Requirement {
+	return &assertLastAssignedPartitionID{
+		baseRequirement:         baseRequirement{TypeName: ReqAssertLastAssignedPartitionID},
+		LastAssignedPartitionID: id,
+	}
+}
+
+func (a *assertLastAssignedPartitionID) Validate(meta Metadata) error {
+	if meta == nil {
+		return a.missingMetadata(a.LastAssignedPartitionID)
+	}
+
+	last := meta.LastPartitionSpecID()
+	if last == nil || *last != a.LastAssignedPartitionID {
+		var actual any
+		if last != nil {
+			actual = *last
+		}
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: a.LastAssignedPartitionID, Actual: actual}
+	}
+	return nil
+}
+
+type assertDefaultSpecID struct {
+	baseRequirement
+	DefaultSpecID int `json:"default-spec-id"`
+}
+
+// AssertDefaultSpecID creates a requirement that the table's default
+// partition spec ID matches the given ID.
+func AssertDefaultSpecID(id int) Requirement {
+	return &assertDefaultSpecID{
+		baseRequirement: baseRequirement{TypeName: ReqAssertDefaultSpecID},
+		DefaultSpecID:   id,
+	}
+}
+
+func (a *assertDefaultSpecID) Validate(meta Metadata) error {
+	if meta == nil {
+		return a.missingMetadata(a.DefaultSpecID)
+	}
+
+	if meta.DefaultPartitionSpec() != a.DefaultSpecID {
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: a.DefaultSpecID, Actual: meta.DefaultPartitionSpec()}
+	}
+	return nil
+}
+
+type assertDefaultSortOrderID struct {
+	baseRequirement
+	DefaultSortOrderID int `json:"default-sort-order-id"`
+}
+
+// AssertDefaultSortOrderID creates a requirement that the table's default
+// sort order ID matches the given ID.
+func AssertDefaultSortOrderID(id int) Requirement {
+	return &assertDefaultSortOrderID{
+		baseRequirement:    baseRequirement{TypeName: ReqAssertDefaultSortOrderID},
+		DefaultSortOrderID: id,
+	}
+}
+
+func (a *assertDefaultSortOrderID) Validate(meta Metadata) error {
+	if meta == nil {
+		return a.missingMetadata(a.DefaultSortOrderID)
+	}
+
+	if current := meta.SortOrder().OrderID; current != a.DefaultSortOrderID {
+		return &RequirementError{Requirement: a.TypeName,
+			Expected: a.DefaultSortOrderID, Actual: current}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+)
+
+var ErrInvalidRowLevelMode = errors.New("invalid row-level operation mode, must be 'copy-on-write' or 'merge-on-read'")
+
+// RowLevelMode is the strategy used by operations which change existing
+// rows of a table, such as deletes, updates and merges.
+type RowLevelMode string
+
+const (
+	// CopyOnWrite rewrites the data files containing changed rows,
+	// without writing delete files.
+	CopyOnWrite RowLevelMode = "copy-on-write"
+	// MergeOnRead writes delete files for the changed rows, which are
+	// applied to the data files when reading.
+	MergeOnRead RowLevelMode = "merge-on-read"
+)
+
+const (
+	// PropertyDeleteMode is the mode of delete operations.
+	PropertyDeleteMode = "write.delete.mode"
+	// PropertyUpdateMode is the mode of update operations.
+	PropertyUpdateMode = "write.update.mode"
+	// PropertyMergeMode is the mode of merge operations.
+	PropertyMergeMode = "write.merge.mode"
+	// PropertyRowLevelModeDefault is the mode of each operation when its
+	// property isn't set.
+	PropertyRowLevelModeDefault = CopyOnWrite
+)
+
+// ParseRowLevelMode parses the value of a row-level operation mode
+// property, ignoring case.
+func ParseRowLevelMode(s string) (RowLevelMode, error) {
+	switch mode := RowLevelMode(strings.ToLower(s)); mode {
+	case CopyOnWrite, MergeOnRead:
+		return mode, nil
+	}
+	return "", fmt.Errorf("%w: got '%s'", ErrInvalidRowLevelMode, s)
+}
+
+// RowLevelModeFromProperties returns the mode configured by the property
+// with the given key, such as PropertyDeleteMode, or the default mode if
+// it isn't set.
+func RowLevelModeFromProperties(props iceberg.Properties, key string) (RowLevelMode, error) {
+	v, ok := props[key]
+	if !ok {
+		return PropertyRowLevelModeDefault, nil
+	}
+
+	mode, err := ParseRowLevelMode(v)
+	if err != nil {
+		return "", fmt.Errorf("%w for property %s", err, key)
+	}
+	return mode, nil
+}
+
+// DeleteMode returns the mode of delete operations on the table.
+func (t Table) DeleteMode() (RowLevelMode, error) {
+	return RowLevelModeFromProperties(t.Properties(), PropertyDeleteMode)
+}
+
+// UpdateMode returns the mode of update operations on the table.
+func (t Table) UpdateMode() (RowLevelMode, error) {
+	return RowLevelModeFromProperties(t.Properties(), PropertyUpdateMode)
+}
+
+// MergeMode returns the mode of merge operations on the table.
+func (t Table) MergeMode() (RowLevelMode, error) {
+	return RowLevelModeFromProperties(t.Properties(), PropertyMergeMode)
+}
@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowLevelModeFromProperties(t *testing.T) {
+	props := iceberg.Properties{
+		table.PropertyDeleteMode: "merge-on-read",
+		table.PropertyUpdateMode: "Copy-On-Write",
+		table.PropertyMergeMode:  "merge-on-write",
+	}
+
+	mode, err := table.RowLevelModeFromProperties(props, table.PropertyDeleteMode)
+	require.NoError(t, err)
+	assert.Equal(t, table.MergeOnRead, mode)
+
+	mode, err = table.RowLevelModeFromProperties(props, table.PropertyUpdateMode)
+	require.NoError(t, err)
+	assert.Equal(t, table.CopyOnWrite, mode)
+
+	_, err = table.RowLevelModeFromProperties(props, table.PropertyMergeMode)
+	assert.ErrorIs(t, err, table.ErrInvalidRowLevelMode)
+	assert.ErrorContains(t, err, "write.merge.mode")
+
+	mode, err = table.RowLevelModeFromProperties(nil, table.PropertyDeleteMode)
+	require.NoError(t, err)
+	assert.Equal(t, table.CopyOnWrite, mode)
+}
+
+func TestTableRowLevelModes(t *testing.T) {
+	meta, err := table.ParseMetadataBytes([]byte(ExampleTableMetadataV2))
+	require.NoError(t, err)
+
+	tbl := table.New([]string{"t"}, meta, "", nil)
+	for _, fn := range []func() (table.RowLevelMode, error){tbl.DeleteMode, tbl.UpdateMode, tbl.MergeMode} {
+		mode, err := fn()
+		require.NoError(t, err)
+		assert.Equal(t, table.PropertyRowLevelModeDefault, mode)
+	}
+}
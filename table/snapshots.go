@@ -18,6 +18,8 @@
 package table
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +28,7 @@ import (
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/io"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 type Operation string
@@ -111,16 +114,36 @@ func (s *Summary) UnmarshalJSON(b []byte) (err error) {
 	return nil
 }
 
+// MarshalJSON writes the operation first followed by the properties
+// sorted by key, so that the output is stable.
 func (s *Summary) MarshalJSON() ([]byte, error) {
 	props := maps.Clone(s.Properties)
+	delete(props, operationKey)
+
+	keys := maps.Keys(props)
+	slices.Sort(keys)
 	if s.Operation != "" {
+		keys = append([]string{operationKey}, keys...)
 		if props == nil {
 			props = make(map[string]string)
 		}
 		props[operationKey] = string(s.Operation)
 	}
 
-	return json.Marshal(props)
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		val, _ := json.Marshal(props[k])
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
 
 type Snapshot struct {
@@ -131,6 +154,27 @@ type Snapshot struct {
 	ManifestList     string   `json:"manifest-list,omitempty"`
 	Summary          *Summary `json:"summary,omitempty"`
 	SchemaID         *int     `json:"schema-id,omitempty"`
+	// FirstRowID and AddedRows track row lineage in v3 tables, the rows
+	// added by the snapshot are assigned IDs starting at FirstRowID.
+	FirstRowID *int64 `json:"first-row-id,omitempty"`
+	AddedRows  *int64 `json:"added-rows,omitempty"`
+}
+
+// MarshalJSON writes the fields in the same order as the Java
+// implementation, which writes the summary before the manifest list.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SnapshotID       int64    `json:"snapshot-id"`
+		ParentSnapshotID *int64   `json:"parent-snapshot-id,omitempty"`
+		SequenceNumber   int64    `json:"sequence-number"`
+		TimestampMs      int64    `json:"timestamp-ms"`
+		Summary          *Summary `json:"summary,omitempty"`
+		ManifestList     string   `json:"manifest-list,omitempty"`
+		SchemaID         *int     `json:"schema-id,omitempty"`
+		FirstRowID       *int64   `json:"first-row-id,omitempty"`
+		AddedRows        *int64   `json:"added-rows,omitempty"`
+	}{s.SnapshotID, s.ParentSnapshotID, s.SequenceNumber, s.TimestampMs,
+		s.Summary, s.ManifestList, s.SchemaID, s.FirstRowID, s.AddedRows})
 }
 
 func (s Snapshot) String() string {
@@ -169,9 +213,18 @@ func (s Snapshot) Equals(other Snapshot) bool {
 		s.SequenceNumber == other.SequenceNumber &&
 		s.TimestampMs == other.TimestampMs &&
 		s.ManifestList == other.ManifestList &&
+		equalInt64Ptr(s.FirstRowID, other.FirstRowID) &&
+		equalInt64Ptr(s.AddedRows, other.AddedRows) &&
 		s.Summary.Equals(other.Summary)
 }
 
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (s Snapshot) Manifests(fio io.IO) ([]iceberg.ManifestFile, error) {
 	if s.ManifestList != "" {
 		f, err := fio.Open(s.ManifestList)
@@ -190,7 +243,129 @@ type MetadataLogEntry struct {
 	TimestampMs  int64  `json:"timestamp-ms"`
 }
 
+// MarshalJSON writes the timestamp first, like the Java implementation.
+func (e MetadataLogEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TimestampMs  int64  `json:"timestamp-ms"`
+		MetadataFile string `json:"metadata-file"`
+	}{e.TimestampMs, e.MetadataFile})
+}
+
 type SnapshotLogEntry struct {
 	SnapshotID  int64 `json:"snapshot-id"`
 	TimestampMs int64 `json:"timestamp-ms"`
 }
+
+// MarshalJSON writes the timestamp first, like the Java implementation.
+func (e SnapshotLogEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TimestampMs int64 `json:"timestamp-ms"`
+		SnapshotID  int64 `json:"snapshot-id"`
+	}{e.TimestampMs, e.SnapshotID})
+}
+
+// AncestorsOf returns the snapshot with the given ID followed by each of its
+// ancestors, found by following the parent-snapshot-id of each snapshot back
+// to the root of the table's history.
+//
+// The walk stops early if a parent snapshot cannot be found in the metadata,
+// such as when it has been expired, or if a cycle is detected. Returns nil if
+// the snapshot itself can't be found.
+func AncestorsOf(meta Metadata, snapshotID int64) []Snapshot {
+	var (
+		out  []Snapshot
+		seen = make(map[int64]struct{})
+	)
+
+	snap := meta.SnapshotByID(snapshotID)
+	for snap != nil {
+		if _, ok := seen[snap.SnapshotID]; ok {
+			break
+		}
+		seen[snap.SnapshotID] = struct{}{}
+		out = append(out, *snap)
+
+		if snap.ParentSnapshotID == nil {
+			break
+		}
+		snap = meta.SnapshotByID(*snap.ParentSnapshotID)
+	}
+
+	return out
+}
+
+// IsAncestorOf returns true if the snapshot with ID ancestorID is the snapshot
+// ofID or one of its ancestors.
+func IsAncestorOf(meta Metadata, ancestorID, ofID int64) bool {
+	for _, s := range AncestorsOf(meta, ofID) {
+		if s.SnapshotID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+// SnapshotSummaryEntry is a compact description of a single snapshot,
+// holding what is needed to show the history of a table without walking
+// the full metadata.
+type SnapshotSummaryEntry struct {
+	TimestampMs      int64
+	SnapshotID       int64
+	ParentSnapshotID *int64
+	Operation        Operation
+	// AddedRecords and DeletedRecords are read from the snapshot summary
+	// and are zero if the summary doesn't record them.
+	AddedRecords   int64
+	DeletedRecords int64
+	// IsCurrentAncestor is whether the snapshot is the current snapshot
+	// or one of its ancestors, rather than a snapshot that was rolled
+	// back or committed to another branch.
+	IsCurrentAncestor bool
+}
+
+// SnapshotsSummary returns a summary of every snapshot in the metadata,
+// sorted by commit time. Snapshots committed at the same time keep the
+// order they have in the metadata.
+func SnapshotsSummary(meta Metadata) []SnapshotSummaryEntry {
+	snapshots := meta.Snapshots()
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	ancestors := make(map[int64]struct{})
+	if current := meta.CurrentSnapshot(); current != nil {
+		for _, s := range AncestorsOf(meta, current.SnapshotID) {
+			ancestors[s.SnapshotID] = struct{}{}
+		}
+	}
+
+	out := make([]SnapshotSummaryEntry, len(snapshots))
+	for i, s := range snapshots {
+		_, isAncestor := ancestors[s.SnapshotID]
+		out[i] = SnapshotSummaryEntry{
+			TimestampMs:       s.TimestampMs,
+			SnapshotID:        s.SnapshotID,
+			ParentSnapshotID:  s.ParentSnapshotID,
+			IsCurrentAncestor: isAncestor,
+		}
+
+		if s.Summary != nil {
+			out[i].Operation = s.Summary.Operation
+			out[i].AddedRecords = summaryInt(s.Summary, "added-records")
+			out[i].DeletedRecords = summaryInt(s.Summary, "deleted-records")
+		}
+	}
+
+	slices.SortStableFunc(out, func(a, b SnapshotSummaryEntry) int {
+		return cmp.Compare(a.TimestampMs, b.TimestampMs)
+	})
+	return out
+}
+
+func summaryInt(s *Summary, key string) int64 {
+	v, err := strconv.ParseInt(s.Properties[key], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
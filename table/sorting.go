@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/apache/arrow/go/v16/arrow"
 	"github.com/apache/iceberg-go"
 )
 
@@ -43,6 +44,7 @@ const (
 var (
 	ErrInvalidSortDirection = errors.New("invalid sort direction, must be 'asc' or 'desc'")
 	ErrInvalidNullOrder     = errors.New("invalid null order, must be 'nulls-first' or 'nulls-last'")
+	ErrSortOrderViolated    = errors.New("data does not satisfy sort order")
 )
 
 // SortField describes a field used in a sort order definition.
@@ -66,7 +68,10 @@ func (s *SortField) String() string {
 	return fmt.Sprintf("%s(%d) %s %s", s.Transform, s.SourceID, s.Direction, s.NullOrder)
 }
 
-func (s *SortField) MarshalJSON() ([]byte, error) {
+// setDefaults fills in an ascending direction when none is set, and the
+// null order implied by the direction (nulls-first for ascending,
+// nulls-last for descending) when no null order is set.
+func (s *SortField) setDefaults() {
 	if s.Direction == "" {
 		s.Direction = SortASC
 	}
@@ -78,9 +83,19 @@ func (s *SortField) MarshalJSON() ([]byte, error) {
 			s.NullOrder = NullsLast
 		}
 	}
+}
 
-	type Alias SortField
-	return json.Marshal((*Alias)(s))
+// MarshalJSON writes the fields in the same order as the Java
+// implementation.
+func (s *SortField) MarshalJSON() ([]byte, error) {
+	s.setDefaults()
+
+	return json.Marshal(struct {
+		Transform iceberg.Transform `json:"transform"`
+		SourceID  int               `json:"source-id"`
+		Direction SortDirection     `json:"direction"`
+		NullOrder NullOrder         `json:"null-order"`
+	}{s.Transform, s.SourceID, s.Direction, s.NullOrder})
 }
 
 func (s *SortField) UnmarshalJSON(b []byte) error {
@@ -101,6 +116,7 @@ func (s *SortField) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	s.setDefaults()
 	switch s.Direction {
 	case SortASC, SortDESC:
 	default:
@@ -169,3 +185,92 @@ func (s *SortOrder) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// IsUnsorted returns true if the sort order has no fields.
+func (s SortOrder) IsUnsorted() bool { return len(s.Fields) == 0 }
+
+// SatisfiedBy checks that the rows of data are ordered according to the
+// sort order, honoring the direction and null order of each field. Columns
+// are matched to sort fields by the field ids stored in the arrow field
+// metadata, as produced by SchemaToArrowSchema with includeFieldIDs set.
+//
+// Only identity sort fields on top-level primitive columns are currently
+// supported, other transforms return an error wrapping
+// iceberg.ErrNotImplemented. If a row sorts before the row preceding it,
+// an error wrapping ErrSortOrderViolated is returned.
+func (s SortOrder) SatisfiedBy(data arrow.Record) error {
+	if s.IsUnsorted() || data.NumRows() < 2 {
+		return nil
+	}
+
+	colsByID := make(map[int]int, data.NumCols())
+	for i, f := range data.Schema().Fields() {
+		if id, err := arrowFieldID(f); err == nil {
+			colsByID[id] = i
+		}
+	}
+
+	comparators := make([]func(i, j int) int, len(s.Fields))
+	for n, field := range s.Fields {
+		if _, ok := field.Transform.(iceberg.IdentityTransform); !ok {
+			return fmt.Errorf("%w: checking sort field with transform %s",
+				iceberg.ErrNotImplemented, field.Transform)
+		}
+
+		idx, ok := colsByID[field.SourceID]
+		if !ok {
+			return fmt.Errorf("%w: no column for sort field source id %d",
+				iceberg.ErrInvalidSchema, field.SourceID)
+		}
+
+		cmp, err := arrowValueComparator(data.Column(idx))
+		if err != nil {
+			return err
+		}
+		comparators[n] = sortFieldComparator(field, data.Column(idx), cmp)
+	}
+
+	for row := 1; row < int(data.NumRows()); row++ {
+		for _, cmp := range comparators {
+			c := cmp(row-1, row)
+			if c < 0 {
+				break
+			}
+			if c > 0 {
+				return fmt.Errorf("%w: row %d sorts before row %d in sort order %d",
+					ErrSortOrderViolated, row, row-1, s.OrderID)
+			}
+		}
+	}
+	return nil
+}
+
+// sortFieldComparator wraps a comparator of non-null values with the
+// direction and null ordering of the sort field.
+func sortFieldComparator(field SortField, arr arrow.Array, cmp func(i, j int) int) func(i, j int) int {
+	nullsFirst := field.NullOrder == NullsFirst ||
+		(field.NullOrder == "" && field.Direction != SortDESC)
+
+	return func(i, j int) int {
+		iNull, jNull := arr.IsNull(i), arr.IsNull(j)
+		switch {
+		case iNull && jNull:
+			return 0
+		case iNull:
+			if nullsFirst {
+				return -1
+			}
+			return 1
+		case jNull:
+			if nullsFirst {
+				return 1
+			}
+			return -1
+		}
+
+		if field.Direction == SortDESC {
+			return cmp(j, i)
+		}
+		return cmp(i, j)
+	}
+}
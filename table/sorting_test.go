@@ -19,8 +19,11 @@ package table_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/apache/arrow/go/v16/arrow/array"
+	"github.com/apache/arrow/go/v16/arrow/memory"
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/table"
 	"github.com/stretchr/testify/assert"
@@ -108,3 +111,109 @@ func TestUnmarshalInvalidSortTransform(t *testing.T) {
 	err := json.Unmarshal([]byte(badJson), &order)
 	assert.ErrorIs(t, err, iceberg.ErrInvalidTransform)
 }
+
+func TestSortOrderRoundTrip(t *testing.T) {
+	data, err := json.Marshal(sortOrder)
+	require.NoError(t, err)
+
+	var order table.SortOrder
+	require.NoError(t, json.Unmarshal(data, &order))
+	assert.Equal(t, sortOrder.OrderID, order.OrderID)
+	require.Len(t, order.Fields, 3)
+	for i, f := range order.Fields {
+		assert.Equal(t, sortOrder.Fields[i].SourceID, f.SourceID)
+		assert.Equal(t, sortOrder.Fields[i].Transform, f.Transform)
+	}
+
+	assert.Equal(t, table.SortASC, order.Fields[0].Direction)
+	assert.Equal(t, table.NullsFirst, order.Fields[0].NullOrder)
+	assert.Equal(t, table.SortDESC, order.Fields[1].Direction)
+	assert.Equal(t, table.NullsLast, order.Fields[1].NullOrder)
+	assert.Equal(t, table.SortASC, order.Fields[2].Direction)
+	assert.Equal(t, table.NullsFirst, order.Fields[2].NullOrder)
+
+	again, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(again))
+}
+
+func TestUnmarshalSortFieldDefaults(t *testing.T) {
+	var order table.SortOrder
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"order-id": 1,
+		"fields": [
+			{"source-id": 1, "transform": "identity"},
+			{"source-id": 2, "transform": "identity", "direction": "desc"},
+			{"source-id": 3, "transform": "identity", "direction": "desc", "null-order": "nulls-first"}
+		]
+	}`), &order))
+
+	assert.Equal(t, table.SortASC, order.Fields[0].Direction)
+	assert.Equal(t, table.NullsFirst, order.Fields[0].NullOrder)
+	assert.Equal(t, table.NullsLast, order.Fields[1].NullOrder)
+	assert.Equal(t, table.NullsFirst, order.Fields[2].NullOrder)
+}
+
+func TestSortOrderSatisfiedBy(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+	)
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true)
+	require.NoError(t, err)
+
+	rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, arrSchema, strings.NewReader(`[
+		{"id": null, "name": "c"},
+		{"id": 1, "name": "b"},
+		{"id": 1, "name": "a"},
+		{"id": 2, "name": null}
+	]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	tests := []struct {
+		name   string
+		fields []table.SortField
+		err    error
+	}{
+		{"unsorted", nil, nil},
+		{"id asc nulls first", []table.SortField{
+			{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+		}, nil},
+		{"id asc nulls last", []table.SortField{
+			{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsLast},
+		}, table.ErrSortOrderViolated},
+		{"id asc, name desc", []table.SortField{
+			{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+			{SourceID: 2, Transform: iceberg.IdentityTransform{}, Direction: table.SortDESC, NullOrder: table.NullsLast},
+		}, nil},
+		{"id asc, name asc", []table.SortField{
+			{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+			{SourceID: 2, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+		}, table.ErrSortOrderViolated},
+		{"name desc nulls last", []table.SortField{
+			{SourceID: 2, Transform: iceberg.IdentityTransform{}, Direction: table.SortDESC, NullOrder: table.NullsLast},
+		}, nil},
+		{"name desc nulls first", []table.SortField{
+			{SourceID: 2, Transform: iceberg.IdentityTransform{}, Direction: table.SortDESC, NullOrder: table.NullsFirst},
+		}, table.ErrSortOrderViolated},
+		{"bucket", []table.SortField{
+			{SourceID: 1, Transform: iceberg.BucketTransform{NumBuckets: 4}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+		}, iceberg.ErrNotImplemented},
+		{"missing column", []table.SortField{
+			{SourceID: 3, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+		}, iceberg.ErrInvalidSchema},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := table.SortOrder{OrderID: 1, Fields: tt.fields}
+			err := order.SatisfiedBy(rec)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
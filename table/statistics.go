@@ -0,0 +1,106 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import "context"
+
+// StatisticsFile is a file containing table statistics for a snapshot,
+// such as a Puffin file with sketches of the distinct values of columns.
+//
+// https://iceberg.apache.org/spec/#table-statistics
+type StatisticsFile struct {
+	SnapshotID            int64          `json:"snapshot-id"`
+	StatisticsPath        string         `json:"statistics-path"`
+	FileSizeInBytes       int64          `json:"file-size-in-bytes"`
+	FileFooterSizeInBytes int64          `json:"file-footer-size-in-bytes"`
+	KeyMetadata           string         `json:"key-metadata,omitempty"`
+	BlobMetadata          []BlobMetadata `json:"blob-metadata"`
+}
+
+// BlobMetadata describes a blob of a statistics file.
+type BlobMetadata struct {
+	Type           string            `json:"type"`
+	SnapshotID     int64             `json:"snapshot-id"`
+	SequenceNumber int64             `json:"sequence-number"`
+	Fields         []int             `json:"fields"`
+	Properties     map[string]string `json:"properties,omitempty"`
+}
+
+// PartitionStatisticsFile is a file containing statistics for each
+// partition of the table as of a snapshot.
+//
+// https://iceberg.apache.org/spec/#partition-statistics
+type PartitionStatisticsFile struct {
+	SnapshotID      int64  `json:"snapshot-id"`
+	StatisticsPath  string `json:"statistics-path"`
+	FileSizeInBytes int64  `json:"file-size-in-bytes"`
+}
+
+// UpdateStatistics collects changes to the statistics and partition
+// statistics files registered in a table's metadata, which are committed
+// together by Commit. Each snapshot has at most one statistics file and
+// one partition statistics file, so setting a file replaces the existing
+// one for its snapshot.
+type UpdateStatistics struct {
+	tbl     *Table
+	updates []Update
+}
+
+// UpdateStatistics begins a change to the statistics files of the table.
+func (t Table) UpdateStatistics() *UpdateStatistics {
+	return &UpdateStatistics{tbl: &t}
+}
+
+// Set registers the statistics file for its snapshot.
+func (u *UpdateStatistics) Set(stats StatisticsFile) *UpdateStatistics {
+	u.updates = append(u.updates, NewSetStatisticsUpdate(stats))
+	return u
+}
+
+// Remove removes the statistics file of the snapshot.
+func (u *UpdateStatistics) Remove(snapshotID int64) *UpdateStatistics {
+	u.updates = append(u.updates, NewRemoveStatisticsUpdate(snapshotID))
+	return u
+}
+
+// SetPartitionStatistics registers the partition statistics file for its
+// snapshot.
+func (u *UpdateStatistics) SetPartitionStatistics(stats PartitionStatisticsFile) *UpdateStatistics {
+	u.updates = append(u.updates, NewSetPartitionStatisticsUpdate(stats))
+	return u
+}
+
+// RemovePartitionStatistics removes the partition statistics file of the
+// snapshot.
+func (u *UpdateStatistics) RemovePartitionStatistics(snapshotID int64) *UpdateStatistics {
+	u.updates = append(u.updates, NewRemovePartitionStatisticsUpdate(snapshotID))
+	return u
+}
+
+// Commit commits the changes through the catalog the table was loaded
+// from, and returns the updated table.
+func (u *UpdateStatistics) Commit(ctx context.Context) (*Table, error) {
+	txn, err := u.tbl.NewTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.apply(u.updates, nil); err != nil {
+		return nil, err
+	}
+	return txn.Commit(ctx)
+}
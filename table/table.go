@@ -18,7 +18,13 @@
 package table
 
 import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/io"
@@ -27,11 +33,35 @@ import (
 
 type Identifier = []string
 
+// Properties which can be passed to a catalog's LoadTable to pin the
+// returned table to a snapshot, see [Table.PinFromProperties].
+const (
+	LoadSnapshotIDProp      = "snapshot-id"
+	LoadAsOfTimestampMsProp = "as-of-timestamp-ms"
+	LoadRefProp             = "ref"
+)
+
+// CatalogIO is the part of a catalog needed to reload a table and commit
+// changes to it, it's implemented by the catalogs in the catalog package.
+type CatalogIO interface {
+	LoadTable(ctx context.Context, identifier Identifier, props iceberg.Properties) (*Table, error)
+	// CommitTable applies the updates to the table if the requirements
+	// hold for its current metadata, and returns the new metadata along
+	// with its location.
+	CommitTable(ctx context.Context, identifier Identifier, requirements []Requirement, updates []Update) (Metadata, string, error)
+}
+
 type Table struct {
 	identifier       Identifier
 	metadata         Metadata
 	metadataLocation string
 	fs               io.IO
+	clock            Clock
+	pinned           *Snapshot
+
+	cat      CatalogIO
+	catIdent Identifier
+	catProps iceberg.Properties
 }
 
 func (t Table) Equals(other Table) bool {
@@ -45,6 +75,149 @@ func (t Table) Metadata() Metadata       { return t.metadata }
 func (t Table) MetadataLocation() string { return t.metadataLocation }
 func (t Table) FS() io.IO                { return t.fs }
 
+// Clock returns the clock used for timestamps when changing the table.
+func (t Table) Clock() Clock {
+	if t.clock == nil {
+		return SystemClock
+	}
+	return t.clock
+}
+
+// WithClock returns a copy of the table which uses the given clock for
+// the timestamps of metadata changes made through transactions.
+func (t Table) WithClock(c Clock) *Table {
+	t.clock = c
+	return &t
+}
+
+// WithCatalog returns a copy of the table which remembers the catalog it
+// was loaded from, along with the identifier and properties that were
+// passed to LoadTable, so that it can be reloaded with Refresh.
+func (t Table) WithCatalog(cat CatalogIO, identifier Identifier, props iceberg.Properties) *Table {
+	t.cat, t.catIdent, t.catProps = cat, identifier, props
+	return &t
+}
+
+// Refresh reloads the latest metadata of the table from the catalog it was
+// loaded from, and returns it as a new table. The table Refresh is called
+// on is left unchanged, so it's safe to keep reading from it concurrently.
+//
+// If the table was dropped, the catalog's not found error is returned,
+// which wraps catalog.ErrNoSuchTable. Tables which weren't loaded from a
+// catalog can't be refreshed and return an error wrapping
+// iceberg.ErrInvalidArgument.
+//
+// Snapshot pins requested by the LoadTable properties are applied again
+// to the reloaded table, pins set with At, AsOf or AtRef are not.
+func (t Table) Refresh(ctx context.Context) (*Table, error) {
+	if t.cat == nil {
+		return nil, fmt.Errorf("%w: table %v was not loaded from a catalog",
+			iceberg.ErrInvalidArgument, t.identifier)
+	}
+
+	fresh, err := t.cat.LoadTable(ctx, t.catIdent, t.catProps)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.clock != nil {
+		fresh = fresh.WithClock(t.clock)
+	}
+	return fresh, nil
+}
+
+// Snapshot returns the snapshot the table is pinned to with At, AsOf or
+// AtRef, or its current snapshot if it isn't pinned. Reads of the table
+// should use this snapshot.
+func (t Table) Snapshot() *Snapshot {
+	if t.pinned != nil {
+		return t.pinned
+	}
+	return t.metadata.CurrentSnapshot()
+}
+
+// At returns a copy of the table pinned to the snapshot with the given ID.
+func (t Table) At(snapshotID int64) (*Table, error) {
+	snap := t.metadata.SnapshotByID(snapshotID)
+	if snap == nil {
+		return nil, fmt.Errorf("%w: snapshot %d not found in table %v",
+			iceberg.ErrInvalidArgument, snapshotID, t.identifier)
+	}
+	t.pinned = snap
+	return &t, nil
+}
+
+// AsOf returns a copy of the table pinned to the snapshot which was
+// current at the given time, in milliseconds since the epoch, according
+// to the table's snapshot log.
+func (t Table) AsOf(timestampMs int64) (*Table, error) {
+	var (
+		snapshotID int64
+		found      bool
+	)
+	for _, entry := range t.metadata.SnapshotLogs() {
+		if entry.TimestampMs > timestampMs {
+			break
+		}
+		snapshotID, found = entry.SnapshotID, true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: table %v has no snapshot as of %d",
+			iceberg.ErrInvalidArgument, t.identifier, timestampMs)
+	}
+	return t.At(snapshotID)
+}
+
+// AtRef returns a copy of the table pinned to the snapshot the branch or
+// tag with the given name refers to.
+func (t Table) AtRef(name string) (*Table, error) {
+	snap := t.metadata.SnapshotByName(name)
+	if snap == nil {
+		return nil, fmt.Errorf("%w: ref %s not found in table %v",
+			iceberg.ErrInvalidArgument, name, t.identifier)
+	}
+	t.pinned = snap
+	return &t, nil
+}
+
+// PinFromProperties pins the table to the snapshot requested by the
+// LoadTable properties, using At for LoadSnapshotIDProp, AsOf for
+// LoadAsOfTimestampMsProp and AtRef for LoadRefProp. The table is
+// returned unchanged if none of them are set, and at most one of them
+// may be set.
+func (t Table) PinFromProperties(props iceberg.Properties) (*Table, error) {
+	var set []string
+	for _, k := range []string{LoadSnapshotIDProp, LoadAsOfTimestampMsProp, LoadRefProp} {
+		if _, ok := props[k]; ok {
+			set = append(set, k)
+		}
+	}
+
+	switch len(set) {
+	case 0:
+		return &t, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("%w: conflicting snapshot pins %s, only one may be set",
+			iceberg.ErrInvalidArgument, strings.Join(set, ", "))
+	}
+
+	if ref, ok := props[LoadRefProp]; ok {
+		return t.AtRef(ref)
+	}
+
+	v, err := strconv.ParseInt(props[set[0]], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s %q", iceberg.ErrInvalidArgument, set[0], props[set[0]])
+	}
+
+	if set[0] == LoadSnapshotIDProp {
+		return t.At(v)
+	}
+	return t.AsOf(v)
+}
+
 func (t Table) Schema() *iceberg.Schema              { return t.metadata.CurrentSchema() }
 func (t Table) Spec() iceberg.PartitionSpec          { return t.metadata.PartitionSpec() }
 func (t Table) SortOrder() SortOrder                 { return t.metadata.SortOrder() }
@@ -53,6 +226,43 @@ func (t Table) Location() string                     { return t.metadata.Locatio
 func (t Table) CurrentSnapshot() *Snapshot           { return t.metadata.CurrentSnapshot() }
 func (t Table) SnapshotByID(id int64) *Snapshot      { return t.metadata.SnapshotByID(id) }
 func (t Table) SnapshotByName(name string) *Snapshot { return t.metadata.SnapshotByName(name) }
+func (t Table) SnapshotLog() []SnapshotLogEntry      { return t.metadata.SnapshotLogs() }
+func (t Table) MetadataLog() []MetadataLogEntry      { return t.metadata.MetadataLogs() }
+
+// SnapshotsSummary returns a summary of each snapshot of the table sorted
+// by commit time, see [SnapshotsSummary].
+func (t Table) SnapshotsSummary() []SnapshotSummaryEntry { return SnapshotsSummary(t.metadata) }
+
+// ResolveLocation returns the location of a file referenced by the table's
+// metadata, such as a data file path. Paths are absolute according to the
+// spec, but some writers reference files relative to the table location,
+// so paths without a scheme or leading slash are resolved against it.
+// Windows paths with a drive letter, such as C:\data\1.parquet, are
+// absolute too.
+func (t Table) ResolveLocation(path string) string {
+	if path == "" || strings.HasPrefix(path, "/") || filepath.VolumeName(path) != "" {
+		return path
+	}
+	// a drive letter is parsed as a scheme as well
+	if parsed, err := url.Parse(path); err == nil && parsed.Scheme != "" {
+		return path
+	}
+	return strings.TrimRight(t.Location(), "/") + "/" + path
+}
+
+// Ancestors returns the snapshot with the given ID and all of its ancestors,
+// ordered from the snapshot itself back to the root of the table's history.
+// See [AncestorsOf] for details.
+func (t Table) Ancestors(snapshotID int64) []Snapshot {
+	return AncestorsOf(t.metadata, snapshotID)
+}
+
+// IsAncestor returns true if ancestorID is the snapshot ofID or one of
+// its ancestors.
+func (t Table) IsAncestor(ancestorID, ofID int64) bool {
+	return IsAncestorOf(t.metadata, ancestorID, ofID)
+}
+
 func (t Table) Schemas() map[int]*iceberg.Schema {
 	m := make(map[int]*iceberg.Schema)
 	for _, s := range t.metadata.Schemas() {
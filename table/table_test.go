@@ -19,11 +19,17 @@ package table_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/internal"
+	"github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -97,6 +103,16 @@ func (t *TableTestSuite) TestLocation() {
 	t.Equal("s3://bucket/test/location", t.tbl.Location())
 }
 
+func (t *TableTestSuite) TestResolveLocation() {
+	t.Equal("s3://other-bucket/data/1.parquet", t.tbl.ResolveLocation("s3://other-bucket/data/1.parquet"))
+	t.Equal("/tmp/data/1.parquet", t.tbl.ResolveLocation("/tmp/data/1.parquet"))
+	t.Equal("s3://bucket/test/location/data/1.parquet", t.tbl.ResolveLocation("data/1.parquet"))
+	t.Equal(`C:\data\1.parquet`, t.tbl.ResolveLocation(`C:\data\1.parquet`))
+	t.Equal("C:/data/1.parquet", t.tbl.ResolveLocation("C:/data/1.parquet"))
+	t.Equal("file:/tmp/data/1.parquet", t.tbl.ResolveLocation("file:/tmp/data/1.parquet"))
+	t.Equal("file:///C:/data/1.parquet", t.tbl.ResolveLocation("file:///C:/data/1.parquet"))
+}
+
 func (t *TableTestSuite) TestSnapshot() {
 	var (
 		parentSnapshotID int64 = 3051729675574597004
@@ -128,3 +144,211 @@ func (t *TableTestSuite) TestSnapshotByName() {
 
 	t.True(testSnapshot.Equals(*t.tbl.SnapshotByName("test")))
 }
+
+func (t *TableTestSuite) TestPinSnapshot() {
+	t.EqualValues(3055729675574597004, t.tbl.Snapshot().SnapshotID)
+
+	pinned, err := t.tbl.At(3051729675574597004)
+	t.Require().NoError(err)
+	t.EqualValues(3051729675574597004, pinned.Snapshot().SnapshotID)
+	t.EqualValues(3055729675574597004, pinned.CurrentSnapshot().SnapshotID)
+	t.EqualValues(3055729675574597004, t.tbl.Snapshot().SnapshotID)
+
+	_, err = t.tbl.At(1)
+	t.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	pinned, err = t.tbl.AsOf(1555100955769)
+	t.Require().NoError(err)
+	t.EqualValues(3051729675574597004, pinned.Snapshot().SnapshotID)
+
+	pinned, err = t.tbl.AsOf(1555100955770)
+	t.Require().NoError(err)
+	t.EqualValues(3055729675574597004, pinned.Snapshot().SnapshotID)
+
+	_, err = t.tbl.AsOf(1515100955769)
+	t.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	pinned, err = t.tbl.AtRef("test")
+	t.Require().NoError(err)
+	t.EqualValues(3051729675574597004, pinned.Snapshot().SnapshotID)
+
+	_, err = t.tbl.AtRef("missing")
+	t.ErrorIs(err, iceberg.ErrInvalidArgument)
+}
+
+func (t *TableTestSuite) TestPinFromProperties() {
+	tests := []struct {
+		props    iceberg.Properties
+		expected int64
+	}{
+		{nil, 3055729675574597004},
+		{iceberg.Properties{"owner": "me"}, 3055729675574597004},
+		{iceberg.Properties{table.LoadSnapshotIDProp: "3051729675574597004"}, 3051729675574597004},
+		{iceberg.Properties{table.LoadAsOfTimestampMsProp: "1515100955770"}, 3051729675574597004},
+		{iceberg.Properties{table.LoadRefProp: "main"}, 3055729675574597004},
+	}
+
+	for _, tt := range tests {
+		pinned, err := t.tbl.PinFromProperties(tt.props)
+		t.Require().NoError(err)
+		t.Equal(tt.expected, pinned.Snapshot().SnapshotID)
+	}
+
+	for _, props := range []iceberg.Properties{
+		{table.LoadSnapshotIDProp: "abc"},
+		{table.LoadAsOfTimestampMsProp: "yesterday"},
+		{table.LoadSnapshotIDProp: "42"},
+		{table.LoadSnapshotIDProp: "3051729675574597004", table.LoadRefProp: "main"},
+		{table.LoadAsOfTimestampMsProp: "1515100955770", table.LoadRefProp: "test"},
+	} {
+		_, err := t.tbl.PinFromProperties(props)
+		t.ErrorIs(err, iceberg.ErrInvalidArgument, props)
+	}
+}
+
+func (t *TableTestSuite) TestSnapshotLog() {
+	t.Equal([]table.SnapshotLogEntry{
+		{SnapshotID: 3051729675574597004, TimestampMs: 1515100955770},
+		{SnapshotID: 3055729675574597004, TimestampMs: 1555100955770},
+	}, t.tbl.SnapshotLog())
+}
+
+func (t *TableTestSuite) TestMetadataLog() {
+	t.Equal([]table.MetadataLogEntry{
+		{MetadataFile: "s3://bucket/.../v1.json", TimestampMs: 1515100},
+	}, t.tbl.MetadataLog())
+}
+
+func (t *TableTestSuite) TestAncestors() {
+	ancestors := t.tbl.Ancestors(3055729675574597004)
+	t.Require().Len(ancestors, 2)
+	t.EqualValues(3055729675574597004, ancestors[0].SnapshotID)
+	t.EqualValues(3051729675574597004, ancestors[1].SnapshotID)
+
+	ancestors = t.tbl.Ancestors(3051729675574597004)
+	t.Require().Len(ancestors, 1)
+	t.EqualValues(3051729675574597004, ancestors[0].SnapshotID)
+
+	t.Nil(t.tbl.Ancestors(1234))
+
+	t.True(t.tbl.IsAncestor(3051729675574597004, 3055729675574597004))
+	t.True(t.tbl.IsAncestor(3055729675574597004, 3055729675574597004))
+	t.False(t.tbl.IsAncestor(3055729675574597004, 3051729675574597004))
+	t.False(t.tbl.IsAncestor(1234, 3055729675574597004))
+}
+
+func (t *TableTestSuite) TestAncestorsStopsOnCycleOrMissingParent() {
+	cyclic := strings.Replace(ExampleTableMetadataV2,
+		`"snapshot-id": 3051729675574597004,
+            "timestamp-ms"`,
+		`"snapshot-id": 3051729675574597004,
+            "parent-snapshot-id": 3055729675574597004,
+            "timestamp-ms"`, 1)
+	meta, err := table.ParseMetadataString(cyclic)
+	t.Require().NoError(err)
+
+	ancestors := table.AncestorsOf(meta, 3055729675574597004)
+	t.Require().Len(ancestors, 2)
+	t.EqualValues(3055729675574597004, ancestors[0].SnapshotID)
+	t.EqualValues(3051729675574597004, ancestors[1].SnapshotID)
+	t.False(table.IsAncestorOf(meta, 1234, 3055729675574597004))
+
+	missing := strings.Replace(ExampleTableMetadataV2,
+		`"parent-snapshot-id": 3051729675574597004`,
+		`"parent-snapshot-id": 1234`, 1)
+	meta, err = table.ParseMetadataString(missing)
+	t.Require().NoError(err)
+
+	ancestors = table.AncestorsOf(meta, 3055729675574597004)
+	t.Require().Len(ancestors, 1)
+	t.EqualValues(3055729675574597004, ancestors[0].SnapshotID)
+}
+
+func (t *TableTestSuite) TestSnapshotsSummary() {
+	// a rolled back overwrite, listed first but committed between the
+	// two appends of the example metadata
+	metadata := strings.Replace(ExampleTableMetadataV2, `"snapshots": [`,
+		`"snapshots": [
+        {
+            "snapshot-id": 1234,
+            "parent-snapshot-id": 3051729675574597004,
+            "timestamp-ms": 1535100955770,
+            "sequence-number": 1,
+            "summary": {"operation": "overwrite", "added-records": "10", "deleted-records": "4"},
+            "manifest-list": "s3://a/b/3.avro"
+        },`, 1)
+	meta, err := table.ParseMetadataString(metadata)
+	t.Require().NoError(err)
+
+	parent := int64(3051729675574597004)
+	t.Equal([]table.SnapshotSummaryEntry{
+		{
+			TimestampMs: 1515100955770, SnapshotID: 3051729675574597004,
+			Operation: table.OpAppend, IsCurrentAncestor: true,
+		},
+		{
+			TimestampMs: 1535100955770, SnapshotID: 1234, ParentSnapshotID: &parent,
+			Operation: table.OpOverwrite, AddedRecords: 10, DeletedRecords: 4,
+		},
+		{
+			TimestampMs: 1555100955770, SnapshotID: 3055729675574597004, ParentSnapshotID: &parent,
+			Operation: table.OpAppend, IsCurrentAncestor: true,
+		},
+	}, table.New(t.tbl.Identifier(), meta, "", nil).SnapshotsSummary())
+
+	empty, err := table.NewMetadata(t.tbl.Schema(), iceberg.UnpartitionedSpec,
+		table.UnsortedSortOrder, "s3://bucket/test", nil)
+	t.Require().NoError(err)
+	t.Nil(table.SnapshotsSummary(empty))
+}
+
+func TestNewTableFromS3ALocation(t *testing.T) {
+	// metadata written by Hadoop-based engines may use the s3a and s3n
+	// schemes, possibly mixed with s3 within the same table
+	metadata := strings.NewReplacer(
+		"s3://bucket", "s3a://bucket",
+		"s3://a/b/1.avro", "s3a://a/b/1.avro",
+		"s3://a/b/2.avro", "s3n://a/b/2.avro",
+	).Replace(ExampleTableMetadataV2)
+
+	const metaLoc = "s3a://bucket/test/location/uuid.metadata.json"
+
+	var mockfs internal.MockFS
+	mockfs.Test(t)
+	mockfs.On("Open", metaLoc).
+		Return(&internal.MockFile{Contents: bytes.NewReader([]byte(metadata))}, nil)
+	defer mockfs.AssertExpectations(t)
+
+	tbl, err := table.NewFromLocation([]string{"foo"}, metaLoc, &mockfs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3a://bucket/test/location", tbl.Location())
+	assert.Equal(t, "s3a://a/b/1.avro", tbl.SnapshotByID(3051729675574597004).ManifestList)
+	assert.Equal(t, "s3n://a/b/2.avro", tbl.CurrentSnapshot().ManifestList)
+
+	for _, loc := range []string{tbl.Location(), tbl.CurrentSnapshot().ManifestList} {
+		fsys, err := io.LoadFS(map[string]string{io.S3Region: "us-east-1"}, loc)
+		require.NoError(t, err, loc)
+		assert.NotEqual(t, io.LocalFS{}, fsys, loc)
+	}
+}
+
+func TestLoadFSForLocations(t *testing.T) {
+	// data files may be stored in another bucket or store than the
+	// table's metadata, each of them is opened with its own IO
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "1.parquet")
+	require.NoError(t, os.WriteFile(dataFile, []byte("data"), 0o644))
+
+	fsys, err := io.LoadFSForLocations(map[string]string{io.S3Region: "us-east-1"},
+		"s3://bucket/test/location/uuid.metadata.json")
+	require.NoError(t, err)
+
+	require.Implements(t, (*io.ReadFileIO)(nil), fsys)
+	contents, err := fsys.(io.ReadFileIO).ReadFile(dataFile)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(contents))
+
+	_, err = fsys.Open("unknown://other-bucket/data/1.parquet")
+	assert.ErrorContains(t, err, "not implemented")
+}
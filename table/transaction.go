@@ -0,0 +1,156 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+	"golang.org/x/exp/slices"
+)
+
+// Transaction accumulates a set of changes to a table's metadata, along
+// with the requirements that must hold for those changes to be committed.
+// A Transaction is safe for concurrent use.
+type Transaction struct {
+	tbl  *Table
+	meta *MetadataBuilder
+	reqs []Requirement
+
+	mx sync.Mutex
+}
+
+// NewTransaction begins a new transaction against the current metadata of
+// the table.
+func (t Table) NewTransaction() (*Transaction, error) {
+	meta, err := MetadataBuilderFromBase(t.metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.SetClock(t.Clock())
+	return &Transaction{tbl: &t, meta: meta}, nil
+}
+
+func (t *Transaction) apply(updates []Update, reqs []Requirement) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	// replay the updates so far and the new ones onto a new builder, so
+	// that the transaction is left unchanged if any of them fail
+	meta, err := MetadataBuilderFromBase(t.tbl.metadata)
+	if err != nil {
+		return err
+	}
+	meta.SetClock(t.tbl.Clock())
+
+	for _, u := range append(slices.Clone(t.meta.updates), updates...) {
+		if err := u.Apply(meta); err != nil {
+			return err
+		}
+	}
+
+	if len(t.meta.updates) == 0 && len(updates) > 0 {
+		// every commit requires that the table hasn't been replaced
+		// since the transaction was started.
+		t.reqs = append(t.reqs, AssertTableUUID(t.tbl.metadata.TableUUID()))
+	}
+
+	for _, r := range reqs {
+		exists := slices.ContainsFunc(t.reqs, func(x Requirement) bool {
+			return reflect.DeepEqual(x, r)
+		})
+		if !exists {
+			t.reqs = append(t.reqs, r)
+		}
+	}
+
+	t.meta = meta
+	return nil
+}
+
+// SetProperties adds or replaces the given table properties.
+func (t *Transaction) SetProperties(props iceberg.Properties) error {
+	if len(props) == 0 {
+		return nil
+	}
+
+	return t.apply([]Update{NewSetPropertiesUpdate(props)}, nil)
+}
+
+// RemoveProperties removes the given keys from the table properties.
+func (t *Transaction) RemoveProperties(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return t.apply([]Update{NewRemovePropertiesUpdate(keys)}, nil)
+}
+
+// Plan returns the metadata that would result from committing the
+// transaction, along with the updates and requirements that would be sent
+// to the catalog. Nothing is committed and the catalog isn't contacted, so
+// this can be used to preview or review a change before committing it.
+//
+// The metadata is produced by applying the accumulated updates to the base
+// metadata of the transaction, using the same logic as a commit.
+func (t *Transaction) Plan() (Metadata, []Update, []Requirement, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	updates := slices.Clone(t.meta.updates)
+	meta, err := applyUpdates(t.tbl.metadata, updates, t.tbl.Clock())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return meta, updates, slices.Clone(t.reqs), nil
+}
+
+// Commit sends the accumulated updates and requirements to the catalog the
+// table was loaded from, and returns the table with the committed metadata.
+// A transaction without any changes returns the table it was started on.
+//
+// Tables which weren't loaded from a catalog can't be committed to and
+// return an error wrapping iceberg.ErrInvalidArgument.
+func (t *Transaction) Commit(ctx context.Context) (*Table, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if len(t.meta.updates) == 0 {
+		return t.tbl, nil
+	}
+
+	if t.tbl.cat == nil {
+		return nil, fmt.Errorf("%w: table %v was not loaded from a catalog",
+			iceberg.ErrInvalidArgument, t.tbl.identifier)
+	}
+
+	meta, loc, err := t.tbl.cat.CommitTable(ctx, t.tbl.catIdent,
+		slices.Clone(t.reqs), slices.Clone(t.meta.updates))
+	if err != nil {
+		return nil, err
+	}
+
+	tbl := *t.tbl
+	tbl.metadata, tbl.metadataLocation = meta, loc
+	return &tbl, nil
+}
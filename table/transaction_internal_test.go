@@ -0,0 +1,68 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionApply(t *testing.T) {
+	sc := iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, "s3://bucket/test", nil)
+	require.NoError(t, err)
+
+	txn, err := New(Identifier{"db", "tbl"}, meta, "", nil).NewTransaction()
+	require.NoError(t, err)
+
+	// a failing update leaves the transaction as it was, including the
+	// updates before it
+	err = txn.apply([]Update{
+		NewSetPropertiesUpdate(iceberg.Properties{"foo": "bar"}),
+		NewSetCurrentSchemaUpdate(42),
+	}, []Requirement{AssertRefSnapshotID(MainBranch, nil)})
+	require.Error(t, err)
+
+	planned, updates, reqs, err := txn.Plan()
+	require.NoError(t, err)
+	assert.Empty(t, planned.Properties())
+	assert.Empty(t, updates)
+	assert.Empty(t, reqs)
+
+	// requirements are only deduplicated if they're the same, not if
+	// they're of the same type
+	snapshotID := int64(1)
+	require.NoError(t, txn.apply([]Update{NewSetPropertiesUpdate(iceberg.Properties{"foo": "bar"})},
+		[]Requirement{AssertRefSnapshotID(MainBranch, nil), AssertRefSnapshotID("audit", &snapshotID)}))
+	require.NoError(t, txn.apply([]Update{NewRemovePropertiesUpdate([]string{"foo"})},
+		[]Requirement{AssertRefSnapshotID(MainBranch, nil), AssertRefSnapshotID("audit", nil)}))
+
+	planned, updates, reqs, err = txn.Plan()
+	require.NoError(t, err)
+	assert.Empty(t, planned.Properties())
+	assert.Len(t, updates, 2)
+	assert.Equal(t, []Requirement{
+		AssertTableUUID(meta.TableUUID()),
+		AssertRefSnapshotID(MainBranch, nil),
+		AssertRefSnapshotID("audit", &snapshotID),
+		AssertRefSnapshotID("audit", nil),
+	}, reqs)
+}
@@ -0,0 +1,275 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionPlan(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+	tbl := table.New([]string{"db", "tbl"}, meta, "s3://bucket/test/location/v1.metadata.json", nil)
+
+	txn, err := tbl.NewTransaction()
+	require.NoError(t, err)
+
+	require.NoError(t, txn.SetProperties(iceberg.Properties{"foo": "bar", "baz": "qux"}))
+	require.NoError(t, txn.RemoveProperties("read.split.target.size", "baz"))
+
+	planned, updates, reqs, err := txn.Plan()
+	require.NoError(t, err)
+
+	assert.Equal(t, iceberg.Properties{"foo": "bar"}, planned.Properties())
+	assert.Equal(t, 2, planned.Version())
+	assert.Equal(t, meta.TableUUID(), planned.TableUUID())
+	assert.Greater(t, planned.LastUpdatedMillis(), meta.LastUpdatedMillis())
+	assert.Equal(t, meta.Snapshots(), planned.Snapshots())
+	assert.True(t, meta.CurrentSchema().Equals(planned.CurrentSchema()))
+
+	require.Len(t, updates, 2)
+	assert.Equal(t, table.UpdateSetProperties, updates[0].Action())
+	assert.Equal(t, table.UpdateRemoveProperties, updates[1].Action())
+
+	require.Len(t, reqs, 1)
+	assert.Equal(t, table.ReqAssertTableUUID, reqs[0].Type())
+	assert.NoError(t, reqs[0].Validate(meta))
+
+	// planning doesn't modify the table or its metadata
+	assert.Equal(t, iceberg.Properties{"read.split.target.size": "134217728"}, tbl.Properties())
+
+	// planning again returns the same result
+	again, _, _, err := txn.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, planned.Properties(), again.Properties())
+}
+
+func TestTransactionPlanNoChanges(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+	tbl := table.New([]string{"db", "tbl"}, meta, "s3://bucket/test/location/v1.metadata.json", nil)
+
+	txn, err := tbl.NewTransaction()
+	require.NoError(t, err)
+
+	planned, updates, reqs, err := txn.Plan()
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+	assert.Empty(t, reqs)
+	assert.Equal(t, meta, planned)
+}
+
+func TestTransactionPlanV1(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV1)
+	require.NoError(t, err)
+	tbl := table.New([]string{"db", "tbl"}, meta, "s3://bucket/test/location/v1.metadata.json", nil)
+
+	txn, err := tbl.NewTransaction()
+	require.NoError(t, err)
+	require.NoError(t, txn.SetProperties(iceberg.Properties{"foo": "bar"}))
+
+	planned, _, _, err := txn.Plan()
+	require.NoError(t, err)
+	require.IsType(t, &table.MetadataV1{}, planned)
+
+	v1 := planned.(*table.MetadataV1)
+	assert.True(t, meta.CurrentSchema().Equals(&v1.Schema))
+	assert.Equal(t, meta.(*table.MetadataV1).Partition, v1.Partition)
+	assert.Equal(t, iceberg.Properties{"foo": "bar"}, planned.Properties())
+}
+
+func TestAssertTableUUID(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+
+	assert.NoError(t, table.AssertTableUUID(meta.TableUUID()).Validate(meta))
+	assert.ErrorIs(t, table.AssertTableUUID(uuid.New()).Validate(meta), table.ErrRequirementFailed)
+	assert.ErrorIs(t, table.AssertTableUUID(uuid.New()).Validate(nil), table.ErrRequirementFailed)
+}
+
+func TestValidateRequirements(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+
+	main, test, missing := int64(3055729675574597004), int64(3051729675574597004), int64(1)
+	assert.NoError(t, table.ValidateRequirements(meta, []table.Requirement{
+		table.AssertTableUUID(meta.TableUUID()),
+		table.AssertRefSnapshotID("main", &main),
+		table.AssertRefSnapshotID("test", &test),
+		table.AssertRefSnapshotID("new-branch", nil),
+		table.AssertLastAssignedFieldID(3),
+		table.AssertCurrentSchemaID(1),
+		table.AssertLastAssignedPartitionID(1000),
+		table.AssertDefaultSpecID(0),
+		table.AssertDefaultSortOrderID(3),
+	}))
+	assert.NoError(t, table.ValidateRequirements(nil, []table.Requirement{table.AssertCreate()}))
+
+	tests := []struct {
+		req       table.Requirement
+		expected  any
+		actual    any
+		retryable bool
+	}{
+		{table.AssertCreate(), "no table", meta.TableUUID(), false},
+		{table.AssertRefSnapshotID("main", &test), test, main, true},
+		{table.AssertRefSnapshotID("main", nil), "no ref", main, true},
+		{table.AssertRefSnapshotID("new-branch", &missing), missing, nil, true},
+		{table.AssertLastAssignedFieldID(4), 4, 3, true},
+		{table.AssertCurrentSchemaID(0), 0, 1, true},
+		{table.AssertLastAssignedPartitionID(999), 999, 1000, true},
+		{table.AssertDefaultSpecID(1), 1, 0, true},
+		{table.AssertDefaultSortOrderID(0), 0, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.req.Type(), func(t *testing.T) {
+			err := table.ValidateRequirements(meta, []table.Requirement{tt.req})
+			assert.ErrorIs(t, err, table.ErrRequirementFailed)
+			assert.ErrorContains(t, err, tt.req.Type())
+
+			var reqErr *table.RequirementError
+			require.ErrorAs(t, err, &reqErr)
+			assert.Equal(t, tt.req.Type(), reqErr.Requirement)
+			assert.Equal(t, tt.expected, reqErr.Expected)
+			assert.Equal(t, tt.actual, reqErr.Actual)
+			assert.Equal(t, tt.retryable, reqErr.Retryable())
+		})
+	}
+
+	err = table.ValidateRequirements(nil, []table.Requirement{table.AssertCurrentSchemaID(1)})
+	assert.ErrorIs(t, err, table.ErrRequirementFailed)
+	assert.ErrorContains(t, err, "table does not exist")
+
+	err = table.ValidateRequirements(meta, []table.Requirement{
+		table.AssertTableUUID(uuid.New()), table.AssertCurrentSchemaID(0)})
+	var reqErr *table.RequirementError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, table.ReqAssertTableUUID, reqErr.Requirement)
+	assert.False(t, reqErr.Retryable())
+}
+
+func TestTransactionClock(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tbl := table.New([]string{"db", "tbl"}, meta, "s3://bucket/test/location/v1.metadata.json", nil).
+		WithClock(table.ClockFunc(func() time.Time { return fixed }))
+
+	txn, err := tbl.NewTransaction()
+	require.NoError(t, err)
+	require.NoError(t, txn.SetProperties(iceberg.Properties{"foo": "bar"}))
+
+	planned, _, _, err := txn.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, fixed.UnixMilli(), planned.LastUpdatedMillis())
+
+	// setting the main branch to an existing snapshot logs it at the
+	// current time of the clock
+	b, err := table.MetadataBuilderFromBase(meta)
+	require.NoError(t, err)
+	_, err = b.SetClock(table.ClockFunc(func() time.Time { return fixed })).
+		SetSnapshotRef(table.MainBranch, 3051729675574597004, table.BranchRef)
+	require.NoError(t, err)
+
+	built, err := b.Build()
+	require.NoError(t, err)
+	assert.Equal(t, fixed.UnixMilli(), built.LastUpdatedMillis())
+	logs := built.SnapshotLogs()
+	assert.Equal(t, table.SnapshotLogEntry{SnapshotID: 3051729675574597004,
+		TimestampMs: fixed.UnixMilli()}, logs[len(logs)-1])
+}
+
+// memCatalog commits updates by applying them to the metadata it holds,
+// after validating the requirements, like a catalog server would.
+type memCatalog struct {
+	meta     table.Metadata
+	location string
+	updates  []table.Update
+}
+
+func (c *memCatalog) LoadTable(ctx context.Context, ident table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	return table.New(ident, c.meta, c.location, nil).WithCatalog(c, ident, props), nil
+}
+
+func (c *memCatalog) CommitTable(ctx context.Context, ident table.Identifier, reqs []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	for _, r := range reqs {
+		if err := r.Validate(c.meta); err != nil {
+			return nil, "", err
+		}
+	}
+
+	meta, err := table.ApplyUpdates(c.meta, updates)
+	if err != nil {
+		return nil, "", err
+	}
+	c.meta, c.location, c.updates = meta, c.location+".next", updates
+	return c.meta, c.location, nil
+}
+
+func TestUpdateStatisticsCommit(t *testing.T) {
+	meta, err := table.ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+	cat := &memCatalog{meta: meta, location: "s3://bucket/test/location/v1.metadata.json"}
+	tbl, err := cat.LoadTable(context.Background(), table.Identifier{"db", "tbl"}, nil)
+	require.NoError(t, err)
+
+	stats := table.StatisticsFile{
+		SnapshotID:      3055729675574597004,
+		StatisticsPath:  "s3://bucket/test/location/metadata/stats.puffin",
+		FileSizeInBytes: 413, FileFooterSizeInBytes: 42,
+		BlobMetadata: []table.BlobMetadata{{Type: "apache-datasketches-theta-v1",
+			SnapshotID: 3055729675574597004, SequenceNumber: 1, Fields: []int{1}}},
+	}
+	partStats := table.PartitionStatisticsFile{SnapshotID: 3055729675574597004,
+		StatisticsPath: "s3://bucket/test/location/metadata/partition-stats.parquet", FileSizeInBytes: 43}
+
+	updated, err := tbl.UpdateStatistics().
+		Set(stats).
+		SetPartitionStatistics(partStats).
+		Commit(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []table.StatisticsFile{stats}, updated.Metadata().Statistics())
+	assert.Equal(t, []table.PartitionStatisticsFile{partStats}, updated.Metadata().PartitionStatistics())
+	assert.Equal(t, "s3://bucket/test/location/v1.metadata.json.next", updated.MetadataLocation())
+	require.Len(t, cat.updates, 2)
+	assert.Equal(t, table.UpdateSetStatistics, cat.updates[0].Action())
+	assert.Equal(t, table.UpdateSetPartitionStatistics, cat.updates[1].Action())
+
+	updated, err = updated.UpdateStatistics().
+		Remove(stats.SnapshotID).
+		RemovePartitionStatistics(partStats.SnapshotID).
+		Commit(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, updated.Metadata().Statistics())
+	assert.Empty(t, updated.Metadata().PartitionStatistics())
+
+	// tables which weren't loaded from a catalog can't be committed to
+	_, err = table.New(tbl.Identifier(), meta, "", nil).UpdateStatistics().
+		Set(stats).Commit(context.Background())
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
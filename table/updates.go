@@ -0,0 +1,551 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	UpdateAddSchema            = "add-schema"
+	UpdateSetCurrentSchema     = "set-current-schema"
+	UpdateAddPartitionSpec     = "add-spec"
+	UpdateSetDefaultSpec       = "set-default-spec"
+	UpdateAddSortOrder         = "add-sort-order"
+	UpdateSetDefaultSortOrder  = "set-default-sort-order"
+	UpdateAddSnapshot          = "add-snapshot"
+	UpdateSetSnapshotRef       = "set-snapshot-ref"
+	UpdateRemoveSnapshots      = "remove-snapshots"
+	UpdateRemoveSnapshotRef    = "remove-snapshot-ref"
+	UpdateSetProperties        = "set-properties"
+	UpdateRemoveProperties     = "remove-properties"
+	UpdateSetLocation          = "set-location"
+	UpdateAssignUUID           = "assign-uuid"
+	UpdateUpgradeFormatVersion = "upgrade-format-version"
+
+	UpdateSetStatistics             = "set-statistics"
+	UpdateRemoveStatistics          = "remove-statistics"
+	UpdateSetPartitionStatistics    = "set-partition-statistics"
+	UpdateRemovePartitionStatistics = "remove-partition-statistics"
+)
+
+// Update represents a change to table metadata, such as setting a table
+// property or adding a schema. Updates are applied to a MetadataBuilder
+// and are also what gets sent to a catalog when committing changes.
+type Update interface {
+	// Action returns the name of the action that the update represents.
+	Action() string
+	// Apply applies the update to the given metadata builder.
+	Apply(*MetadataBuilder) error
+}
+
+// Updates is a list of metadata updates which can be marshaled to and
+// unmarshaled from JSON, such as the updates in the request body of a
+// REST catalog commit. Each update is tagged by its action.
+type Updates []Update
+
+func (u *Updates) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	out := make(Updates, len(raw))
+	for i, r := range raw {
+		upd, err := unmarshalUpdate(r)
+		if err != nil {
+			return err
+		}
+		out[i] = upd
+	}
+
+	*u = out
+	return nil
+}
+
+func unmarshalUpdate(b []byte) (Update, error) {
+	var base baseUpdate
+	if err := json.Unmarshal(b, &base); err != nil {
+		return nil, err
+	}
+
+	var upd Update
+	switch base.ActionName {
+	case UpdateAddSchema:
+		upd = &addSchemaUpdate{}
+	case UpdateSetCurrentSchema:
+		upd = &setCurrentSchemaUpdate{}
+	case UpdateAddPartitionSpec:
+		upd = &addPartitionSpecUpdate{}
+	case UpdateSetDefaultSpec:
+		upd = &setDefaultSpecUpdate{}
+	case UpdateAddSortOrder:
+		upd = &addSortOrderUpdate{}
+	case UpdateSetDefaultSortOrder:
+		upd = &setDefaultSortOrderUpdate{}
+	case UpdateAddSnapshot:
+		upd = &addSnapshotUpdate{}
+	case UpdateSetSnapshotRef:
+		upd = &setSnapshotRefUpdate{}
+	case UpdateRemoveSnapshots:
+		upd = &removeSnapshotsUpdate{}
+	case UpdateRemoveSnapshotRef:
+		upd = &removeSnapshotRefUpdate{}
+	case UpdateSetProperties:
+		upd = &setPropertiesUpdate{}
+	case UpdateRemoveProperties:
+		upd = &removePropertiesUpdate{}
+	case UpdateSetLocation:
+		upd = &setLocationUpdate{}
+	case UpdateAssignUUID:
+		upd = &assignUUIDUpdate{}
+	case UpdateUpgradeFormatVersion:
+		upd = &upgradeFormatVersionUpdate{}
+	case UpdateSetStatistics:
+		upd = &setStatisticsUpdate{}
+	case UpdateRemoveStatistics:
+		upd = &removeStatisticsUpdate{}
+	case UpdateSetPartitionStatistics:
+		upd = &setPartitionStatisticsUpdate{}
+	case UpdateRemovePartitionStatistics:
+		upd = &removePartitionStatisticsUpdate{}
+	case "":
+		return nil, fmt.Errorf("%w: missing action", ErrInvalidUpdate)
+	default:
+		return nil, fmt.Errorf("%w: unknown action '%s'", ErrInvalidUpdate, base.ActionName)
+	}
+
+	if err := json.Unmarshal(b, upd); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidUpdate, base.ActionName, err)
+	}
+	return upd, nil
+}
+
+type baseUpdate struct {
+	ActionName string `json:"action"`
+}
+
+func (u *baseUpdate) Action() string { return u.ActionName }
+
+type addSchemaUpdate struct {
+	baseUpdate
+	Schema       *iceberg.Schema `json:"schema"`
+	LastColumnID int             `json:"last-column-id"`
+}
+
+// NewAddSchemaUpdate creates a new update that adds the given schema to
+// the table and sets the table's last-column-id.
+func NewAddSchemaUpdate(schema *iceberg.Schema, lastColumnID int) Update {
+	return &addSchemaUpdate{
+		baseUpdate:   baseUpdate{ActionName: UpdateAddSchema},
+		Schema:       schema,
+		LastColumnID: lastColumnID,
+	}
+}
+
+func (u *addSchemaUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.AddSchema(u.Schema, u.LastColumnID)
+	return err
+}
+
+type setCurrentSchemaUpdate struct {
+	baseUpdate
+	SchemaID int `json:"schema-id"`
+}
+
+// NewSetCurrentSchemaUpdate creates a new update that sets the current
+// schema of the table. A schema ID of -1 refers to the last added schema.
+func NewSetCurrentSchemaUpdate(id int) Update {
+	return &setCurrentSchemaUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateSetCurrentSchema},
+		SchemaID:   id,
+	}
+}
+
+func (u *setCurrentSchemaUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetCurrentSchemaID(u.SchemaID)
+	return err
+}
+
+type addPartitionSpecUpdate struct {
+	baseUpdate
+	Spec *iceberg.PartitionSpec `json:"spec"`
+}
+
+// NewAddPartitionSpecUpdate creates a new update that adds the given
+// partition spec to the table.
+func NewAddPartitionSpecUpdate(spec *iceberg.PartitionSpec) Update {
+	return &addPartitionSpecUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateAddPartitionSpec},
+		Spec:       spec,
+	}
+}
+
+func (u *addPartitionSpecUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.AddPartitionSpec(u.Spec)
+	return err
+}
+
+type setDefaultSpecUpdate struct {
+	baseUpdate
+	SpecID int `json:"spec-id"`
+}
+
+// NewSetDefaultSpecUpdate creates a new update that sets the default
+// partition spec of the table. A spec ID of -1 refers to the last added
+// spec.
+func NewSetDefaultSpecUpdate(id int) Update {
+	return &setDefaultSpecUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateSetDefaultSpec},
+		SpecID:     id,
+	}
+}
+
+func (u *setDefaultSpecUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetDefaultSpecID(u.SpecID)
+	return err
+}
+
+type addSortOrderUpdate struct {
+	baseUpdate
+	SortOrder *SortOrder `json:"sort-order"`
+}
+
+// NewAddSortOrderUpdate creates a new update that adds the given sort
+// order to the table.
+func NewAddSortOrderUpdate(order *SortOrder) Update {
+	return &addSortOrderUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateAddSortOrder},
+		SortOrder:  order,
+	}
+}
+
+func (u *addSortOrderUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.AddSortOrder(u.SortOrder)
+	return err
+}
+
+type setDefaultSortOrderUpdate struct {
+	baseUpdate
+	SortOrderID int `json:"sort-order-id"`
+}
+
+// NewSetDefaultSortOrderUpdate creates a new update that sets the default
+// sort order of the table. An order ID of -1 refers to the last added
+// sort order.
+func NewSetDefaultSortOrderUpdate(id int) Update {
+	return &setDefaultSortOrderUpdate{
+		baseUpdate:  baseUpdate{ActionName: UpdateSetDefaultSortOrder},
+		SortOrderID: id,
+	}
+}
+
+func (u *setDefaultSortOrderUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetDefaultSortOrderID(u.SortOrderID)
+	return err
+}
+
+type addSnapshotUpdate struct {
+	baseUpdate
+	Snapshot *Snapshot `json:"snapshot"`
+}
+
+// NewAddSnapshotUpdate creates a new update that adds the given snapshot
+// to the table.
+func NewAddSnapshotUpdate(snapshot *Snapshot) Update {
+	return &addSnapshotUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateAddSnapshot},
+		Snapshot:   snapshot,
+	}
+}
+
+func (u *addSnapshotUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.AddSnapshot(u.Snapshot)
+	return err
+}
+
+type setSnapshotRefUpdate struct {
+	baseUpdate
+	RefName string `json:"ref-name"`
+	SnapshotRef
+}
+
+// NewSetSnapshotRefUpdate creates a new update that creates or updates the
+// named ref of the table.
+func NewSetSnapshotRefUpdate(name string, ref SnapshotRef) Update {
+	return &setSnapshotRefUpdate{
+		baseUpdate:  baseUpdate{ActionName: UpdateSetSnapshotRef},
+		RefName:     name,
+		SnapshotRef: ref,
+	}
+}
+
+// UnmarshalJSON is needed as the embedded SnapshotRef would otherwise
+// promote its own UnmarshalJSON, dropping the action and ref name.
+func (u *setSnapshotRefUpdate) UnmarshalJSON(b []byte) error {
+	aux := struct {
+		ActionName string `json:"action"`
+		RefName    string `json:"ref-name"`
+	}{}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, &u.SnapshotRef); err != nil {
+		return err
+	}
+
+	u.ActionName, u.RefName = aux.ActionName, aux.RefName
+	return nil
+}
+
+func (u *setSnapshotRefUpdate) Apply(builder *MetadataBuilder) error {
+	var opts []setSnapshotRefOption
+	if u.MaxRefAgeMs != nil {
+		opts = append(opts, WithMaxRefAgeMs(*u.MaxRefAgeMs))
+	}
+	if u.MaxSnapshotAgeMs != nil {
+		opts = append(opts, WithMaxSnapshotAgeMs(*u.MaxSnapshotAgeMs))
+	}
+	if u.MinSnapshotsToKeep != nil {
+		opts = append(opts, WithMinSnapshotsToKeep(*u.MinSnapshotsToKeep))
+	}
+
+	_, err := builder.SetSnapshotRef(u.RefName, u.SnapshotID, u.SnapshotRefType, opts...)
+	return err
+}
+
+type removeSnapshotsUpdate struct {
+	baseUpdate
+	SnapshotIDs []int64 `json:"snapshot-ids"`
+}
+
+// NewRemoveSnapshotsUpdate creates a new update that removes the snapshots
+// with the given IDs from the table.
+func NewRemoveSnapshotsUpdate(ids []int64) Update {
+	return &removeSnapshotsUpdate{
+		baseUpdate:  baseUpdate{ActionName: UpdateRemoveSnapshots},
+		SnapshotIDs: slices.Clone(ids),
+	}
+}
+
+func (u *removeSnapshotsUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.RemoveSnapshots(u.SnapshotIDs)
+	return err
+}
+
+type removeSnapshotRefUpdate struct {
+	baseUpdate
+	RefName string `json:"ref-name"`
+}
+
+// NewRemoveSnapshotRefUpdate creates a new update that removes the named
+// ref from the table.
+func NewRemoveSnapshotRefUpdate(name string) Update {
+	return &removeSnapshotRefUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateRemoveSnapshotRef},
+		RefName:    name,
+	}
+}
+
+func (u *removeSnapshotRefUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.RemoveSnapshotRef(u.RefName)
+	return err
+}
+
+type setPropertiesUpdate struct {
+	baseUpdate
+	Updates iceberg.Properties `json:"updates"`
+}
+
+// NewSetPropertiesUpdate creates a new update that adds or replaces the
+// given table properties.
+func NewSetPropertiesUpdate(updates iceberg.Properties) Update {
+	return &setPropertiesUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateSetProperties},
+		Updates:    maps.Clone(updates),
+	}
+}
+
+func (u *setPropertiesUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetProperties(u.Updates)
+	return err
+}
+
+type removePropertiesUpdate struct {
+	baseUpdate
+	Removals []string `json:"removals"`
+}
+
+// NewRemovePropertiesUpdate creates a new update that removes the given
+// keys from the table properties.
+func NewRemovePropertiesUpdate(keys []string) Update {
+	return &removePropertiesUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateRemoveProperties},
+		Removals:   slices.Clone(keys),
+	}
+}
+
+func (u *removePropertiesUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.RemoveProperties(u.Removals)
+	return err
+}
+
+type setLocationUpdate struct {
+	baseUpdate
+	Location string `json:"location"`
+}
+
+// NewSetLocationUpdate creates a new update that changes the base location
+// of the table.
+func NewSetLocationUpdate(loc string) Update {
+	return &setLocationUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateSetLocation},
+		Location:   loc,
+	}
+}
+
+func (u *setLocationUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetLocation(u.Location)
+	return err
+}
+
+type assignUUIDUpdate struct {
+	baseUpdate
+	UUID uuid.UUID `json:"uuid"`
+}
+
+// NewAssignUUIDUpdate creates a new update that assigns the UUID of the
+// table.
+func NewAssignUUIDUpdate(id uuid.UUID) Update {
+	return &assignUUIDUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateAssignUUID},
+		UUID:       id,
+	}
+}
+
+func (u *assignUUIDUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetUUID(u.UUID)
+	return err
+}
+
+type upgradeFormatVersionUpdate struct {
+	baseUpdate
+	FormatVersion int `json:"format-version"`
+}
+
+// NewUpgradeFormatVersionUpdate creates a new update that upgrades the
+// format version of the table.
+func NewUpgradeFormatVersionUpdate(formatVersion int) Update {
+	return &upgradeFormatVersionUpdate{
+		baseUpdate:    baseUpdate{ActionName: UpdateUpgradeFormatVersion},
+		FormatVersion: formatVersion,
+	}
+}
+
+func (u *upgradeFormatVersionUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetFormatVersion(u.FormatVersion)
+	return err
+}
+
+type setStatisticsUpdate struct {
+	baseUpdate
+	// SnapshotID is deprecated in the REST spec, but still sent for
+	// compatibility with older catalogs.
+	SnapshotID int64          `json:"snapshot-id"`
+	Statistics StatisticsFile `json:"statistics"`
+}
+
+// NewSetStatisticsUpdate creates a new update that adds the statistics file
+// for its snapshot, replacing any existing one for the same snapshot.
+func NewSetStatisticsUpdate(stats StatisticsFile) Update {
+	return &setStatisticsUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateSetStatistics},
+		SnapshotID: stats.SnapshotID,
+		Statistics: stats,
+	}
+}
+
+func (u *setStatisticsUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetStatistics(u.Statistics)
+	return err
+}
+
+type removeStatisticsUpdate struct {
+	baseUpdate
+	SnapshotID int64 `json:"snapshot-id"`
+}
+
+// NewRemoveStatisticsUpdate creates a new update that removes the
+// statistics file for the snapshot.
+func NewRemoveStatisticsUpdate(snapshotID int64) Update {
+	return &removeStatisticsUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateRemoveStatistics},
+		SnapshotID: snapshotID,
+	}
+}
+
+func (u *removeStatisticsUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.RemoveStatistics(u.SnapshotID)
+	return err
+}
+
+type setPartitionStatisticsUpdate struct {
+	baseUpdate
+	Statistics PartitionStatisticsFile `json:"partition-statistics"`
+}
+
+// NewSetPartitionStatisticsUpdate creates a new update that adds the
+// partition statistics file for its snapshot, replacing any existing one
+// for the same snapshot.
+func NewSetPartitionStatisticsUpdate(stats PartitionStatisticsFile) Update {
+	return &setPartitionStatisticsUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateSetPartitionStatistics},
+		Statistics: stats,
+	}
+}
+
+func (u *setPartitionStatisticsUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.SetPartitionStatistics(u.Statistics)
+	return err
+}
+
+type removePartitionStatisticsUpdate struct {
+	baseUpdate
+	SnapshotID int64 `json:"snapshot-id"`
+}
+
+// NewRemovePartitionStatisticsUpdate creates a new update that removes the
+// partition statistics file for the snapshot.
+func NewRemovePartitionStatisticsUpdate(snapshotID int64) Update {
+	return &removePartitionStatisticsUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateRemovePartitionStatistics},
+		SnapshotID: snapshotID,
+	}
+}
+
+func (u *removePartitionStatisticsUpdate) Apply(builder *MetadataBuilder) error {
+	_, err := builder.RemovePartitionStatistics(u.SnapshotID)
+	return err
+}
@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatesJSONRoundTrip(t *testing.T) {
+	schema := iceberg.NewSchema(2,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	spec := iceberg.NewPartitionSpecID(1,
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "x", Transform: iceberg.IdentityTransform{}})
+	order := &table.SortOrder{OrderID: 1, Fields: []table.SortField{
+		{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst}}}
+	parent, maxAge := int64(1), int64(1000)
+
+	updates := table.Updates{
+		table.NewAddSchemaUpdate(schema, 1),
+		table.NewSetCurrentSchemaUpdate(-1),
+		table.NewAddPartitionSpecUpdate(&spec),
+		table.NewSetDefaultSpecUpdate(-1),
+		table.NewAddSortOrderUpdate(order),
+		table.NewSetDefaultSortOrderUpdate(-1),
+		table.NewAddSnapshotUpdate(&table.Snapshot{SnapshotID: 2, ParentSnapshotID: &parent,
+			SequenceNumber: 3, TimestampMs: 1555100955770, ManifestList: "s3://a/b/2.avro",
+			Summary: &table.Summary{Operation: table.OpAppend}}),
+		table.NewSetSnapshotRefUpdate("main", table.SnapshotRef{SnapshotID: 2,
+			SnapshotRefType: table.BranchRef, MaxRefAgeMs: &maxAge}),
+		table.NewRemoveSnapshotsUpdate([]int64{1}),
+		table.NewRemoveSnapshotRefUpdate("test"),
+		table.NewSetPropertiesUpdate(iceberg.Properties{"foo": "bar"}),
+		table.NewRemovePropertiesUpdate([]string{"baz"}),
+		table.NewSetLocationUpdate("s3://bucket/new"),
+		table.NewAssignUUIDUpdate(uuid.MustParse("9c12d441-03fe-4693-9a96-a0705ddf69c1")),
+		table.NewUpgradeFormatVersionUpdate(2),
+		table.NewSetStatisticsUpdate(table.StatisticsFile{SnapshotID: 2,
+			StatisticsPath: "s3://a/b/stats.puffin", FileSizeInBytes: 413, FileFooterSizeInBytes: 42,
+			BlobMetadata: []table.BlobMetadata{{Type: "apache-datasketches-theta-v1",
+				SnapshotID: 2, SequenceNumber: 3, Fields: []int{1}}}}),
+		table.NewRemoveStatisticsUpdate(1),
+		table.NewSetPartitionStatisticsUpdate(table.PartitionStatisticsFile{SnapshotID: 2,
+			StatisticsPath: "s3://a/b/partition-stats.parquet", FileSizeInBytes: 43}),
+		table.NewRemovePartitionStatisticsUpdate(1),
+	}
+
+	data, err := json.Marshal(updates)
+	require.NoError(t, err)
+
+	var raw []map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	require.Len(t, raw, len(updates))
+	for i, u := range updates {
+		assert.Equal(t, u.Action(), raw[i]["action"])
+	}
+	assert.Equal(t, "main", raw[7]["ref-name"])
+	assert.Equal(t, "branch", raw[7]["type"])
+	assert.EqualValues(t, 2, raw[7]["snapshot-id"])
+
+	var decoded table.Updates
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded, len(updates))
+	for i, u := range decoded {
+		assert.Equal(t, updates[i].Action(), u.Action())
+	}
+	assert.Equal(t, updates[7], decoded[7])
+	assert.Equal(t, updates[14], decoded[14])
+	assert.Equal(t, updates[15], decoded[15])
+	assert.Equal(t, updates[17], decoded[17])
+	assert.EqualValues(t, 2, raw[15]["snapshot-id"])
+	assert.Equal(t, "s3://a/b/stats.puffin", raw[15]["statistics"].(map[string]any)["statistics-path"])
+	assert.Equal(t, "s3://a/b/partition-stats.parquet",
+		raw[17]["partition-statistics"].(map[string]any)["statistics-path"])
+
+	again, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(again))
+
+	// the decoded updates can be applied just like the originals
+	meta, err := table.ApplyUpdates(exampleMetadataV2(t), decoded[10:12])
+	require.NoError(t, err)
+	assert.Equal(t, "bar", meta.Properties()["foo"])
+}
+
+func TestUpdatesJSONInvalid(t *testing.T) {
+	var updates table.Updates
+	err := json.Unmarshal([]byte(`[{"action": "set-location", "location": "s3://a"}, {"action": "drop-table"}]`), &updates)
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+	assert.ErrorContains(t, err, "drop-table")
+
+	err = json.Unmarshal([]byte(`[{"location": "s3://a"}]`), &updates)
+	assert.ErrorIs(t, err, table.ErrInvalidUpdate)
+
+	err = json.Unmarshal([]byte(`[{"action": "set-snapshot-ref", "ref-name": "main", "snapshot-id": 1, "type": "foo"}]`), &updates)
+	assert.ErrorIs(t, err, table.ErrInvalidRefType)
+}
+
+func TestRequirementsJSONRoundTrip(t *testing.T) {
+	id := int64(3055729675574597004)
+	reqs := table.Requirements{
+		table.AssertCreate(),
+		table.AssertTableUUID(uuid.MustParse("9c12d441-03fe-4693-9a96-a0705ddf69c1")),
+		table.AssertRefSnapshotID("main", &id),
+		table.AssertRefSnapshotID("branch", nil),
+		table.AssertLastAssignedFieldID(3),
+		table.AssertCurrentSchemaID(1),
+		table.AssertLastAssignedPartitionID(1000),
+		table.AssertDefaultSpecID(0),
+		table.AssertDefaultSortOrderID(3),
+	}
+
+	data, err := json.Marshal(reqs)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"type": "assert-create"},
+		{"type": "assert-table-uuid", "uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1"},
+		{"type": "assert-ref-snapshot-id", "ref": "main", "snapshot-id": 3055729675574597004},
+		{"type": "assert-ref-snapshot-id", "ref": "branch", "snapshot-id": null},
+		{"type": "assert-last-assigned-field-id", "last-assigned-field-id": 3},
+		{"type": "assert-current-schema-id", "current-schema-id": 1},
+		{"type": "assert-last-assigned-partition-id", "last-assigned-partition-id": 1000},
+		{"type": "assert-default-spec-id", "default-spec-id": 0},
+		{"type": "assert-default-sort-order-id", "default-sort-order-id": 3}
+	]`, string(data))
+
+	var decoded table.Requirements
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, reqs, decoded)
+
+	err = json.Unmarshal([]byte(`[{"type": "assert-view-uuid", "uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1"}]`), &decoded)
+	assert.ErrorIs(t, err, table.ErrInvalidRequirement)
+	assert.ErrorContains(t, err, "assert-view-uuid")
+}
@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+)
+
+// ViewRepresentationSQL is the type of a view representation which
+// defines the view with a SQL query.
+const ViewRepresentationSQL = "sql"
+
+// ViewRepresentation is one definition of a view, such as the SQL query
+// for a particular SQL dialect.
+type ViewRepresentation struct {
+	Type    string `json:"type"`
+	SQL     string `json:"sql"`
+	Dialect string `json:"dialect"`
+}
+
+// ViewVersion is a version of a view's definition. A version may have
+// several representations, such as the same query in different SQL
+// dialects.
+type ViewVersion struct {
+	VersionID       int64                `json:"version-id"`
+	TimestampMs     int64                `json:"timestamp-ms"`
+	SchemaID        int                  `json:"schema-id"`
+	Summary         map[string]string    `json:"summary"`
+	Representations []ViewRepresentation `json:"representations"`
+	// DefaultCatalog is the catalog used to resolve unqualified table
+	// names in the query, empty means the catalog of the view.
+	DefaultCatalog string `json:"default-catalog,omitempty"`
+	// DefaultNamespace is the namespace used to resolve unqualified
+	// table names in the query.
+	DefaultNamespace Identifier `json:"default-namespace"`
+}
+
+// Representation returns the SQL representation for the dialect, or nil
+// if the version doesn't have one. Dialects are matched case-insensitively.
+func (v *ViewVersion) Representation(dialect string) *ViewRepresentation {
+	for i, r := range v.Representations {
+		if r.Type == ViewRepresentationSQL && strings.EqualFold(r.Dialect, dialect) {
+			return &v.Representations[i]
+		}
+	}
+	return nil
+}
+
+// ViewVersionLogEntry records when a version became the current version
+// of a view.
+type ViewVersionLogEntry struct {
+	TimestampMs int64 `json:"timestamp-ms"`
+	VersionID   int64 `json:"version-id"`
+}
+
+// ViewMetadata is the metadata of an iceberg view, as described by the
+// view spec.
+type ViewMetadata struct {
+	FormatVersion    int                   `json:"format-version"`
+	UUID             uuid.UUID             `json:"view-uuid"`
+	Location         string                `json:"location"`
+	CurrentVersionID int64                 `json:"current-version-id"`
+	Versions         []ViewVersion         `json:"versions"`
+	VersionLog       []ViewVersionLogEntry `json:"version-log"`
+	Schemas          []*iceberg.Schema     `json:"schemas"`
+	Properties       iceberg.Properties    `json:"properties,omitempty"`
+}
+
+func (m *ViewMetadata) UnmarshalJSON(b []byte) error {
+	type Alias ViewMetadata
+	if err := json.Unmarshal(b, (*Alias)(m)); err != nil {
+		return err
+	}
+	return m.validate()
+}
+
+func (m *ViewMetadata) validate() error {
+	if m.FormatVersion != 1 {
+		return fmt.Errorf("%w: unsupported view format version %d",
+			ErrInvalidMetadataFormatVersion, m.FormatVersion)
+	}
+
+	if m.CurrentVersion() == nil {
+		return fmt.Errorf("%w: current-version-id %d can't be found in any version",
+			ErrInvalidMetadata, m.CurrentVersionID)
+	}
+
+	for _, v := range m.Versions {
+		if m.SchemaByID(v.SchemaID) == nil {
+			return fmt.Errorf("%w: schema-id %d of view version %d can't be found in any schema",
+				ErrInvalidMetadata, v.SchemaID, v.VersionID)
+		}
+
+		dialects := make(map[string]struct{}, len(v.Representations))
+		for _, r := range v.Representations {
+			if r.Type != ViewRepresentationSQL {
+				continue
+			}
+			if _, ok := dialects[r.Dialect]; ok {
+				return fmt.Errorf("%w: view version %d has more than one representation for dialect %s",
+					ErrInvalidMetadata, v.VersionID, r.Dialect)
+			}
+			dialects[r.Dialect] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// CurrentVersion returns the current version of the view.
+func (m *ViewMetadata) CurrentVersion() *ViewVersion { return m.Version(m.CurrentVersionID) }
+
+// Version returns the version with the given ID, or nil if there isn't one.
+func (m *ViewMetadata) Version(id int64) *ViewVersion {
+	idx := slices.IndexFunc(m.Versions, func(v ViewVersion) bool { return v.VersionID == id })
+	if idx < 0 {
+		return nil
+	}
+	return &m.Versions[idx]
+}
+
+// SchemaByID returns the schema with the given ID, or nil if there isn't one.
+func (m *ViewMetadata) SchemaByID(id int) *iceberg.Schema {
+	for _, s := range m.Schemas {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// ParseViewMetadataBytes parses and validates the JSON metadata of a view.
+func ParseViewMetadataBytes(b []byte) (*ViewMetadata, error) {
+	var meta ViewMetadata
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// View is an iceberg view read directly from its metadata file. Views
+// read this way aren't associated with a catalog, so they are read-only.
+type View struct {
+	metadata         *ViewMetadata
+	metadataLocation string
+	fs               io.IO
+}
+
+func (v View) Metadata() *ViewMetadata        { return v.metadata }
+func (v View) MetadataLocation() string       { return v.metadataLocation }
+func (v View) FS() io.IO                      { return v.fs }
+func (v View) Location() string               { return v.metadata.Location }
+func (v View) Properties() iceberg.Properties { return v.metadata.Properties }
+func (v View) CurrentVersion() *ViewVersion   { return v.metadata.CurrentVersion() }
+
+// Schema returns the schema of the current version of the view.
+func (v View) Schema() *iceberg.Schema {
+	return v.metadata.SchemaByID(v.CurrentVersion().SchemaID)
+}
+
+// ReadViewMetadata reads the view metadata file at the location using the
+// given IO, without the need for a catalog which supports views.
+func ReadViewMetadata(location string, fsys io.IO) (*View, error) {
+	var meta *ViewMetadata
+	if rf, ok := fsys.(io.ReadFileIO); ok {
+		data, err := rf.ReadFile(location)
+		if err != nil {
+			return nil, err
+		}
+
+		if meta, err = ParseViewMetadataBytes(data); err != nil {
+			return nil, err
+		}
+	} else {
+		f, err := fsys.Open(location)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		meta = &ViewMetadata{}
+		if err = json.NewDecoder(f).Decode(meta); err != nil {
+			return nil, err
+		}
+	}
+	return &View{metadata: meta, metadataLocation: location, fs: fsys}, nil
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/internal"
+	"github.com/apache/iceberg-go/table"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ExampleViewMetadata = `{
+	"view-uuid": "fa6506c3-7681-40c8-86dc-e36561f83385",
+	"format-version": 1,
+	"location": "s3://bucket/warehouse/default.db/event_agg",
+	"current-version-id": 2,
+	"properties": {"comment": "Daily event counts"},
+	"versions": [
+		{
+			"version-id": 1,
+			"timestamp-ms": 1573518431292,
+			"schema-id": 1,
+			"default-catalog": "prod",
+			"default-namespace": ["default"],
+			"summary": {"engine-name": "Spark", "engineVersion": "3.3.2"},
+			"representations": [
+				{"type": "sql", "sql": "SELECT COUNT(1), CAST(event_ts AS DATE) FROM events GROUP BY 2", "dialect": "spark"}
+			]
+		},
+		{
+			"version-id": 2,
+			"timestamp-ms": 1573518981593,
+			"schema-id": 1,
+			"default-catalog": "prod",
+			"default-namespace": ["default"],
+			"summary": {"engine-name": "Spark", "engineVersion": "3.3.2"},
+			"representations": [
+				{"type": "sql", "sql": "SELECT COUNT(1), CAST(event_ts AS DATE) FROM prod.default.events GROUP BY 2", "dialect": "spark"},
+				{"type": "sql", "sql": "SELECT COUNT(1), CAST(event_ts AS DATE) FROM prod.default.events GROUP BY 2", "dialect": "trino"}
+			]
+		}
+	],
+	"schemas": [
+		{
+			"schema-id": 1,
+			"type": "struct",
+			"fields": [
+				{"id": 1, "name": "event_count", "required": false, "type": "int", "doc": "Count of events"},
+				{"id": 2, "name": "event_date", "required": false, "type": "date"}
+			]
+		}
+	],
+	"version-log": [
+		{"timestamp-ms": 1573518431292, "version-id": 1},
+		{"timestamp-ms": 1573518981593, "version-id": 2}
+	]
+}`
+
+func TestReadViewMetadata(t *testing.T) {
+	var mockfs internal.MockFS
+	mockfs.Test(t)
+	mockfs.On("Open", "s3://bucket/warehouse/default.db/event_agg/metadata/00002.metadata.json").
+		Return(&internal.MockFile{Contents: bytes.NewReader([]byte(ExampleViewMetadata))}, nil)
+	defer mockfs.AssertExpectations(t)
+
+	view, err := table.ReadViewMetadata("s3://bucket/warehouse/default.db/event_agg/metadata/00002.metadata.json", &mockfs)
+	require.NoError(t, err)
+
+	meta := view.Metadata()
+	assert.Equal(t, uuid.MustParse("fa6506c3-7681-40c8-86dc-e36561f83385"), meta.UUID)
+	assert.Equal(t, "s3://bucket/warehouse/default.db/event_agg", view.Location())
+	assert.Equal(t, iceberg.Properties{"comment": "Daily event counts"}, view.Properties())
+	assert.Len(t, meta.Versions, 2)
+	assert.Equal(t, []table.ViewVersionLogEntry{
+		{TimestampMs: 1573518431292, VersionID: 1},
+		{TimestampMs: 1573518981593, VersionID: 2},
+	}, meta.VersionLog)
+
+	current := view.CurrentVersion()
+	require.NotNil(t, current)
+	assert.EqualValues(t, 2, current.VersionID)
+	assert.Equal(t, "prod", current.DefaultCatalog)
+	assert.Equal(t, table.Identifier{"default"}, current.DefaultNamespace)
+	assert.Len(t, current.Representations, 2)
+	require.NotNil(t, current.Representation("Trino"))
+	assert.Equal(t, "trino", current.Representation("Trino").Dialect)
+	assert.Nil(t, current.Representation("postgres"))
+
+	assert.Equal(t, []string{"event_count", "event_date"},
+		[]string{view.Schema().Field(0).Name, view.Schema().Field(1).Name})
+}
+
+func TestParseViewMetadataInvalid(t *testing.T) {
+	tests := []struct {
+		name, from, to string
+		err            error
+	}{
+		{"format version", `"format-version": 1`, `"format-version": 2`, table.ErrInvalidMetadataFormatVersion},
+		{"current version", `"current-version-id": 2`, `"current-version-id": 3`, table.ErrInvalidMetadata},
+		{"schema", `"schema-id": 1,
+			"default-catalog"`, `"schema-id": 5,
+			"default-catalog"`, table.ErrInvalidMetadata},
+		{"dialect", `"dialect": "trino"`, `"dialect": "spark"`, table.ErrInvalidMetadata},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(ExampleViewMetadata, tt.from, tt.to, 1)
+			require.NotEqual(t, ExampleViewMetadata, data)
+
+			_, err := table.ParseViewMetadataBytes([]byte(data))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -19,9 +19,17 @@ package iceberg
 
 import (
 	"encoding"
+	"encoding/binary"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v16/arrow/decimal128"
+	"github.com/apache/iceberg-go/internal"
+	"github.com/google/uuid"
 )
 
 // ParseTransform takes the string representation of a transform as
@@ -67,13 +75,22 @@ func ParseTransform(s string) (Transform, error) {
 }
 
 // Transform is an interface for the various Transformation types
-// in partition specs. Currently, they do not yet provide actual
-// transformation functions or implementation. That will come later as
-// data reading gets implemented.
+// in partition specs.
 type Transform interface {
 	fmt.Stringer
 	encoding.TextMarshaler
 	ResultType(t Type) Type
+	// Apply transforms a single source value into its partition value.
+	// Values use the same Go types as partition data: bool, int32, int64,
+	// float32, float64, Date, Time, Timestamp, TimestampNano, string,
+	// []byte, uuid.UUID and Decimal. A nil value is always transformed to
+	// nil, values the transform can't be applied to return an error
+	// wrapping ErrType.
+	Apply(val any) (any, error)
+}
+
+func cannotApply(t Transform, val any) error {
+	return fmt.Errorf("%w: cannot apply %s transform to %T", ErrType, t, val)
 }
 
 // IdentityTransform uses the identity function, performing no transformation
@@ -88,6 +105,8 @@ func (IdentityTransform) String() string { return "identity" }
 
 func (IdentityTransform) ResultType(t Type) Type { return t }
 
+func (IdentityTransform) Apply(val any) (any, error) { return val, nil }
+
 // VoidTransform is a transformation that always returns nil.
 type VoidTransform struct{}
 
@@ -99,6 +118,8 @@ func (VoidTransform) String() string { return "void" }
 
 func (VoidTransform) ResultType(t Type) Type { return t }
 
+func (VoidTransform) Apply(any) (any, error) { return nil, nil }
+
 // BucketTransform transforms values into a bucket partition value. It is
 // parameterized by a number of buckets. Bucket partition transforms use
 // a 32-bit hash of the source value to produce a positive value by mod
@@ -115,6 +136,60 @@ func (t BucketTransform) String() string { return fmt.Sprintf("bucket[%d]", t.Nu
 
 func (BucketTransform) ResultType(Type) Type { return PrimitiveTypes.Int32 }
 
+// Apply hashes the value with the 32-bit murmur3 hash as defined in the
+// spec, and returns the bucket for the hash as an int32.
+func (t BucketTransform) Apply(val any) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+	if t.NumBuckets <= 0 {
+		return nil, fmt.Errorf("%w: invalid number of buckets %d", ErrInvalidArgument, t.NumBuckets)
+	}
+
+	h, ok := bucketHash(val)
+	if !ok {
+		return nil, cannotApply(t, val)
+	}
+	return (h & math.MaxInt32) % int32(t.NumBuckets), nil
+}
+
+// bucketHash returns the 32-bit murmur3 hash of the value as defined in
+// the appendix of the spec: integers, dates, times and timestamps are
+// hashed as 8 byte little-endian longs, strings as their UTF-8 bytes,
+// uuids as their 16 big-endian bytes and decimals as the minimal
+// big-endian two's-complement bytes of their unscaled value. It returns
+// false for values which can't be bucketed.
+func bucketHash(val any) (int32, bool) {
+	var b []byte
+	switch v := val.(type) {
+	case int32:
+		b = binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case int64:
+		b = binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case Date:
+		b = binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case Time:
+		b = binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case Timestamp:
+		b = binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case TimestampNano:
+		// nanosecond timestamps hash the same as microsecond timestamps
+		b = binary.LittleEndian.AppendUint64(nil, uint64(floorDiv(int64(v), 1000)))
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case uuid.UUID:
+		b = v[:]
+	case Decimal:
+		b = decimalBytes(v.Val.BigInt())
+	default:
+		return 0, false
+	}
+
+	return int32(internal.Murmur3Hash32(b)), true
+}
+
 // TruncateTransform is a transformation for truncating a value to a specified width.
 type TruncateTransform struct {
 	Width int
@@ -128,6 +203,45 @@ func (t TruncateTransform) String() string { return fmt.Sprintf("truncate[%d]",
 
 func (TruncateTransform) ResultType(t Type) Type { return t }
 
+// Apply truncates integers and decimals down to a multiple of the width,
+// and strings and binary values to at most width characters or bytes.
+func (t TruncateTransform) Apply(val any) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+	if t.Width <= 0 {
+		return nil, fmt.Errorf("%w: invalid truncate width %d", ErrInvalidArgument, t.Width)
+	}
+
+	switch v := val.(type) {
+	case int32:
+		w := int32(t.Width)
+		return v - (((v % w) + w) % w), nil
+	case int64:
+		w := int64(t.Width)
+		return v - (((v % w) + w) % w), nil
+	case string:
+		n := 0
+		for i := range v {
+			if n == t.Width {
+				return v[:i], nil
+			}
+			n++
+		}
+		return v, nil
+	case []byte:
+		if len(v) > t.Width {
+			return v[:t.Width], nil
+		}
+		return v, nil
+	case Decimal:
+		unscaled, w := v.Val.BigInt(), big.NewInt(int64(t.Width))
+		rem := new(big.Int).Mod(unscaled, w)
+		return Decimal{Val: decimal128.FromBigInt(unscaled.Sub(unscaled, rem)), Scale: v.Scale}, nil
+	}
+	return nil, cannotApply(t, val)
+}
+
 // YearTransform transforms a datetime value into a year value.
 type YearTransform struct{}
 
@@ -139,6 +253,18 @@ func (YearTransform) String() string { return "year" }
 
 func (YearTransform) ResultType(Type) Type { return PrimitiveTypes.Int32 }
 
+// Apply returns the number of years since 1970 as an int32.
+func (t YearTransform) Apply(val any) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+	tm, ok := toTime(val)
+	if !ok {
+		return nil, cannotApply(t, val)
+	}
+	return int32(tm.Year() - 1970), nil
+}
+
 // MonthTransform transforms a datetime value into a month value.
 type MonthTransform struct{}
 
@@ -150,6 +276,18 @@ func (MonthTransform) String() string { return "month" }
 
 func (MonthTransform) ResultType(Type) Type { return PrimitiveTypes.Int32 }
 
+// Apply returns the number of months since 1970-01 as an int32.
+func (t MonthTransform) Apply(val any) (any, error) {
+	if val == nil {
+		return nil, nil
+	}
+	tm, ok := toTime(val)
+	if !ok {
+		return nil, cannotApply(t, val)
+	}
+	return int32((tm.Year()-1970)*12 + int(tm.Month()) - 1), nil
+}
+
 // DayTransform transforms a datetime value into a date value.
 type DayTransform struct{}
 
@@ -161,6 +299,21 @@ func (DayTransform) String() string { return "day" }
 
 func (DayTransform) ResultType(Type) Type { return PrimitiveTypes.Date }
 
+// Apply returns the date of the value.
+func (t DayTransform) Apply(val any) (any, error) {
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case Date:
+		return v, nil
+	case Timestamp:
+		return Date(floorDiv(int64(v), int64(24*time.Hour/time.Microsecond))), nil
+	case TimestampNano:
+		return Date(floorDiv(int64(v), int64(24*time.Hour))), nil
+	}
+	return nil, cannotApply(t, val)
+}
+
 // HourTransform transforms a datetime value into an hour value.
 type HourTransform struct{}
 
@@ -171,3 +324,55 @@ func (t HourTransform) MarshalText() ([]byte, error) {
 func (HourTransform) String() string { return "hour" }
 
 func (HourTransform) ResultType(Type) Type { return PrimitiveTypes.Int32 }
+
+// Apply returns the number of hours since 1970-01-01 00:00 as an int32.
+// Dates have no hour, so they can't be transformed.
+func (t HourTransform) Apply(val any) (any, error) {
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case Timestamp:
+		return int32(floorDiv(int64(v), int64(time.Hour/time.Microsecond))), nil
+	case TimestampNano:
+		return int32(floorDiv(int64(v), int64(time.Hour))), nil
+	}
+	return nil, cannotApply(t, val)
+}
+
+// toTime converts a date or timestamp value to a UTC time.
+func toTime(val any) (time.Time, bool) {
+	switch v := val.(type) {
+	case Date:
+		return time.Unix(int64(v)*int64(24*time.Hour/time.Second), 0).UTC(), true
+	case Timestamp:
+		return time.UnixMicro(int64(v)).UTC(), true
+	case TimestampNano:
+		return time.Unix(0, int64(v)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// floorDiv divides rounding towards negative infinity, so that values
+// before the epoch are assigned to the correct date or hour.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// decimalBytes returns the minimal big-endian two's-complement
+// representation of the unscaled value, like Java's
+// BigInteger.toByteArray, which is what decimals are hashed as.
+func decimalBytes(v *big.Int) []byte {
+	if v.Sign() >= 0 {
+		return v.FillBytes(make([]byte, v.BitLen()/8+1))
+	}
+
+	// -v-1 has the same bit length as v without its sign bit
+	abs := new(big.Int).Neg(v)
+	n := abs.Sub(abs, big.NewInt(1)).BitLen()/8 + 1
+	twos := new(big.Int).Lsh(big.NewInt(1), uint(n*8))
+	return twos.Add(twos, v).FillBytes(make([]byte, n))
+}
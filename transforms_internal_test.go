@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/v16/arrow/decimal128"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketHash(t *testing.T) {
+	tests := []struct {
+		val  any
+		hash int32
+	}{
+		// examples from the appendix of the spec
+		{int32(34), 2017239379},
+		{int64(34), 2017239379},
+		{Decimal{Val: decimal128.FromI64(1420), Scale: 2}, -500754589},
+		{Date(17486), -653330422},
+		{Time(81068000000), -662762989},
+		{Timestamp(1510871468000000), -2047944441},
+		{Timestamp(1510871468000001), -1207196810},
+		{TimestampNano(1510871468000001001), -1207196810},
+		{"iceberg", 1210000089},
+		{uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7"), 1488055340},
+		{[]byte{0, 1, 2, 3}, -188683207},
+		// reference vectors of murmur3 x86 32-bit with a seed of 0,
+		// covering each length of the tail
+		{"", 0},
+		{"foo", -156908512},
+		{"hello", 613153351},
+		{"The quick brown fox jumps over the lazy dog", 776992547},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T(%v)", tt.val, tt.val), func(t *testing.T) {
+			h, ok := bucketHash(tt.val)
+			assert.True(t, ok)
+			assert.Equal(t, tt.hash, h)
+		})
+	}
+
+	// ints are hashed as sign extended longs and decimals as their
+	// minimal two's-complement bytes, whatever their precision
+	for _, pair := range [][2]any{
+		{int32(-1), int64(-1)},
+		{Date(-1), int64(-1)},
+		{TimestampNano(-1), Timestamp(-1)},
+		{Decimal{Val: decimal128.FromI64(-129), Scale: 2}, []byte{0xff, 0x7f}},
+		{Decimal{Val: decimal128.FromI64(128), Scale: 0}, []byte{0x00, 0x80}},
+	} {
+		h1, ok1 := bucketHash(pair[0])
+		h2, ok2 := bucketHash(pair[1])
+		assert.True(t, ok1 && ok2)
+		assert.Equal(t, h2, h1, "%v", pair)
+	}
+
+	for _, val := range []any{true, float32(1), float64(1)} {
+		_, ok := bucketHash(val)
+		assert.False(t, ok, "%T", val)
+	}
+}
@@ -18,10 +18,14 @@
 package iceberg_test
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"testing"
 
+	"github.com/apache/arrow/go/v16/arrow/decimal128"
 	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,3 +91,107 @@ func TestParseTransform(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketTransformApply(t *testing.T) {
+	// hashes from the appendix of the iceberg spec
+	tests := []struct {
+		val  any
+		hash int32
+	}{
+		{int32(34), 2017239379},
+		{int64(34), 2017239379},
+		{iceberg.Decimal{Val: decimal128.FromI64(1420), Scale: 2}, -500754589},
+		{iceberg.Date(17486), -653330422},
+		{iceberg.Time(81068000000), -662762989},
+		{iceberg.Timestamp(1510871468000000), -2047944441},
+		{iceberg.TimestampNano(1510871468000000001), -2047944441},
+		{"iceberg", 1210000089},
+		{uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7"), 1488055340},
+		{[]byte{0, 1, 2, 3}, -188683207},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T", tt.val), func(t *testing.T) {
+			for _, n := range []int{1, 16, 1000} {
+				got, err := iceberg.BucketTransform{NumBuckets: n}.Apply(tt.val)
+				require.NoError(t, err)
+				assert.Equal(t, (tt.hash&math.MaxInt32)%int32(n), got)
+			}
+		})
+	}
+
+	got, err := iceberg.BucketTransform{NumBuckets: 4}.Apply(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = iceberg.BucketTransform{NumBuckets: 4}.Apply(float64(1))
+	assert.ErrorIs(t, err, iceberg.ErrType)
+}
+
+func TestTruncateTransformApply(t *testing.T) {
+	tests := []struct {
+		width    int
+		val, exp any
+	}{
+		{10, int32(1), int32(0)},
+		{10, int32(-1), int32(-10)},
+		{10, int64(25), int64(20)},
+		{10, int64(-25), int64(-30)},
+		{50, iceberg.Decimal{Val: decimal128.FromI64(1065), Scale: 2},
+			iceberg.Decimal{Val: decimal128.FromI64(1050), Scale: 2}},
+		{10, iceberg.Decimal{Val: decimal128.FromI64(-5), Scale: 2},
+			iceberg.Decimal{Val: decimal128.FromI64(-10), Scale: 2}},
+		{3, "iceberg", "ice"},
+		{2, "äöü", "äö"},
+		{10, "ice", "ice"},
+		{3, []byte{1, 2, 3, 4}, []byte{1, 2, 3}},
+		{3, nil, nil},
+	}
+
+	for _, tt := range tests {
+		got, err := iceberg.TruncateTransform{Width: tt.width}.Apply(tt.val)
+		require.NoError(t, err)
+		assert.Equal(t, tt.exp, got)
+	}
+
+	_, err := iceberg.TruncateTransform{Width: 3}.Apply(iceberg.Date(1))
+	assert.ErrorIs(t, err, iceberg.ErrType)
+}
+
+func TestTemporalTransformApply(t *testing.T) {
+	const (
+		date = iceberg.Date(17486) // 2017-11-16
+		ts   = iceberg.Timestamp(1510871468000000)
+	)
+
+	tests := []struct {
+		transform iceberg.Transform
+		val, exp  any
+	}{
+		{iceberg.YearTransform{}, date, int32(47)},
+		{iceberg.YearTransform{}, ts, int32(47)},
+		{iceberg.YearTransform{}, iceberg.TimestampNano(int64(ts) * 1000), int32(47)},
+		{iceberg.YearTransform{}, iceberg.Date(-1), int32(-1)},
+		{iceberg.MonthTransform{}, date, int32(574)},
+		{iceberg.MonthTransform{}, ts, int32(574)},
+		{iceberg.MonthTransform{}, iceberg.Date(-1), int32(-1)},
+		{iceberg.DayTransform{}, date, date},
+		{iceberg.DayTransform{}, ts, date},
+		{iceberg.DayTransform{}, iceberg.TimestampNano(int64(ts) * 1000), date},
+		{iceberg.DayTransform{}, iceberg.Timestamp(-1), iceberg.Date(-1)},
+		{iceberg.HourTransform{}, ts, int32(419686)},
+		{iceberg.HourTransform{}, iceberg.Timestamp(-1), int32(-1)},
+		{iceberg.HourTransform{}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.transform.Apply(tt.val)
+		require.NoError(t, err)
+		assert.Equal(t, tt.exp, got, "%s(%v)", tt.transform, tt.val)
+	}
+
+	_, err := iceberg.HourTransform{}.Apply(date)
+	assert.ErrorIs(t, err, iceberg.ErrType)
+	_, err = iceberg.YearTransform{}.Apply("2017")
+	assert.ErrorIs(t, err, iceberg.ErrType)
+}
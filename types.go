@@ -32,6 +32,8 @@ import (
 var (
 	regexFromBrackets = regexp.MustCompile(`^\w+\[(\d+)\]$`)
 	decimalRegex      = regexp.MustCompile(`decimal\(\s*(\d+)\s*,\s*(\d+)\s*\)`)
+	geometryRegex     = regexp.MustCompile(`^geometry\(\s*([^,()]+?)\s*\)$`)
+	geographyRegex    = regexp.MustCompile(`^geography\(\s*([^,()]+?)\s*(?:,\s*(\w+)\s*)?\)$`)
 )
 
 type Properties map[string]string
@@ -85,12 +87,24 @@ func (t *typeIFace) UnmarshalJSON(b []byte) error {
 			t.Type = TimestampType{}
 		case "timestamptz":
 			t.Type = TimestampTzType{}
+		case "timestamp_ns":
+			t.Type = TimestampNsType{}
+		case "timestamptz_ns":
+			t.Type = TimestampTzNsType{}
 		case "string":
 			t.Type = StringType{}
 		case "uuid":
 			t.Type = UUIDType{}
 		case "binary":
 			t.Type = BinaryType{}
+		case "unknown":
+			t.Type = UnknownType{}
+		case "variant":
+			t.Type = VariantType{}
+		case "geometry":
+			t.Type = GeometryType{}
+		case "geography":
+			t.Type = GeographyType{}
 		default:
 			switch {
 			case strings.HasPrefix(typename, "fixed"):
@@ -110,6 +124,20 @@ func (t *typeIFace) UnmarshalJSON(b []byte) error {
 				prec, _ := strconv.Atoi(matches[1])
 				scale, _ := strconv.Atoi(matches[2])
 				t.Type = DecimalType{precision: prec, scale: scale}
+			case strings.HasPrefix(typename, "geometry"):
+				matches := geometryRegex.FindStringSubmatch(typename)
+				if len(matches) != 2 {
+					return fmt.Errorf("%w: %s", ErrInvalidTypeString, typename)
+				}
+
+				t.Type = GeometryType{crs: matches[1]}
+			case strings.HasPrefix(typename, "geography"):
+				matches := geographyRegex.FindStringSubmatch(typename)
+				if len(matches) != 3 {
+					return fmt.Errorf("%w: %s", ErrInvalidTypeString, typename)
+				}
+
+				t.Type = GeographyType{crs: matches[1], algorithm: matches[2]}
 			default:
 				return fmt.Errorf("%w: unrecognized field type", ErrInvalidSchema)
 			}
@@ -177,11 +205,15 @@ func (n *NestedField) Equals(other NestedField) bool {
 }
 
 func (n NestedField) MarshalJSON() ([]byte, error) {
-	type Alias NestedField
 	return json.Marshal(struct {
-		Type *typeIFace `json:"type"`
-		*Alias
-	}{Type: &typeIFace{n.Type}, Alias: (*Alias)(&n)})
+		ID             int        `json:"id"`
+		Name           string     `json:"name"`
+		Required       bool       `json:"required"`
+		Type           *typeIFace `json:"type"`
+		Doc            string     `json:"doc,omitempty"`
+		InitialDefault any        `json:"initial-default,omitempty"`
+		WriteDefault   any        `json:"write-default,omitempty"`
+	}{n.ID, n.Name, n.Required, &typeIFace{n.Type}, n.Doc, n.InitialDefault, n.WriteDefault})
 }
 
 func (n *NestedField) UnmarshalJSON(b []byte) error {
@@ -567,6 +599,40 @@ func (TimestampTzType) primitive()     {}
 func (TimestampTzType) Type() string   { return "timestamptz" }
 func (TimestampTzType) String() string { return "timestamptz" }
 
+type TimestampNano int64
+
+func (t TimestampNano) ToDate() Date {
+	tm := time.Unix(0, int64(t)).UTC()
+	return Date(tm.Truncate(24*time.Hour).Unix() / int64((time.Hour * 24).Seconds()))
+}
+
+// TimestampNsType represents a number of nanoseconds since the unix epoch
+// without regard for timezone. It was added in v3 of the iceberg spec.
+type TimestampNsType struct{}
+
+func (TimestampNsType) Equals(other Type) bool {
+	_, ok := other.(TimestampNsType)
+	return ok
+}
+
+func (TimestampNsType) primitive()     {}
+func (TimestampNsType) Type() string   { return "timestamp_ns" }
+func (TimestampNsType) String() string { return "timestamp_ns" }
+
+// TimestampTzNsType represents a timestamp stored as UTC representing the
+// number of nanoseconds since the unix epoch. It was added in v3 of the
+// iceberg spec.
+type TimestampTzNsType struct{}
+
+func (TimestampTzNsType) Equals(other Type) bool {
+	_, ok := other.(TimestampTzNsType)
+	return ok
+}
+
+func (TimestampTzNsType) primitive()     {}
+func (TimestampTzNsType) Type() string   { return "timestamptz_ns" }
+func (TimestampTzNsType) String() string { return "timestamptz_ns" }
+
 type StringType struct{}
 
 func (StringType) Equals(other Type) bool {
@@ -600,30 +666,163 @@ func (BinaryType) primitive()     {}
 func (BinaryType) Type() string   { return "binary" }
 func (BinaryType) String() string { return "binary" }
 
+// UnknownType is the v3 "unknown" type, used for columns whose type is
+// not yet known such as a column which only contains nulls. Unknown
+// columns must be optional and are always read as null.
+type UnknownType struct{}
+
+func (UnknownType) Equals(other Type) bool {
+	_, ok := other.(UnknownType)
+	return ok
+}
+
+func (UnknownType) primitive()     {}
+func (UnknownType) Type() string   { return "unknown" }
+func (UnknownType) String() string { return "unknown" }
+
+// VariantType is the v3 "variant" type for semi-structured data, which is
+// stored using the variant binary encoding.
+type VariantType struct{}
+
+func (VariantType) Equals(other Type) bool {
+	_, ok := other.(VariantType)
+	return ok
+}
+
+func (VariantType) primitive()     {}
+func (VariantType) Type() string   { return "variant" }
+func (VariantType) String() string { return "variant" }
+
+const (
+	// DefaultGeoCRS is the coordinate reference system used by the
+	// geometry and geography types when one isn't specified.
+	DefaultGeoCRS = "OGC:CRS84"
+	// DefaultGeoAlgorithm is the edge interpolation algorithm used by
+	// the geography type when one isn't specified.
+	DefaultGeoAlgorithm = "spherical"
+)
+
+// GeometryTypeOf returns a geometry type with the given coordinate
+// reference system, an empty CRS means the default of OGC:CRS84.
+func GeometryTypeOf(crs string) GeometryType {
+	return GeometryType{crs: crs}
+}
+
+// GeometryType is the v3 "geometry" type for geospatial features stored
+// as well-known binary, with an optional coordinate reference system.
+type GeometryType struct {
+	crs string
+}
+
+func (g GeometryType) Equals(other Type) bool {
+	rhs, ok := other.(GeometryType)
+	if !ok {
+		return false
+	}
+
+	return g.CRS() == rhs.CRS()
+}
+
+// CRS returns the coordinate reference system of the type.
+func (g GeometryType) CRS() string {
+	if g.crs == "" {
+		return DefaultGeoCRS
+	}
+	return g.crs
+}
+
+func (GeometryType) primitive() {}
+func (g GeometryType) Type() string {
+	if g.crs == "" {
+		return "geometry"
+	}
+	return fmt.Sprintf("geometry(%s)", g.crs)
+}
+func (g GeometryType) String() string { return g.Type() }
+
+// GeographyTypeOf returns a geography type with the given coordinate
+// reference system and edge interpolation algorithm. Empty values mean
+// the defaults of OGC:CRS84 and spherical.
+func GeographyTypeOf(crs, algorithm string) GeographyType {
+	return GeographyType{crs: crs, algorithm: algorithm}
+}
+
+// GeographyType is the v3 "geography" type for geospatial features stored
+// as well-known binary, with an optional coordinate reference system and
+// edge interpolation algorithm.
+type GeographyType struct {
+	crs, algorithm string
+}
+
+func (g GeographyType) Equals(other Type) bool {
+	rhs, ok := other.(GeographyType)
+	if !ok {
+		return false
+	}
+
+	return g.CRS() == rhs.CRS() && g.Algorithm() == rhs.Algorithm()
+}
+
+// CRS returns the coordinate reference system of the type.
+func (g GeographyType) CRS() string {
+	if g.crs == "" {
+		return DefaultGeoCRS
+	}
+	return g.crs
+}
+
+// Algorithm returns the edge interpolation algorithm of the type.
+func (g GeographyType) Algorithm() string {
+	if g.algorithm == "" {
+		return DefaultGeoAlgorithm
+	}
+	return g.algorithm
+}
+
+func (GeographyType) primitive() {}
+func (g GeographyType) Type() string {
+	switch {
+	case g.crs == "" && g.algorithm == "":
+		return "geography"
+	case g.algorithm == "":
+		return fmt.Sprintf("geography(%s)", g.crs)
+	}
+	return fmt.Sprintf("geography(%s, %s)", g.CRS(), g.algorithm)
+}
+func (g GeographyType) String() string { return g.Type() }
+
 var PrimitiveTypes = struct {
-	Bool        PrimitiveType
-	Int32       PrimitiveType
-	Int64       PrimitiveType
-	Float32     PrimitiveType
-	Float64     PrimitiveType
-	Date        PrimitiveType
-	Time        PrimitiveType
-	Timestamp   PrimitiveType
-	TimestampTz PrimitiveType
-	String      PrimitiveType
-	Binary      PrimitiveType
-	UUID        PrimitiveType
+	Bool          PrimitiveType
+	Int32         PrimitiveType
+	Int64         PrimitiveType
+	Float32       PrimitiveType
+	Float64       PrimitiveType
+	Date          PrimitiveType
+	Time          PrimitiveType
+	Timestamp     PrimitiveType
+	TimestampTz   PrimitiveType
+	TimestampNs   PrimitiveType
+	TimestampTzNs PrimitiveType
+	String        PrimitiveType
+	Binary        PrimitiveType
+	UUID          PrimitiveType
+	Unknown       PrimitiveType
+	Variant       PrimitiveType
 }{
-	Bool:        BooleanType{},
-	Int32:       Int32Type{},
-	Int64:       Int64Type{},
-	Float32:     Float32Type{},
-	Float64:     Float64Type{},
-	Date:        DateType{},
-	Time:        TimeType{},
-	Timestamp:   TimestampType{},
-	TimestampTz: TimestampTzType{},
-	String:      StringType{},
-	Binary:      BinaryType{},
-	UUID:        UUIDType{},
+	Bool:          BooleanType{},
+	Int32:         Int32Type{},
+	Int64:         Int64Type{},
+	Float32:       Float32Type{},
+	Float64:       Float64Type{},
+	Date:          DateType{},
+	Time:          TimeType{},
+	Timestamp:     TimestampType{},
+	TimestampTz:   TimestampTzType{},
+	TimestampNs:   TimestampNsType{},
+	TimestampTzNs: TimestampTzNsType{},
+	String:        StringType{},
+	Binary:        BinaryType{},
+	UUID:          UUIDType{},
+	Unknown:       UnknownType{},
+	Variant:       VariantType{},
 }
@@ -40,10 +40,19 @@ func TestTypesBasic(t *testing.T) {
 		{"time", iceberg.PrimitiveTypes.Time},
 		{"timestamp", iceberg.PrimitiveTypes.Timestamp},
 		{"timestamptz", iceberg.PrimitiveTypes.TimestampTz},
+		{"timestamp_ns", iceberg.PrimitiveTypes.TimestampNs},
+		{"timestamptz_ns", iceberg.PrimitiveTypes.TimestampTzNs},
 		{"uuid", iceberg.PrimitiveTypes.UUID},
 		{"binary", iceberg.PrimitiveTypes.Binary},
 		{"fixed[5]", iceberg.FixedTypeOf(5)},
 		{"decimal(9, 4)", iceberg.DecimalTypeOf(9, 4)},
+		{"unknown", iceberg.PrimitiveTypes.Unknown},
+		{"variant", iceberg.PrimitiveTypes.Variant},
+		{"geometry", iceberg.GeometryTypeOf("")},
+		{"geometry(EPSG:4326)", iceberg.GeometryTypeOf("EPSG:4326")},
+		{"geography", iceberg.GeographyTypeOf("", "")},
+		{"geography(EPSG:4326)", iceberg.GeographyTypeOf("EPSG:4326", "")},
+		{"geography(EPSG:4326, karney)", iceberg.GeographyTypeOf("EPSG:4326", "karney")},
 	}
 
 	for _, tt := range tests {
@@ -66,6 +75,26 @@ func TestTypesBasic(t *testing.T) {
 	}
 }
 
+func TestGeoTypes(t *testing.T) {
+	geom := iceberg.GeometryTypeOf("")
+	assert.Equal(t, iceberg.DefaultGeoCRS, geom.CRS())
+	assert.True(t, geom.Equals(iceberg.GeometryTypeOf("OGC:CRS84")))
+	assert.False(t, geom.Equals(iceberg.GeometryTypeOf("EPSG:4326")))
+	assert.False(t, geom.Equals(iceberg.GeographyTypeOf("", "")))
+
+	geog := iceberg.GeographyTypeOf("EPSG:4326", "")
+	assert.Equal(t, "EPSG:4326", geog.CRS())
+	assert.Equal(t, iceberg.DefaultGeoAlgorithm, geog.Algorithm())
+	assert.True(t, geog.Equals(iceberg.GeographyTypeOf("EPSG:4326", "spherical")))
+	assert.False(t, geog.Equals(iceberg.GeographyTypeOf("EPSG:4326", "vincenty")))
+
+	for _, bad := range []string{"geometry()", "geometry(a, b)", "geography(a, b, c)", "geography("} {
+		var n iceberg.NestedField
+		err := json.Unmarshal([]byte(`{"id": 1, "name": "g", "type": "`+bad+`", "required": false}`), &n)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidTypeString, bad)
+	}
+}
+
 func TestFixedType(t *testing.T) {
 	typ := iceberg.FixedTypeOf(5)
 	assert.Equal(t, 5, typ.Len())
@@ -181,9 +210,13 @@ var (
 		iceberg.PrimitiveTypes.Time,
 		iceberg.PrimitiveTypes.Timestamp,
 		iceberg.PrimitiveTypes.TimestampTz,
+		iceberg.PrimitiveTypes.TimestampNs,
+		iceberg.PrimitiveTypes.TimestampTzNs,
 		iceberg.PrimitiveTypes.String,
 		iceberg.PrimitiveTypes.Binary,
 		iceberg.PrimitiveTypes.UUID,
+		iceberg.PrimitiveTypes.Unknown,
+		iceberg.PrimitiveTypes.Variant,
 	}
 )
 
@@ -213,6 +246,8 @@ func TestTypeStrings(t *testing.T) {
 		{iceberg.PrimitiveTypes.Time, "time"},
 		{iceberg.PrimitiveTypes.Timestamp, "timestamp"},
 		{iceberg.PrimitiveTypes.TimestampTz, "timestamptz"},
+		{iceberg.PrimitiveTypes.TimestampNs, "timestamp_ns"},
+		{iceberg.PrimitiveTypes.TimestampTzNs, "timestamptz_ns"},
 		{iceberg.PrimitiveTypes.String, "string"},
 		{iceberg.PrimitiveTypes.UUID, "uuid"},
 		{iceberg.PrimitiveTypes.Binary, "binary"},
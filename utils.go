@@ -24,6 +24,8 @@ import (
 	"maps"
 	"runtime/debug"
 	"strings"
+
+	"golang.org/x/exp/slices"
 )
 
 var version string
@@ -162,8 +164,13 @@ func (l literalSet) Members() []Literal {
 }
 
 func (l literalSet) Equals(other Set[Literal]) bool {
-	rhs, ok := other.(literalSet)
-	if !ok {
+	var rhs literalSet
+	switch other := other.(type) {
+	case literalSet:
+		rhs = other
+	case *sortedLiteralSet:
+		rhs = other.literalSet
+	default:
 		return false
 	}
 	return maps.EqualFunc(l, rhs, func(v1, v2 struct{ orig Literal }) bool {
@@ -179,3 +186,51 @@ func (l literalSet) Equals(other Set[Literal]) bool {
 }
 
 func (l literalSet) Len() int { return len(l) }
+
+// sortedLiteralSet is a set of literals of the same type which also keeps
+// its members sorted, so that bound set predicates can be checked against
+// a range of values without scanning every member.
+type sortedLiteralSet struct {
+	literalSet
+	sorted []Literal
+	cmp    func(Literal, Literal) int
+}
+
+func newSortedLiteralSet(set literalSet) *sortedLiteralSet {
+	s := &sortedLiteralSet{literalSet: set}
+	s.sort()
+	return s
+}
+
+func (s *sortedLiteralSet) sort() {
+	s.sorted = s.literalSet.Members()
+	if len(s.sorted) > 0 {
+		s.cmp = getCmpLiteral(s.sorted[0])
+		slices.SortFunc(s.sorted, s.cmp)
+	}
+}
+
+func (s *sortedLiteralSet) Add(lits ...Literal) {
+	s.literalSet.Add(lits...)
+	s.sort()
+}
+
+// Members returns the members of the set in ascending order.
+func (s *sortedLiteralSet) Members() []Literal { return slices.Clone(s.sorted) }
+
+func (s *sortedLiteralSet) Equals(other Set[Literal]) bool {
+	return s.literalSet.Equals(other)
+}
+
+// anyInRange returns true if any member is within lower and upper
+// inclusive, either of which may be nil to leave that side unbounded.
+func (s *sortedLiteralSet) anyInRange(lower, upper Literal) bool {
+	i := 0
+	if lower != nil {
+		i, _ = slices.BinarySearchFunc(s.sorted, lower, s.cmp)
+	}
+	if i == len(s.sorted) {
+		return false
+	}
+	return upper == nil || s.cmp(s.sorted[i], upper) <= 0
+}
@@ -0,0 +1,894 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// BooleanExprVisitor is an interface for recursively visiting the nodes of a
+// boolean expression
+type BooleanExprVisitor[T any] interface {
+	VisitTrue() T
+	VisitFalse() T
+	VisitNot(childResult T) T
+	VisitAnd(left, right T) T
+	VisitOr(left, right T) T
+	VisitUnbound(UnboundPredicate) T
+	VisitBound(BoundPredicate) T
+}
+
+// BoundBooleanExprVisitor builds on BooleanExprVisitor by adding interface
+// methods for visiting bound expressions, because we do casting of literals
+// during binding you can assume that the BoundTerm and the Literal passed
+// to a method have the same type.
+type BoundBooleanExprVisitor[T any] interface {
+	BooleanExprVisitor[T]
+
+	VisitIn(BoundTerm, Set[Literal]) T
+	VisitNotIn(BoundTerm, Set[Literal]) T
+	VisitIsNan(BoundTerm) T
+	VisitNotNan(BoundTerm) T
+	VisitIsNull(BoundTerm) T
+	VisitNotNull(BoundTerm) T
+	VisitEqual(BoundTerm, Literal) T
+	VisitNotEqual(BoundTerm, Literal) T
+	VisitGreaterEqual(BoundTerm, Literal) T
+	VisitGreater(BoundTerm, Literal) T
+	VisitLessEqual(BoundTerm, Literal) T
+	VisitLess(BoundTerm, Literal) T
+	VisitStartsWith(BoundTerm, Literal) T
+	VisitNotStartsWith(BoundTerm, Literal) T
+}
+
+// VisitExpr is a convenience function to use a given visitor to visit all parts of
+// a boolean expression in-order. Values returned from the methods are passed to the
+// subsequent methods, effectively "bubbling up" the results.
+func VisitExpr[T any](expr BooleanExpression, visitor BooleanExprVisitor[T]) (res T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case string:
+				err = fmt.Errorf("error encountered during visitExpr: %s", e)
+			case error:
+				err = e
+			}
+		}
+	}()
+
+	return visitBoolExpr(expr, visitor), err
+}
+
+func visitBoolExpr[T any](e BooleanExpression, visitor BooleanExprVisitor[T]) T {
+	switch e := e.(type) {
+	case AlwaysFalse:
+		return visitor.VisitFalse()
+	case AlwaysTrue:
+		return visitor.VisitTrue()
+	case AndExpr:
+		left, right := visitBoolExpr(e.left, visitor), visitBoolExpr(e.right, visitor)
+		return visitor.VisitAnd(left, right)
+	case OrExpr:
+		left, right := visitBoolExpr(e.left, visitor), visitBoolExpr(e.right, visitor)
+		return visitor.VisitOr(left, right)
+	case NotExpr:
+		child := visitBoolExpr(e.child, visitor)
+		return visitor.VisitNot(child)
+	case UnboundPredicate:
+		return visitor.VisitUnbound(e)
+	case BoundPredicate:
+		return visitor.VisitBound(e)
+	}
+
+	panic(fmt.Errorf("%w: VisitBooleanExpression type %s", ErrNotImplemented, e))
+}
+
+// VisitBoundPredicate uses a BoundBooleanExprVisitor to call the appropriate method
+// based on the type of operation in the predicate. This is a convenience function
+// for implementing the VisitBound method of a BoundBooleanExprVisitor by simply calling
+// iceberg.VisitBoundPredicate(pred, this).
+func VisitBoundPredicate[T any](e BoundPredicate, visitor BoundBooleanExprVisitor[T]) T {
+	switch e.Op() {
+	case OpIn:
+		return visitor.VisitIn(e.Term(), e.(BoundSetPredicate).Literals())
+	case OpNotIn:
+		return visitor.VisitNotIn(e.Term(), e.(BoundSetPredicate).Literals())
+	case OpIsNan:
+		return visitor.VisitIsNan(e.Term())
+	case OpNotNan:
+		return visitor.VisitNotNan(e.Term())
+	case OpIsNull:
+		return visitor.VisitIsNull(e.Term())
+	case OpNotNull:
+		return visitor.VisitNotNull(e.Term())
+	case OpEQ:
+		return visitor.VisitEqual(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpNEQ:
+		return visitor.VisitNotEqual(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpGTEQ:
+		return visitor.VisitGreaterEqual(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpGT:
+		return visitor.VisitGreater(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpLTEQ:
+		return visitor.VisitLessEqual(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpLT:
+		return visitor.VisitLess(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpStartsWith:
+		return visitor.VisitStartsWith(e.Term(), e.(BoundLiteralPredicate).Literal())
+	case OpNotStartsWith:
+		return visitor.VisitNotStartsWith(e.Term(), e.(BoundLiteralPredicate).Literal())
+	}
+
+	panic(fmt.Errorf("%w: unhandled bound predicate type: %s", ErrNotImplemented, e))
+}
+
+// BindExpr recursively binds each portion of an expression using the provided schema.
+// Because the expression can end up being simplified to just AlwaysTrue/AlwaysFalse,
+// this returns a BooleanExpression.
+func BindExpr(s *Schema, expr BooleanExpression, caseSensitive bool) (BooleanExpression, error) {
+	return VisitExpr(expr, &bindVisitor{schema: s, caseSensitive: caseSensitive})
+}
+
+type bindVisitor struct {
+	schema        *Schema
+	caseSensitive bool
+}
+
+func (*bindVisitor) VisitTrue() BooleanExpression  { return AlwaysTrue{} }
+func (*bindVisitor) VisitFalse() BooleanExpression { return AlwaysFalse{} }
+func (*bindVisitor) VisitNot(child BooleanExpression) BooleanExpression {
+	return NewNot(child)
+}
+func (*bindVisitor) VisitAnd(left, right BooleanExpression) BooleanExpression {
+	return NewAnd(left, right)
+}
+func (*bindVisitor) VisitOr(left, right BooleanExpression) BooleanExpression {
+	return NewOr(left, right)
+}
+func (b *bindVisitor) VisitUnbound(pred UnboundPredicate) BooleanExpression {
+	expr, err := pred.Bind(b.schema, b.caseSensitive)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+func (*bindVisitor) VisitBound(pred BoundPredicate) BooleanExpression {
+	panic(fmt.Errorf("%w: found already bound predicate: %s", ErrInvalidArgument, pred))
+}
+
+// RewriteNotExpr rewrites a boolean expression to remove "Not" nodes from the expression
+// tree. This is because Projections assume there are no "not" nodes.
+//
+// Not nodes will be replaced with simply calling `Negate` on the child in the tree.
+func RewriteNotExpr(expr BooleanExpression) (BooleanExpression, error) {
+	return VisitExpr(expr, rewriteNotVisitor{})
+}
+
+type rewriteNotVisitor struct{}
+
+func (rewriteNotVisitor) VisitTrue() BooleanExpression  { return AlwaysTrue{} }
+func (rewriteNotVisitor) VisitFalse() BooleanExpression { return AlwaysFalse{} }
+func (rewriteNotVisitor) VisitNot(child BooleanExpression) BooleanExpression {
+	return child.Negate()
+}
+func (rewriteNotVisitor) VisitAnd(left, right BooleanExpression) BooleanExpression {
+	return NewAnd(left, right)
+}
+func (rewriteNotVisitor) VisitOr(left, right BooleanExpression) BooleanExpression {
+	return NewOr(left, right)
+}
+func (rewriteNotVisitor) VisitUnbound(pred UnboundPredicate) BooleanExpression {
+	return pred
+}
+func (rewriteNotVisitor) VisitBound(pred BoundPredicate) BooleanExpression {
+	return pred
+}
+
+const (
+	rowsMightMatch, rowsCannotMatch = true, false
+	// set predicates with more literals than this are not checked
+	// against the bounds unless their literals are sorted, as the cost of
+	// scanning them outweighs the benefit.
+	inPredicateLimit = 200
+)
+
+// literalsInRange returns whether any of the literals is within lower and
+// upper inclusive, either of which may be nil for an unbounded side. The
+// sorted literals of bound set predicates are searched, others are
+// scanned unless there are more than inPredicateLimit of them, in which
+// case they're assumed to be in range.
+func literalsInRange(lits Set[Literal], lower, upper Literal) bool {
+	if sorted, ok := lits.(*sortedLiteralSet); ok {
+		return sorted.anyInRange(lower, upper)
+	}
+
+	if lits.Len() > inPredicateLimit {
+		return true
+	}
+
+	for _, v := range lits.Members() {
+		if lower != nil && getCmpLiteral(lower)(lower, v) > 0 {
+			continue
+		}
+		if upper != nil && getCmpLiteral(upper)(upper, v) < 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// NewManifestEvaluator returns a function that can be used to evaluate whether
+// a particular manifest file might contain rows matching the given partition
+// filter, using only the partition field summaries stored in the manifest list.
+// This allows skipping manifests without opening and decoding them.
+//
+// The filter must be expressed in terms of the partition fields of the spec
+// (e.g. "id_bucket" or "ts_day") rather than the source columns of the table
+// schema. The schema is used to determine the type of each partition field so
+// that the summary bounds can be decoded correctly.
+//
+// The returned function only returns false if it is certain that the manifest
+// cannot contain any matching rows.
+func NewManifestEvaluator(spec PartitionSpec, schema *Schema, partitionFilter BooleanExpression, caseSensitive bool) (func(ManifestFile) (bool, error), error) {
+	partType := spec.PartitionType(schema)
+	partSchema := NewSchema(0, partType.FieldList...)
+	filter, err := RewriteNotExpr(partitionFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	boundFilter, err := BindExpr(partSchema, filter, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&manifestEvalVisitor{partitionFilter: boundFilter}).Eval, nil
+}
+
+type manifestEvalVisitor struct {
+	partitionFilter BooleanExpression
+}
+
+func (m *manifestEvalVisitor) Eval(manifest ManifestFile) (bool, error) {
+	partitions := manifest.Partitions()
+	if len(partitions) == 0 {
+		return rowsMightMatch, nil
+	}
+
+	return VisitExpr(m.partitionFilter, &manifestSummaryVisitor{partitionFields: partitions})
+}
+
+// manifestSummaryVisitor evaluates a bound partition filter against the
+// field summaries of a single manifest. A new one is created for each
+// manifest so that evaluation is safe for concurrent use.
+type manifestSummaryVisitor struct {
+	partitionFields []FieldSummary
+}
+
+func (m *manifestSummaryVisitor) VisitTrue() bool  { return rowsMightMatch }
+func (m *manifestSummaryVisitor) VisitFalse() bool { return rowsCannotMatch }
+func (m *manifestSummaryVisitor) VisitNot(bool) bool {
+	panic(fmt.Errorf("%w: NOT should be rewritten before evaluating manifests",
+		ErrInvalidArgument))
+}
+func (m *manifestSummaryVisitor) VisitAnd(left, right bool) bool { return left && right }
+func (m *manifestSummaryVisitor) VisitOr(left, right bool) bool  { return left || right }
+func (m *manifestSummaryVisitor) VisitUnbound(UnboundPredicate) bool {
+	panic(fmt.Errorf("%w: manifest evaluation requires a bound expression",
+		ErrInvalidArgument))
+}
+func (m *manifestSummaryVisitor) VisitBound(pred BoundPredicate) bool {
+	return VisitBoundPredicate(pred, m)
+}
+
+// summary returns the field summary for the referenced partition field, or
+// false if the manifest doesn't have a summary for it.
+func (m *manifestSummaryVisitor) summary(term BoundTerm) (FieldSummary, bool) {
+	pos := term.Ref().Pos()
+	if pos < 0 || pos >= len(m.partitionFields) {
+		return FieldSummary{}, false
+	}
+	return m.partitionFields[pos], true
+}
+
+func (m *manifestSummaryVisitor) bounds(term BoundTerm, field FieldSummary) (lower, upper Literal) {
+	var err error
+	if field.LowerBound != nil {
+		if lower, err = LiteralFromBytes(term.Type(), *field.LowerBound); err != nil {
+			panic(err)
+		}
+	}
+	if field.UpperBound != nil {
+		if upper, err = LiteralFromBytes(term.Type(), *field.UpperBound); err != nil {
+			panic(err)
+		}
+	}
+	return
+}
+
+func (m *manifestSummaryVisitor) VisitIn(term BoundTerm, lits Set[Literal]) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.LowerBound == nil {
+		// all values are null
+		return rowsCannotMatch
+	}
+
+	lower, upper := m.bounds(term, field)
+	if !literalsInRange(lits, lower, upper) {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitNotIn(BoundTerm, Set[Literal]) bool {
+	// because the bounds are not necessarily a min or max value, this
+	// cannot be answered using them. notIn(col, {X, ...}) with (X, Y)
+	// doesn't guarantee that X is a value in col.
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitIsNan(term BoundTerm) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.ContainsNaN != nil && !*field.ContainsNaN {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitNotNan(term BoundTerm) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.ContainsNaN != nil && *field.ContainsNaN &&
+		!field.ContainsNull && field.LowerBound == nil {
+		// all values are NaN
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitIsNull(term BoundTerm) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if !field.ContainsNull {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitNotNull(term BoundTerm) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	// ContainsNull encodes whether at least one partition value is null,
+	// the lower bound is null if all partition values are null
+	allNull := field.ContainsNull && field.LowerBound == nil
+	if allNull {
+		switch term.Type().(type) {
+		case Float32Type, Float64Type:
+			// floating point types may include NaN values, which are
+			// not included in the bounds, so check those separately.
+			allNull = field.ContainsNaN != nil && !*field.ContainsNaN
+		}
+	}
+
+	if allNull {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitEqual(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.LowerBound == nil || field.UpperBound == nil {
+		// values are all null and literal cannot contain null
+		return rowsCannotMatch
+	}
+
+	lower, upper := m.bounds(term, field)
+	cmp := getCmpLiteral(lit)
+	if cmp(lower, lit) > 0 || cmp(upper, lit) < 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitNotEqual(BoundTerm, Literal) bool {
+	// because the bounds are not necessarily a min or max value, this
+	// cannot be answered using them. notEq(col, X) with (X, Y) doesn't
+	// guarantee that X is a value in col.
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitGreaterEqual(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.UpperBound == nil {
+		return rowsCannotMatch
+	}
+
+	_, upper := m.bounds(term, field)
+	if getCmpLiteral(lit)(upper, lit) < 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitGreater(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.UpperBound == nil {
+		return rowsCannotMatch
+	}
+
+	_, upper := m.bounds(term, field)
+	if getCmpLiteral(lit)(upper, lit) <= 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitLessEqual(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.LowerBound == nil {
+		return rowsCannotMatch
+	}
+
+	lower, _ := m.bounds(term, field)
+	if getCmpLiteral(lit)(lower, lit) > 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitLess(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.LowerBound == nil {
+		return rowsCannotMatch
+	}
+
+	lower, _ := m.bounds(term, field)
+	if getCmpLiteral(lit)(lower, lit) >= 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitStartsWith(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.LowerBound == nil || field.UpperBound == nil {
+		return rowsCannotMatch
+	}
+
+	prefix := lit.(StringLiteral).Value()
+	lower, upper := m.bounds(term, field)
+
+	// truncate the bounds to the length of the prefix before comparing
+	// so that a lower bound of "abc" doesn't exclude the prefix "ab"
+	lowerStr := lower.(StringLiteral).Value()
+	if len(lowerStr) > len(prefix) {
+		lowerStr = lowerStr[:len(prefix)]
+	}
+	if lowerStr > prefix {
+		return rowsCannotMatch
+	}
+
+	upperStr := upper.(StringLiteral).Value()
+	if len(upperStr) > len(prefix) {
+		upperStr = upperStr[:len(prefix)]
+	}
+	if upperStr < prefix {
+		return rowsCannotMatch
+	}
+
+	return rowsMightMatch
+}
+
+func (m *manifestSummaryVisitor) VisitNotStartsWith(term BoundTerm, lit Literal) bool {
+	field, ok := m.summary(term)
+	if !ok {
+		return rowsMightMatch
+	}
+
+	if field.ContainsNull || field.LowerBound == nil || field.UpperBound == nil {
+		return rowsMightMatch
+	}
+
+	// NotStartsWith will match unless all values must start with the
+	// prefix. This happens when the lower and upper bounds both start
+	// with the prefix.
+	prefix := lit.(StringLiteral).Value()
+	lower, upper := m.bounds(term, field)
+	if strings.HasPrefix(lower.(StringLiteral).Value(), prefix) &&
+		strings.HasPrefix(upper.(StringLiteral).Value(), prefix) {
+		return rowsCannotMatch
+	}
+
+	return rowsMightMatch
+}
+
+// NewInclusiveMetricsEvaluator returns a function that can be used to
+// evaluate whether a data file might contain rows matching the given row
+// filter, using the column statistics (value, null and NaN counts and
+// lower and upper bounds) stored for the file in its manifest.
+//
+// Statistics are optional, so a column without them is treated as
+// unknown: a file is only excluded if the statistics which are present
+// show that no rows can match, and never because statistics are missing.
+// Empty files are excluded unless includeEmptyFiles is true.
+func NewInclusiveMetricsEvaluator(schema *Schema, filter BooleanExpression, caseSensitive, includeEmptyFiles bool) (func(DataFile) (bool, error), error) {
+	rewritten, err := RewriteNotExpr(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	boundFilter, err := BindExpr(schema, rewritten, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(file DataFile) (bool, error) {
+		if !includeEmptyFiles && file.Count() == 0 {
+			return rowsCannotMatch, nil
+		}
+
+		if file.Count() < 0 {
+			// older versions don't always write the record count
+			return rowsMightMatch, nil
+		}
+
+		return VisitExpr(boundFilter, &metricsEvalVisitor{
+			valueCounts: file.ValueCounts(),
+			nullCounts:  file.NullValueCounts(),
+			nanCounts:   file.NaNValueCounts(),
+			lowerBounds: file.LowerBoundValues(),
+			upperBounds: file.UpperBoundValues(),
+		})
+	}, nil
+}
+
+// metricsEvalVisitor evaluates a bound row filter against the column
+// statistics of a single data file. Any of the statistics may be missing
+// for a column, or entirely, in which case they're treated as unknown.
+type metricsEvalVisitor struct {
+	valueCounts map[int]int64
+	nullCounts  map[int]int64
+	nanCounts   map[int]int64
+	lowerBounds map[int][]byte
+	upperBounds map[int][]byte
+}
+
+func (m *metricsEvalVisitor) VisitTrue() bool  { return rowsMightMatch }
+func (m *metricsEvalVisitor) VisitFalse() bool { return rowsCannotMatch }
+func (m *metricsEvalVisitor) VisitNot(bool) bool {
+	panic(fmt.Errorf("%w: NOT should be rewritten before evaluating metrics",
+		ErrInvalidArgument))
+}
+func (m *metricsEvalVisitor) VisitAnd(left, right bool) bool { return left && right }
+func (m *metricsEvalVisitor) VisitOr(left, right bool) bool  { return left || right }
+func (m *metricsEvalVisitor) VisitUnbound(UnboundPredicate) bool {
+	panic(fmt.Errorf("%w: metrics evaluation requires a bound expression",
+		ErrInvalidArgument))
+}
+func (m *metricsEvalVisitor) VisitBound(pred BoundPredicate) bool {
+	return VisitBoundPredicate(pred, m)
+}
+
+func (m *metricsEvalVisitor) containsNullsOnly(id int) bool {
+	valCount, okVal := m.valueCounts[id]
+	nullCount, okNull := m.nullCounts[id]
+	return okVal && okNull && valCount == nullCount
+}
+
+func (m *metricsEvalVisitor) containsNaNsOnly(id int) bool {
+	valCount, okVal := m.valueCounts[id]
+	nanCount, okNaN := m.nanCounts[id]
+	return okVal && okNaN && valCount == nanCount
+}
+
+// bound decodes the lower or upper bound of the referenced column,
+// returning nil if there is no bound or it can't be decoded. A NaN bound
+// is also treated as unknown, since NaN is not ordered.
+func (m *metricsEvalVisitor) bound(term BoundTerm, bounds map[int][]byte) Literal {
+	raw, ok := bounds[term.Ref().Field().ID]
+	if !ok {
+		return nil
+	}
+
+	if _, isPrimitive := term.Type().(PrimitiveType); !isPrimitive {
+		return nil
+	}
+
+	lit, err := LiteralFromBytes(term.Type(), raw)
+	if err != nil {
+		return nil
+	}
+
+	switch l := lit.(type) {
+	case Float32Literal:
+		if math.IsNaN(float64(l)) {
+			return nil
+		}
+	case Float64Literal:
+		if math.IsNaN(float64(l)) {
+			return nil
+		}
+	}
+	return lit
+}
+
+func (m *metricsEvalVisitor) lower(term BoundTerm) Literal {
+	return m.bound(term, m.lowerBounds)
+}
+
+func (m *metricsEvalVisitor) upper(term BoundTerm) Literal {
+	return m.bound(term, m.upperBounds)
+}
+
+// cannotMatchNonNull reports whether the column is known to contain only
+// nulls or only NaNs, so no comparison with a literal can match.
+func (m *metricsEvalVisitor) cannotMatchNonNull(term BoundTerm) bool {
+	id := term.Ref().Field().ID
+	return m.containsNullsOnly(id) || m.containsNaNsOnly(id)
+}
+
+func (m *metricsEvalVisitor) VisitIn(term BoundTerm, lits Set[Literal]) bool {
+	if m.cannotMatchNonNull(term) {
+		return rowsCannotMatch
+	}
+
+	if !literalsInRange(lits, m.lower(term), m.upper(term)) {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitNotIn(BoundTerm, Set[Literal]) bool {
+	// the bounds are not necessarily values in the column, so this
+	// cannot be answered using them.
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitIsNan(term BoundTerm) bool {
+	id := term.Ref().Field().ID
+	if nanCount, ok := m.nanCounts[id]; ok && nanCount == 0 {
+		return rowsCannotMatch
+	}
+
+	if m.containsNullsOnly(id) {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitNotNan(term BoundTerm) bool {
+	if m.containsNaNsOnly(term.Ref().Field().ID) {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitIsNull(term BoundTerm) bool {
+	if nullCount, ok := m.nullCounts[term.Ref().Field().ID]; ok && nullCount == 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitNotNull(term BoundTerm) bool {
+	if m.containsNullsOnly(term.Ref().Field().ID) {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitEqual(term BoundTerm, lit Literal) bool {
+	if m.cannotMatchNonNull(term) {
+		return rowsCannotMatch
+	}
+
+	cmp := getCmpLiteral(lit)
+	if lower := m.lower(term); lower != nil && cmp(lower, lit) > 0 {
+		return rowsCannotMatch
+	}
+	if upper := m.upper(term); upper != nil && cmp(upper, lit) < 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitNotEqual(BoundTerm, Literal) bool {
+	// the bounds are not necessarily values in the column, so this
+	// cannot be answered using them.
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitGreaterEqual(term BoundTerm, lit Literal) bool {
+	if m.cannotMatchNonNull(term) {
+		return rowsCannotMatch
+	}
+
+	if upper := m.upper(term); upper != nil && getCmpLiteral(lit)(upper, lit) < 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitGreater(term BoundTerm, lit Literal) bool {
+	if m.cannotMatchNonNull(term) {
+		return rowsCannotMatch
+	}
+
+	if upper := m.upper(term); upper != nil && getCmpLiteral(lit)(upper, lit) <= 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitLessEqual(term BoundTerm, lit Literal) bool {
+	if m.cannotMatchNonNull(term) {
+		return rowsCannotMatch
+	}
+
+	if lower := m.lower(term); lower != nil && getCmpLiteral(lit)(lower, lit) > 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitLess(term BoundTerm, lit Literal) bool {
+	if m.cannotMatchNonNull(term) {
+		return rowsCannotMatch
+	}
+
+	if lower := m.lower(term); lower != nil && getCmpLiteral(lit)(lower, lit) >= 0 {
+		return rowsCannotMatch
+	}
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitStartsWith(term BoundTerm, lit Literal) bool {
+	if m.containsNullsOnly(term.Ref().Field().ID) {
+		return rowsCannotMatch
+	}
+
+	prefix := lit.(StringLiteral).Value()
+	if lower, ok := m.lower(term).(StringLiteral); ok {
+		lowerStr := string(lower)
+		if len(lowerStr) > len(prefix) {
+			lowerStr = lowerStr[:len(prefix)]
+		}
+		if lowerStr > prefix {
+			return rowsCannotMatch
+		}
+	}
+
+	if upper, ok := m.upper(term).(StringLiteral); ok {
+		upperStr := string(upper)
+		if len(upperStr) > len(prefix) {
+			upperStr = upperStr[:len(prefix)]
+		}
+		if upperStr < prefix {
+			return rowsCannotMatch
+		}
+	}
+
+	return rowsMightMatch
+}
+
+func (m *metricsEvalVisitor) VisitNotStartsWith(term BoundTerm, lit Literal) bool {
+	id := term.Ref().Field().ID
+	if nullCount, ok := m.nullCounts[id]; !ok || nullCount > 0 {
+		return rowsMightMatch
+	}
+
+	// NotStartsWith will match unless all values must start with the
+	// prefix, which is the case when both bounds start with it.
+	prefix := lit.(StringLiteral).Value()
+	lower, okLower := m.lower(term).(StringLiteral)
+	upper, okUpper := m.upper(term).(StringLiteral)
+	if okLower && okUpper &&
+		strings.HasPrefix(string(lower), prefix) && strings.HasPrefix(string(upper), prefix) {
+		return rowsCannotMatch
+	}
+
+	return rowsMightMatch
+}
+
+func getCmp[T LiteralType](b TypedLiteral[T]) func(Literal, Literal) int {
+	cmp := b.Comparator()
+	return func(l1, l2 Literal) int {
+		return cmp(l1.(TypedLiteral[T]).Value(), l2.(TypedLiteral[T]).Value())
+	}
+}
+
+// getCmpLiteral returns a comparison function for literals of the same
+// type as the provided literal.
+func getCmpLiteral(boundary Literal) func(Literal, Literal) int {
+	switch l := boundary.(type) {
+	case TypedLiteral[bool]:
+		return getCmp(l)
+	case TypedLiteral[int32]:
+		return getCmp(l)
+	case TypedLiteral[int64]:
+		return getCmp(l)
+	case TypedLiteral[float32]:
+		return getCmp(l)
+	case TypedLiteral[float64]:
+		return getCmp(l)
+	case TypedLiteral[Date]:
+		return getCmp(l)
+	case TypedLiteral[Time]:
+		return getCmp(l)
+	case TypedLiteral[Timestamp]:
+		return getCmp(l)
+	case TypedLiteral[TimestampNano]:
+		return getCmp(l)
+	case TypedLiteral[[]byte]:
+		return getCmp(l)
+	case TypedLiteral[string]:
+		return getCmp(l)
+	case TypedLiteral[uuid.UUID]:
+		return getCmp(l)
+	case TypedLiteral[Decimal]:
+		return getCmp(l)
+	}
+	panic(fmt.Errorf("%w: invalid literal type %s", ErrType, boundary.Type()))
+}
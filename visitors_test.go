@@ -0,0 +1,487 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteNot(t *testing.T) {
+	tests := []struct {
+		expr, expected iceberg.BooleanExpression
+	}{
+		{iceberg.NewNot(iceberg.EqualTo(iceberg.Reference("x"), 34.56)),
+			iceberg.NotEqualTo(iceberg.Reference("x"), 34.56)},
+		{iceberg.NewNot(iceberg.NotEqualTo(iceberg.Reference("x"), 34.56)),
+			iceberg.EqualTo(iceberg.Reference("x"), 34.56)},
+		{iceberg.NewNot(iceberg.IsIn(iceberg.Reference("x"), 34.56, 23.45)),
+			iceberg.NotIn(iceberg.Reference("x"), 34.56, 23.45)},
+		{iceberg.NewNot(iceberg.NewAnd(
+			iceberg.EqualTo(iceberg.Reference("x"), 34.56), iceberg.EqualTo(iceberg.Reference("y"), 34.56))),
+			iceberg.NewOr(
+				iceberg.NotEqualTo(iceberg.Reference("x"), 34.56), iceberg.NotEqualTo(iceberg.Reference("y"), 34.56))},
+		{iceberg.NewNot(iceberg.NewOr(
+			iceberg.EqualTo(iceberg.Reference("x"), 34.56), iceberg.EqualTo(iceberg.Reference("y"), 34.56))),
+			iceberg.NewAnd(iceberg.NotEqualTo(iceberg.Reference("x"), 34.56), iceberg.NotEqualTo(iceberg.Reference("y"), 34.56))},
+		{iceberg.NewNot(iceberg.AlwaysFalse{}), iceberg.AlwaysTrue{}},
+		{iceberg.NewNot(iceberg.AlwaysTrue{}), iceberg.AlwaysFalse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			out, err := iceberg.RewriteNotExpr(tt.expr)
+			require.NoError(t, err)
+			assert.True(t, out.Equals(tt.expected))
+		})
+	}
+}
+
+func TestBindExpr(t *testing.T) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "foo", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 2, Name: "bar", Type: iceberg.PrimitiveTypes.String, Required: true})
+
+	bound, err := iceberg.BindExpr(sc, iceberg.NewAnd(
+		iceberg.EqualTo(iceberg.Reference("foo"), int32(1)),
+		iceberg.NewNot(iceberg.IsNull(iceberg.Reference("bar")))), true)
+	require.NoError(t, err)
+
+	pred, ok := bound.(iceberg.BoundPredicate)
+	require.True(t, ok)
+	assert.Equal(t, iceberg.OpEQ, pred.Op())
+	assert.Equal(t, 0, pred.Ref().Pos())
+
+	_, err = iceberg.BindExpr(sc, iceberg.EqualTo(iceberg.Reference("baz"), int32(1)), true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	_, err = iceberg.BindExpr(sc, bound, true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func int32Bytes(v int32) *[]byte {
+	out := binary.LittleEndian.AppendUint32(nil, uint32(v))
+	return &out
+}
+
+func strBytes(v string) *[]byte {
+	out := []byte(v)
+	return &out
+}
+
+var (
+	manifestEvalSchema = iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "all_nulls", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 4, Name: "float", Type: iceberg.PrimitiveTypes.Float64},
+	)
+
+	manifestEvalSpec = iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id_part", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "data_part", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "all_nulls_part", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1003, Name: "float_part", Transform: iceberg.IdentityTransform{}},
+	)
+)
+
+func TestManifestEvaluator(t *testing.T) {
+	yes, no := true, false
+
+	manifest := iceberg.NewManifestV2Builder("manifest.avro", 1024, 0,
+		iceberg.ManifestContentData, 1).Partitions([]iceberg.FieldSummary{
+		{ContainsNull: false, LowerBound: int32Bytes(30), UpperBound: int32Bytes(79)},
+		{ContainsNull: true, ContainsNaN: &no, LowerBound: strBytes("a"), UpperBound: strBytes("abd")},
+		{ContainsNull: true, ContainsNaN: &no},
+		{ContainsNull: false, ContainsNaN: &yes},
+	}).Build()
+
+	type ref = iceberg.Reference
+	tests := []struct {
+		expr     iceberg.BooleanExpression
+		expected bool
+	}{
+		{iceberg.AlwaysTrue{}, true},
+		{iceberg.AlwaysFalse{}, false},
+		{iceberg.EqualTo(ref("id_part"), int32(29)), false},
+		{iceberg.EqualTo(ref("id_part"), int32(30)), true},
+		{iceberg.EqualTo(ref("id_part"), int32(79)), true},
+		{iceberg.EqualTo(ref("id_part"), int32(80)), false},
+		{iceberg.NotEqualTo(ref("id_part"), int32(30)), true},
+		{iceberg.LessThan(ref("id_part"), int32(30)), false},
+		{iceberg.LessThan(ref("id_part"), int32(31)), true},
+		{iceberg.LessThanEqual(ref("id_part"), int32(29)), false},
+		{iceberg.LessThanEqual(ref("id_part"), int32(30)), true},
+		{iceberg.GreaterThan(ref("id_part"), int32(79)), false},
+		{iceberg.GreaterThan(ref("id_part"), int32(78)), true},
+		{iceberg.GreaterThanEqual(ref("id_part"), int32(80)), false},
+		{iceberg.GreaterThanEqual(ref("id_part"), int32(79)), true},
+		{iceberg.IsIn(ref("id_part"), int32(24), int32(25)), false},
+		{iceberg.IsIn(ref("id_part"), int32(80), int32(81)), false},
+		{iceberg.IsIn(ref("id_part"), int32(25), int32(50)), true},
+		{iceberg.NotIn(ref("id_part"), int32(24), int32(25)), true},
+		{iceberg.NewNot(iceberg.LessThan(ref("id_part"), int32(80))), false},
+		{iceberg.NewNot(iceberg.LessThan(ref("id_part"), int32(30))), true},
+		{iceberg.NewAnd(iceberg.LessThan(ref("id_part"), int32(30)),
+			iceberg.GreaterThanEqual(ref("id_part"), int32(79))), false},
+		{iceberg.NewOr(iceberg.LessThan(ref("id_part"), int32(30)),
+			iceberg.GreaterThanEqual(ref("id_part"), int32(79))), true},
+		{iceberg.NotNull(ref("id_part")), true},
+		{iceberg.IsNull(ref("data_part")), true},
+		{iceberg.NotNull(ref("all_nulls_part")), false},
+		{iceberg.IsNull(ref("all_nulls_part")), true},
+		{iceberg.EqualTo(ref("all_nulls_part"), "a"), false},
+		{iceberg.LessThan(ref("all_nulls_part"), "a"), false},
+		{iceberg.GreaterThan(ref("all_nulls_part"), "a"), false},
+		{iceberg.StartsWith(ref("all_nulls_part"), "a"), false},
+		{iceberg.StartsWith(ref("data_part"), "a"), true},
+		{iceberg.StartsWith(ref("data_part"), "ab"), true},
+		{iceberg.StartsWith(ref("data_part"), "abd"), true},
+		{iceberg.StartsWith(ref("data_part"), "abe"), false},
+		{iceberg.StartsWith(ref("data_part"), "b"), false},
+		{iceberg.NotStartsWith(ref("data_part"), "a"), true},
+		{iceberg.IsNaN(ref("data_part")), false},
+		{iceberg.IsNaN(ref("float_part")), true},
+		{iceberg.NotNaN(ref("float_part")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			eval, err := iceberg.NewManifestEvaluator(manifestEvalSpec,
+				manifestEvalSchema, tt.expr, true)
+			require.NoError(t, err)
+
+			result, err := eval(manifest)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("no partition summaries", func(t *testing.T) {
+		eval, err := iceberg.NewManifestEvaluator(manifestEvalSpec, manifestEvalSchema,
+			iceberg.EqualTo(ref("id_part"), int32(1)), true)
+		require.NoError(t, err)
+
+		result, err := eval(iceberg.NewManifestV2Builder("manifest.avro", 1024, 0,
+			iceberg.ManifestContentData, 1).Build())
+		require.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("case insensitive", func(t *testing.T) {
+		_, err := iceberg.NewManifestEvaluator(manifestEvalSpec, manifestEvalSchema,
+			iceberg.EqualTo(ref("ID_PART"), int32(1)), true)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+		eval, err := iceberg.NewManifestEvaluator(manifestEvalSpec, manifestEvalSchema,
+			iceberg.EqualTo(ref("ID_PART"), int32(1)), false)
+		require.NoError(t, err)
+
+		result, err := eval(manifest)
+		require.NoError(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("invalid bounds", func(t *testing.T) {
+		eval, err := iceberg.NewManifestEvaluator(manifestEvalSpec, manifestEvalSchema,
+			iceberg.EqualTo(ref("id_part"), int32(1)), true)
+		require.NoError(t, err)
+
+		_, err = eval(iceberg.NewManifestV2Builder("manifest.avro", 1024, 0,
+			iceberg.ManifestContentData, 1).Partitions([]iceberg.FieldSummary{
+			{LowerBound: strBytes("a"), UpperBound: strBytes("b")},
+		}).Build())
+		assert.ErrorIs(t, err, iceberg.ErrBadLiteral)
+	})
+}
+
+// randomManifests builds manifests partitioned by an int32 identity
+// partition, returning the manifests along with the actual partition
+// values (nil for null) contained in each.
+func randomManifests(r *rand.Rand, n int) ([]iceberg.ManifestFile, [][]*int32) {
+	manifests := make([]iceberg.ManifestFile, n)
+	values := make([][]*int32, n)
+	for i := range manifests {
+		var (
+			summary iceberg.FieldSummary
+			lower   int32
+			upper   int32
+			hasVal  bool
+		)
+
+		count := 1 + r.Intn(5)
+		for j := 0; j < count; j++ {
+			if r.Intn(10) == 0 {
+				summary.ContainsNull = true
+				values[i] = append(values[i], nil)
+				continue
+			}
+
+			v := int32(r.Intn(200) - 100)
+			values[i] = append(values[i], &v)
+			if !hasVal || v < lower {
+				lower = v
+			}
+			if !hasVal || v > upper {
+				upper = v
+			}
+			hasVal = true
+		}
+
+		if hasVal {
+			summary.LowerBound, summary.UpperBound = int32Bytes(lower), int32Bytes(upper)
+		}
+
+		manifests[i] = iceberg.NewManifestV2Builder("manifest-"+strconv.Itoa(i)+".avro",
+			1024, 0, iceberg.ManifestContentData, 1).
+			Partitions([]iceberg.FieldSummary{summary}).Build()
+	}
+	return manifests, values
+}
+
+func TestManifestEvaluatorNeverPrunesMatches(t *testing.T) {
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_part", Transform: iceberg.IdentityTransform{}})
+	schema := iceberg.NewSchema(0, iceberg.NestedField{
+		ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32})
+
+	type pred struct {
+		expr  iceberg.BooleanExpression
+		match func(*int32) bool
+	}
+
+	r := rand.New(rand.NewSource(42))
+	manifests, values := randomManifests(r, 500)
+	ref := iceberg.Reference("id_part")
+
+	for i := 0; i < 200; i++ {
+		a, b := int32(r.Intn(240)-120), int32(r.Intn(240)-120)
+		preds := []pred{
+			{iceberg.EqualTo(ref, a), func(v *int32) bool { return v != nil && *v == a }},
+			{iceberg.NotEqualTo(ref, a), func(v *int32) bool { return v != nil && *v != a }},
+			{iceberg.LessThan(ref, a), func(v *int32) bool { return v != nil && *v < a }},
+			{iceberg.LessThanEqual(ref, a), func(v *int32) bool { return v != nil && *v <= a }},
+			{iceberg.GreaterThan(ref, a), func(v *int32) bool { return v != nil && *v > a }},
+			{iceberg.GreaterThanEqual(ref, a), func(v *int32) bool { return v != nil && *v >= a }},
+			{iceberg.IsIn(ref, a, b), func(v *int32) bool { return v != nil && (*v == a || *v == b) }},
+			{iceberg.NotIn(ref, a, b), func(v *int32) bool { return v != nil && *v != a && *v != b }},
+			{iceberg.IsNull(ref), func(v *int32) bool { return v == nil }},
+			{iceberg.NotNull(ref), func(v *int32) bool { return v != nil }},
+			{iceberg.NewAnd(iceberg.GreaterThanEqual(ref, a), iceberg.LessThanEqual(ref, b)),
+				func(v *int32) bool { return v != nil && *v >= a && *v <= b }},
+			{iceberg.NewOr(iceberg.LessThan(ref, a), iceberg.GreaterThan(ref, b)),
+				func(v *int32) bool { return v != nil && (*v < a || *v > b) }},
+			{iceberg.NewNot(iceberg.GreaterThan(ref, a)),
+				func(v *int32) bool { return v != nil && *v <= a }},
+		}
+
+		for _, p := range preds {
+			eval, err := iceberg.NewManifestEvaluator(spec, schema, p.expr, true)
+			require.NoError(t, err)
+
+			for m, manifest := range manifests {
+				result, err := eval(manifest)
+				require.NoError(t, err)
+				if result {
+					continue
+				}
+
+				for _, v := range values[m] {
+					require.Falsef(t, p.match(v),
+						"manifest %d was pruned by %s but contains a matching value", m, p.expr)
+				}
+			}
+		}
+	}
+}
+
+func TestManifestEvaluatorLargeInSet(t *testing.T) {
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id", Transform: iceberg.IdentityTransform{}})
+	schema := iceberg.NewSchema(0, iceberg.NestedField{
+		ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32})
+
+	// the even numbers from 0 to 19998, in a shuffled order
+	values := make([]int32, 10000)
+	for i, j := range rand.New(rand.NewSource(1)).Perm(len(values)) {
+		values[i] = int32(j * 2)
+	}
+
+	inEval, err := iceberg.NewManifestEvaluator(spec, schema,
+		iceberg.IsIn(iceberg.Reference("id"), values...), true)
+	require.NoError(t, err)
+	notInEval, err := iceberg.NewManifestEvaluator(spec, schema,
+		iceberg.NotIn(iceberg.Reference("id"), values...), true)
+	require.NoError(t, err)
+
+	manifest := func(lower, upper int32) iceberg.ManifestFile {
+		return iceberg.NewManifestV2Builder("manifest.avro", 1024, 0,
+			iceberg.ManifestContentData, 1).Partitions([]iceberg.FieldSummary{{
+			LowerBound: int32Bytes(lower), UpperBound: int32Bytes(upper)}}).Build()
+	}
+
+	tests := []struct {
+		lower, upper int32
+		expected     bool
+	}{
+		{-10, -1, false},
+		{-10, 0, true},
+		{1, 1, false},
+		{3, 3, false},
+		{1, 2, true},
+		{5001, 5001, false},
+		{5001, 5003, true},
+		{19998, 30000, true},
+		{19999, 30000, false},
+		{-100, 30000, true},
+	}
+
+	for _, tt := range tests {
+		result, err := inEval(manifest(tt.lower, tt.upper))
+		require.NoError(t, err)
+		assert.Equalf(t, tt.expected, result, "bounds [%d, %d]", tt.lower, tt.upper)
+
+		result, err = notInEval(manifest(tt.lower, tt.upper))
+		require.NoError(t, err)
+		assert.True(t, result)
+	}
+
+	// the result matches a scan of the values for bounds which partially
+	// overlap the set
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		lower := rng.Int31n(22000) - 1000
+		upper := lower + rng.Int31n(4)
+
+		expected := false
+		for _, v := range values {
+			if v >= lower && v <= upper {
+				expected = true
+				break
+			}
+		}
+
+		result, err := inEval(manifest(lower, upper))
+		require.NoError(t, err)
+		require.Equalf(t, expected, result, "bounds [%d, %d]", lower, upper)
+	}
+}
+
+func BenchmarkManifestEvaluatorLargeInSet(b *testing.B) {
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id", Transform: iceberg.IdentityTransform{}})
+	schema := iceberg.NewSchema(0, iceberg.NestedField{
+		ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+
+	values := make([]int64, 10000)
+	for i := range values {
+		values[i] = int64(i) * 1000
+	}
+
+	eval, err := iceberg.NewManifestEvaluator(spec, schema,
+		iceberg.IsIn(iceberg.Reference("id"), values...), true)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	int64Bytes := func(v int64) *[]byte {
+		out := binary.LittleEndian.AppendUint64(nil, uint64(v))
+		return &out
+	}
+
+	// half of the manifests have bounds between the values of the set
+	manifests := make([]iceberg.ManifestFile, 1000)
+	for i := range manifests {
+		lower := int64(i) * 10000
+		if i%2 == 1 {
+			lower++
+		}
+		manifests[i] = iceberg.NewManifestV2Builder("manifest-"+strconv.Itoa(i)+".avro",
+			1024, 0, iceberg.ManifestContentData, 1).
+			Partitions([]iceberg.FieldSummary{{
+				LowerBound: int64Bytes(lower), UpperBound: int64Bytes(lower + 998),
+			}}).Build()
+	}
+
+	b.ResetTimer()
+	var pruned int
+	for n := 0; n < b.N; n++ {
+		pruned = 0
+		for _, m := range manifests {
+			ok, err := eval(m)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if !ok {
+				pruned++
+			}
+		}
+	}
+
+	b.ReportMetric(float64(pruned), "manifests_pruned/op")
+}
+
+func BenchmarkManifestEvaluator(b *testing.B) {
+	const numManifests = 5000
+
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "day", Transform: iceberg.IdentityTransform{}})
+	schema := iceberg.NewSchema(0, iceberg.NestedField{
+		ID: 1, Name: "day", Type: iceberg.PrimitiveTypes.Int32})
+
+	// each manifest covers a week of days, with neighbouring manifests
+	// overlapping so that a selective filter matches a handful of them
+	manifests := make([]iceberg.ManifestFile, numManifests)
+	for i := range manifests {
+		manifests[i] = iceberg.NewManifestV2Builder("manifest-"+strconv.Itoa(i)+".avro",
+			1024, 0, iceberg.ManifestContentData, 1).
+			Partitions([]iceberg.FieldSummary{{
+				LowerBound: int32Bytes(int32(i)), UpperBound: int32Bytes(int32(i + 7)),
+			}}).Build()
+	}
+
+	eval, err := iceberg.NewManifestEvaluator(spec, schema,
+		iceberg.EqualTo(iceberg.Reference("day"), int32(numManifests/2)), true)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	var opened int
+	for n := 0; n < b.N; n++ {
+		opened = 0
+		for _, m := range manifests {
+			ok, err := eval(m)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if ok {
+				opened++
+			}
+		}
+	}
+
+	b.ReportMetric(float64(opened), "manifests_opened/op")
+	b.ReportMetric(float64(numManifests-opened), "manifests_pruned/op")
+}